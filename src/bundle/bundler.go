@@ -1,26 +1,33 @@
 package bundle
 
 import (
+	"context"
 	crypto_rand "crypto/rand"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"math/big"
 	"math/rand"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	irysResponses "github.com/warp-contracts/syncer/src/utils/bundlr/responses"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/price_cache"
 	"github.com/warp-contracts/syncer/src/utils/task"
 	"github.com/warp-contracts/syncer/src/utils/tool"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 	"github.com/warp-contracts/syncer/src/utils/turbo"
 	turboResponses "github.com/warp-contracts/syncer/src/utils/turbo/responses"
 
 	"github.com/jackc/pgtype"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
@@ -28,22 +35,24 @@ type Bundler struct {
 	*task.Task
 	rand    *rand.Rand
 	db      *gorm.DB
-	input   chan *model.BundleItem
+	input   chan *Payload
 	monitor monitoring.Monitor
 
+	// Latency from the sequencer block till the upload
+	freshness *freshness.Stage
+
 	// Bundling and signing
 	irysClient  *bundlr.Client
 	turboClient *turbo.Client
-	signer      *bundlr.ArweaveSigner
+	priceCache  *price_cache.PriceCache
+	signer      bundlr.Signer
 
 	// Ids of successfully bundled interactions
 	Output chan *Confirmation
 }
 
 // Receives bundle items from the input channel and sends them to bundlr
-func NewBundler(config *config.Config, db *gorm.DB) (self *Bundler) {
-	var err error
-
+func NewBundler(config *config.Config, db *gorm.DB) (self *Bundler, err error) {
 	self = new(Bundler)
 	self.db = db
 
@@ -57,6 +66,8 @@ func NewBundler(config *config.Config, db *gorm.DB) (self *Bundler) {
 
 	self.Output = make(chan *Confirmation)
 
+	self.freshness = freshness.NewStage(config, freshness.PipelineL2, freshness.StageBundle)
+
 	self.Task = task.NewTask(config, "bundler").
 		// Pool of workers that perform requests to bundlr.
 		// It's possible to run multiple requests in parallel.
@@ -64,9 +75,11 @@ func NewBundler(config *config.Config, db *gorm.DB) (self *Bundler) {
 		WithWorkerPool(config.Bundler.BundlerNumBundlingWorkers, config.Bundler.WorkerPoolQueueSize).
 		WithSubtaskFunc(self.run)
 
-	self.signer, err = bundlr.NewArweaveSigner(config.Bundlr.Wallet)
+	// Remote signer fetches the owner here, it may be unreachable
+	self.signer, err = bundlr.NewSigner(config.Bundlr.Wallet, bundlr.SignatureTypeArweave)
 	if err != nil {
-		self.Log.WithError(err).Panic("Failed to create bundlr signer")
+		self.Log.WithError(err).Error("Failed to create bundlr signer")
+		return
 	}
 
 	return
@@ -82,7 +95,12 @@ func (self *Bundler) WithTurboClient(client *turbo.Client) *Bundler {
 	return self
 }
 
-func (self *Bundler) WithInputChannel(in chan *model.BundleItem) *Bundler {
+func (self *Bundler) WithPriceCache(v *price_cache.PriceCache) *Bundler {
+	self.priceCache = v
+	return self
+}
+
+func (self *Bundler) WithInputChannel(in chan *Payload) *Bundler {
 	self.input = in
 	return self
 }
@@ -92,14 +110,14 @@ func (self *Bundler) WithMonitor(monitor monitoring.Monitor) *Bundler {
 	return self
 }
 
-func (self *Bundler) upload(dataItem *model.BundleItem, item *bundlr.BundleItem) (response []byte, resp *resty.Response, id string, err error) {
+func (self *Bundler) upload(ctx context.Context, dataItem *model.BundleItem, item *bundlr.BundleItem) (response []byte, resp *resty.Response, id string, price int64, err error) {
 	switch model.BundlingService(dataItem.Service.String) {
 	case model.BundlingServiceTurbo:
 		var (
 			uploadResponse *turboResponses.Upload
 		)
 
-		uploadResponse, resp, err = self.turboClient.Upload(self.Ctx, item)
+		uploadResponse, resp, err = self.turboClient.Upload(ctx, item)
 		if err != nil {
 			if resp != nil {
 				self.Log.WithError(err).
@@ -135,12 +153,19 @@ func (self *Bundler) upload(dataItem *model.BundleItem, item *bundlr.BundleItem)
 
 		id = uploadResponse.Id
 
+		// Turbo returns the price in the response
+		price, err = bundlr.ParseWinc(uploadResponse.Winc)
+		if err != nil {
+			self.Log.WithError(err).WithField("id", dataItem.InteractionID).Warn("Failed to parse price from Turbo response")
+			err = nil
+		}
+
 	case model.BundlingServiceIrys:
 		var (
 			uploadResponse *irysResponses.Upload
 		)
 
-		uploadResponse, resp, err = self.irysClient.Upload(self.Ctx, item)
+		uploadResponse, resp, err = self.irysClient.Upload(ctx, item)
 		if err != nil {
 			if resp != nil {
 				self.Log.WithError(err).
@@ -176,6 +201,13 @@ func (self *Bundler) upload(dataItem *model.BundleItem, item *bundlr.BundleItem)
 
 		id = uploadResponse.Id
 
+		// Irys doesn't return the price, it's checked separately and cached
+		price, err = self.priceCache.GetIrysPrice(ctx, item.Size())
+		if err != nil {
+			self.Log.WithError(err).WithField("id", dataItem.InteractionID).Warn("Failed to get price from Irys")
+			err = nil
+		}
+
 	default:
 		err = errors.New("Unknown bundling service")
 		self.Log.WithError(err).WithField("service", dataItem.Service).Error("Unknown bundling service")
@@ -215,19 +247,24 @@ func (self *Bundler) run() (err error) {
 				return
 			}
 
-			bundleItem, err := self.createDataItem(item)
+			bundleItem, err := self.createDataItem(item.BundleItem)
 			if err != nil {
 				return
 			}
 
 			// Pick random bundling service
-			err = self.setBundleProvider(item)
+			err = self.setBundleProvider(item.BundleItem)
 			if err != nil {
 				return
 			}
 
 			// Send the bundle
-			uploadResponse, resp, id, err := self.upload(item, bundleItem)
+			ctx, span := item.Trace.StartSpan(self.Ctx, self.Name,
+				trace.WithAttributes(
+					attribute.Int("interaction_id", item.InteractionID),
+					attribute.String("service", item.Service.String)))
+			uploadResponse, resp, id, price, err := self.upload(ctx, item.BundleItem, bundleItem)
+			tracing.End(span, err)
 			if err != nil {
 				if resp != nil {
 					self.Log.WithError(err).
@@ -284,16 +321,25 @@ func (self *Bundler) run() (err error) {
 			}
 			self.monitor.GetReport().Bundler.State.AllSuccess.Inc()
 
+			if key := strconv.Itoa(item.InteractionID); self.freshness.IsSampled(key) {
+				self.freshness.Record(key, item.GetSequencerTimestamp())
+			}
+
 			// Save the response
-			select {
-			case <-self.Ctx.Done():
-				return
-			case self.Output <- &Confirmation{
+			confirmation := &Confirmation{
 				InteractionID: item.InteractionID,
 				BundlerTxID:   id,
 				Response:      pgtype.JSONB{Bytes: uploadResponse, Status: pgtype.Present},
 				Service:       item.Service,
-			}:
+				Size:          int64(bundleItem.Size()),
+				Price:         price,
+			}
+			confirmation.Trace.InheritFrom(&item.Trace)
+
+			select {
+			case <-self.Ctx.Done():
+				return
+			case self.Output <- confirmation:
 			}
 		})
 
@@ -363,28 +409,13 @@ func (self *Bundler) createBundle(item *model.BundleItem) (bundleItem *bundlr.Bu
 }
 
 func (self *Bundler) createNestedBundle(item *model.BundleItem) (bundleItem *bundlr.BundleItem, err error) {
-	// Parse bundle item
-	nestedBundle := new(bundlr.BundleItem)
-	err = nestedBundle.Unmarshal(item.DataItem.Bytes)
+	// Nested data item is streamed from its encoded form, only its header gets parsed
+	nestedBundle, err := bundlr.NewNestedBundleDataSource(bundlr.NewBytesDataSource(item.DataItem.Bytes))
 	if err != nil {
-		self.Log.WithError(err).WithField("id", item.InteractionID).Error("Failed to unmarshal nested bundle item")
+		self.Log.WithError(err).WithField("id", item.InteractionID).Error("Failed to parse nested bundle item")
 		return
 	}
 
-	// err = nestedBundle.Verify()
-	// if err != nil {
-	// 	self.Log.WithError(err).WithField("id", item.InteractionID).Error("Failed to verify nested bundle item")
-	// 	return
-	// }
-
-	// err = nestedBundle.VerifySignature()
-	// if err != nil {
-	// 	self.Log.WithError(err).WithField("id", item.InteractionID).Error("Failed to verify nested bundle item signature")
-	// 	return
-	// }
-
-	// self.Log.WithField("nested", nestedBundle.String()).Debug("Nested bundle item")
-
 	bundleItem = new(bundlr.BundleItem)
 
 	// Tags stored in the bundle_items table
@@ -415,11 +446,7 @@ func (self *Bundler) createNestedBundle(item *model.BundleItem) (bundleItem *bun
 	bundleItem.Tags = append(bundleItem.Tags, tags...)
 
 	// Nest the bundle
-	err = bundleItem.NestBundles([]*bundlr.BundleItem{nestedBundle})
-	if err != nil {
-		self.Log.WithError(err).WithField("id", item.InteractionID).Error("Failed to nest bundle")
-		return
-	}
+	bundleItem.DataSource = nestedBundle
 
 	return
 }
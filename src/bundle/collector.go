@@ -2,7 +2,6 @@ package bundle
 
 import (
 	"github.com/warp-contracts/syncer/src/utils/config"
-	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
 
@@ -17,7 +16,7 @@ type Collector struct {
 	poller   *Poller
 
 	// Data about the interactions that need to be bundled
-	Output chan *model.BundleItem
+	Output chan *Payload
 }
 
 // Sets up the task of fetching interactions to be bundled
@@ -27,7 +26,7 @@ type Collector struct {
 func NewCollector(config *config.Config, db *gorm.DB) (self *Collector) {
 	self = new(Collector)
 
-	self.Output = make(chan *model.BundleItem, 100)
+	self.Output = make(chan *Payload, 100)
 
 	self.notifier = NewNotifier(config).
 		WithDB(db).
@@ -2,6 +2,7 @@ package bundle
 
 import (
 	"cmp"
+	"context"
 	"database/sql"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -9,8 +10,11 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/jackc/pgtype"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/slices"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -30,6 +34,10 @@ type Confirmation struct {
 	BundlerTxID   string
 	Response      pgtype.JSONB
 	Service       pgtype.Text
+	Size          int64
+	Price         int64
+
+	Trace tracing.Carrier
 }
 
 func NewConfirmer(config *config.Config) (self *Confirmer) {
@@ -63,17 +71,30 @@ func (self *Confirmer) WithNetworkMonitor(v *listener.NetworkMonitor) *Confirmer
 	return self
 }
 
-func (self *Confirmer) save(confirmations []*Confirmation) error {
+func (self *Confirmer) save(confirmations []*Confirmation) (err error) {
 	if len(confirmations) == 0 {
 		// Nothing to save
 		return nil
 	}
 
+	// Save handles many confirmations, their traces are linked.
+	// Context isn't cancelled upon stopping, so the last batch gets saved
+	links := make([]trace.Link, 0, len(confirmations))
+	for _, confirmation := range confirmations {
+		links = append(links, tracing.Links(&confirmation.Trace)...)
+	}
+	ctx, span := tracing.Start(context.Background(), "confirmer.save",
+		trace.WithLinks(links...),
+		trace.WithAttributes(attribute.Int("confirmations", len(confirmations))))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	self.Log.WithField("len", len(confirmations)).Trace("Saving confirmations to DB")
 
 	// Sort confirmations by interaction ID to minimize deadlocks
 	slices.SortFunc(confirmations, func(a, b *Confirmation) int {
-		 return cmp.Compare(a.InteractionID, b.InteractionID)
+		return cmp.Compare(a.InteractionID, b.InteractionID)
 	})
 
 	// Prepare ids
@@ -89,7 +110,7 @@ func (self *Confirmer) save(confirmations []*Confirmation) error {
 	// Uses one transaction to do all the updates
 	// NOTE: It still uses many requests to the database,
 	// it should be possible to combine updates into batches, but it's not a priority for now.
-	err := self.db.Transaction(func(tx *gorm.DB) (err error) {
+	err = self.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
 		err = tx.Table(model.TableInteraction).
 			Select("1").
 			Where("id IN ?", ids).
@@ -107,8 +128,11 @@ func (self *Confirmer) save(confirmations []*Confirmation) error {
 			return
 		}
 
+		// Ids of bundle items that changed state in this transaction, costs are counted only once
+		uploadedIds := make([]int, 0, len(confirmations))
+
 		for _, confirmation := range confirmations {
-			err := tx.Table(model.TableBundleItem).
+			result := tx.Table(model.TableBundleItem).
 				Where("interaction_id = ?", confirmation.InteractionID).
 				Where("state = ?", model.BundleStateUploading).
 				Updates(model.BundleItem{
@@ -116,10 +140,14 @@ func (self *Confirmer) save(confirmations []*Confirmation) error {
 					BlockHeight:    sql.NullInt64{Int64: currentBlockHeight, Valid: true},
 					BundlrResponse: confirmation.Response,
 					Service:        confirmation.Service,
-				}).
-				Error
-			if err != nil {
-				return err
+					Size:           sql.NullInt64{Int64: confirmation.Size, Valid: confirmation.Size > 0},
+					Price:          sql.NullInt64{Int64: confirmation.Price, Valid: confirmation.Price > 0},
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				uploadedIds = append(uploadedIds, confirmation.InteractionID)
 			}
 
 			err = tx.Table(model.TableInteraction).
@@ -130,7 +158,23 @@ func (self *Confirmer) save(confirmations []*Confirmation) error {
 				return err
 			}
 		}
-		return nil
+
+		if len(uploadedIds) == 0 {
+			return nil
+		}
+
+		// Daily costs per service and per contract
+		return tx.Exec(`INSERT INTO bundling_costs (day, service, contract_id, uploads, size, price)
+			SELECT (NOW() AT TIME ZONE 'UTC')::date, b.service, i.contract_id, count(1), COALESCE(sum(b.size), 0), COALESCE(sum(b.price), 0)
+			FROM bundle_items b
+			JOIN interactions i ON i.id = b.interaction_id
+			WHERE b.interaction_id IN ? AND b.service IS NOT NULL
+			GROUP BY b.service, i.contract_id
+			ON CONFLICT (day, service, contract_id) DO UPDATE SET
+				uploads = bundling_costs.uploads + EXCLUDED.uploads,
+				size = bundling_costs.size + EXCLUDED.size,
+				price = bundling_costs.price + EXCLUDED.price`, uploadedIds).
+			Error
 	})
 	if err != nil {
 		self.Log.WithError(err).Error("Failed to save bundle items, retrying...")
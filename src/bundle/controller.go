@@ -1,13 +1,15 @@
 package bundle
 
 import (
-	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/balance_monitor"
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/listener"
-	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_bundler "github.com/warp-contracts/syncer/src/utils/monitoring/bundler"
+	"github.com/warp-contracts/syncer/src/utils/price_cache"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 	"github.com/warp-contracts/syncer/src/utils/turbo"
 )
@@ -21,27 +23,27 @@ type Controller struct {
 // |               |
 // |               |
 // | +-----------+ |
-// | |  Poller   | |             +----------+         +-----------+                +-----------------+
-// | +-----------+ |     tx      |          |   pd    |           |  network_info  |                 |
-// |               +------------>| Bundler  +-------->| Confirmer |<-------------- | Network Monitor |
-// | +-----------+ |             |          |         |           |                |                 |
-// | |  Notifier | |             +----------+         +-----------+                +-----------------+
+// | |  Poller   | |             +-----------+          +----------+         +-----------+                +-----------------+
+// | +-----------+ |     tx      |           |    tx    |          |   pd    |           |  network_info  |                 |
+// |               +------------>| Scheduler +--------->| Bundler  +-------->| Confirmer |<-------------- | Network Monitor |
+// | +-----------+ |             |           |          |          |         |           |                |                 |
+// | |  Notifier | |             +-----------+          +----------+         +-----------+                +-----------------+
 // | +-----------+ |
 // |               |
 // +---------------+
 // Main class that orchestrates main syncer functionalities
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "bundle-controller")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "bundler")
+	db, err := resources.GetDB(self.Ctx, config, "bundler")
 	if err != nil {
 		return
 	}
 
 	// Arweave client
-	arweaveClient := arweave.NewClient(self.Ctx, config)
+	arweaveClient := resources.GetArweaveClient(self.Ctx, config)
 
 	// Bundlr client
 	irysClient := bundlr.NewClient(self.Ctx, &config.Bundlr)
@@ -49,8 +51,7 @@ func NewController(config *config.Config) (self *Controller, err error) {
 
 	// Monitoring
 	monitor := monitor_bundler.NewMonitor()
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "bundle", monitor)
 
 	// Gets interactions to bundle from the database
 	collector := NewCollector(config, db).
@@ -64,12 +65,26 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithRequiredConfirmationBlocks(0).
 		WithEnableOutput(false /*disable output channel to avoid blocking*/)
 
-	// Sends interactions to bundlr.network
-	bundler := NewBundler(config, db).
+	// Divides items into priority lanes and passes them on fairly between contracts
+	scheduler := NewScheduler(config).
 		WithInputChannel(collector.Output).
+		WithMonitor(monitor)
+
+	// Irys prices, refreshed in the background
+	priceCache := price_cache.NewPriceCache(config).
+		WithIrysClient(irysClient)
+
+	// Sends interactions to bundlr.network
+	bundler, err := NewBundler(config, db)
+	if err != nil {
+		return
+	}
+	bundler.
+		WithInputChannel(scheduler.Output).
 		WithMonitor(monitor).
 		WithIrysClient(irysClient).
-		WithTurboClient(turboClient)
+		WithTurboClient(turboClient).
+		WithPriceCache(priceCache)
 
 	// Confirmer periodically updates the state of the bundled interactions
 	confirmer := NewConfirmer(config).
@@ -78,19 +93,41 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithNetworkMonitor(networkMonitor).
 		WithInputChannel(bundler.Output)
 
+	// Checks if there's enough funds in the bundling wallet
+	balanceMonitor := balance_monitor.NewBalanceMonitor(config).
+		WithIrysClient(irysClient).
+		WithTurboClient(turboClient).
+		WithMonitor(monitor)
+
 	// Periodically run queries. Results stored in the monitor.
+	// Alert rules may need the polled values regardless of the bundling mode
+	isPollingCount := (!config.Bundler.NotifierDisabled && config.Bundler.PollerDisabled) || config.Alerting.UsesValue("bundler.state.pending_bundle_items")
+	isPollingAge := config.Alerting.UsesValue("bundler.state.pending_bundle_items_age")
 	dbPoller := monitoring.NewDbPoller(config).
-		WithDB(db).
-		WithQuery(config.Bundler.DBPollerInterval, &monitor.GetReport().Bundler.State.PendingBundleItems, "SELECT count(1) FROM bundle_items WHERE state='PENDING'")
+		WithDB(db)
+	if isPollingCount {
+		dbPoller.WithQuery(config.Bundler.DBPollerInterval, &monitor.GetReport().Bundler.State.PendingBundleItems, "SELECT count(1) FROM bundle_items WHERE state='PENDING'")
+	}
+	if isPollingAge {
+		dbPoller.WithQuery(config.Bundler.DBPollerInterval, &monitor.GetReport().Bundler.State.PendingBundleItemsAge, "SELECT COALESCE(EXTRACT(EPOCH FROM NOW() - MIN(updated_at)), 0)::bigint FROM bundle_items WHERE state='PENDING'")
+	}
+
+	// Saves sampled latencies for the slo command
+	recorder := freshness.NewRecorder(config).
+		WithDB(db)
 
 	// Setup everything, will start upon calling Controller.Start()
 	self.Task.
-		WithConditionalSubtask(!config.Bundler.NotifierDisabled && config.Bundler.PollerDisabled, dbPoller.Task).
+		WithConditionalSubtask(isPollingCount || isPollingAge, dbPoller.Task).
 		WithSubtask(confirmer.Task).
 		WithSubtask(bundler.Task).
 		WithSubtask(monitor.Task).
 		WithSubtask(networkMonitor.Task).
-		WithSubtask(server.Task).
-		WithSubtask(collector.Task)
+		WithConditionalSubtask(!config.Bundlr.BalanceMonitorDisabled, balanceMonitor.Task).
+		WithConditionalSubtask(config.Bundlr.IrysSendProbability > 0, priceCache.Task).
+		WithConditionalSubtask(server != nil, server).
+		WithSubtask(scheduler.Task).
+		WithSubtask(collector.Task).
+		WithConditionalSubtask(config.Freshness.Enabled, recorder.Task)
 	return
 }
@@ -2,6 +2,7 @@ package bundle
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/model"
@@ -17,11 +18,12 @@ type Notifier struct {
 	*task.Task
 	db *gorm.DB
 
-	streamer *streamer.Streamer
-	monitor  monitoring.Monitor
+	streamer   *streamer.Streamer
+	replicator *streamer.Replicator
+	monitor    monitoring.Monitor
 
 	// Data about the interactions that need to be bundled
-	output chan *model.BundleItem
+	output chan *Payload
 }
 
 func NewNotifier(config *config.Config) (self *Notifier) {
@@ -32,6 +34,18 @@ func NewNotifier(config *config.Config) (self *Notifier) {
 		return
 	}
 
+	if config.Replication.Enabled {
+		// Live source of inserted bundle items, with all columns
+		self.replicator = streamer.NewReplicator(config, "bundler").
+			WithTables(model.TableBundleItem).
+			WithCapacity(10)
+
+		self.Task = task.NewTask(config, "notifier").
+			WithSubtask(self.replicator.Task).
+			WithSubtaskFunc(self.runReplication)
+		return
+	}
+
 	self.streamer = streamer.NewStreamer(config, "bundler-notifier").
 		WithNotificationChannelName("bundle_items_pending").
 		WithCapacity(10)
@@ -57,7 +71,7 @@ func (self *Notifier) WithMonitor(monitor monitoring.Monitor) *Notifier {
 	return self
 }
 
-func (self *Notifier) WithOutputChannel(bundleItems chan *model.BundleItem) *Notifier {
+func (self *Notifier) WithOutputChannel(bundleItems chan *Payload) *Notifier {
 	self.output = bundleItems
 	return self
 }
@@ -84,6 +98,7 @@ func (self *Notifier) run() error {
 
 				bundleItem := model.BundleItem{
 					InteractionID: notification.InteractionID,
+					Priority:      notification.Priority,
 				}
 				if notification.Transaction != nil || notification.DataItem != nil {
 					if notification.Transaction != nil {
@@ -122,7 +137,7 @@ func (self *Notifier) run() error {
 				select {
 				case <-self.StopChannel:
 					return
-				case self.output <- &bundleItem:
+				case self.output <- newPayload(self.Ctx, self.Name, &bundleItem):
 				}
 
 				// Update metrics
@@ -148,3 +163,67 @@ func (self *Notifier) run() error {
 		}
 	}
 }
+
+// Takes pending bundle items from the replication stream, like the notification trigger does.
+// Items already taken by the poller are skipped.
+func (self *Notifier) runReplication() (err error) {
+	for {
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case change, ok := <-self.replicator.Output:
+			if !ok {
+				self.Log.Info("Replicator channel closed")
+				return nil
+			}
+
+			err = self.handleChange(change)
+			if err != nil {
+				return
+			}
+
+			self.replicator.Ack(change)
+		}
+	}
+}
+
+func (self *Notifier) handleChange(change *streamer.Change) (err error) {
+	state, _ := change.Get("state")
+	if change.Operation != streamer.OperationInsert || state != string(model.BundleStatePending) {
+		return
+	}
+
+	var bundleItem model.BundleItem
+	err = change.Scan(&bundleItem)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to scan bundle item")
+		return nil
+	}
+
+	result := self.db.WithContext(self.Ctx).
+		Model(&model.BundleItem{}).
+		Where("interaction_id = ?", bundleItem.InteractionID).
+		Where("state = ?", model.BundleStatePending).
+		Update("state", model.BundleStateUploading)
+	if result.Error != nil {
+		// Bundle item will be picked up by the poller
+		self.Log.WithError(result.Error).Error("Failed to update bundle item state")
+		self.monitor.GetReport().Bundler.Errors.AdditionalFetchError.Inc()
+		return nil
+	}
+	if result.RowsAffected == 0 {
+		// Poller already took it
+		return nil
+	}
+	bundleItem.State = model.BundleStateUploading
+	select {
+	case <-self.Ctx.Done():
+		return errors.New("notifier stopped")
+	case self.output <- newPayload(self.Ctx, self.Name, &bundleItem):
+	}
+
+	// Update metrics
+	self.monitor.GetReport().Bundler.State.BundlesFromNotifications.Inc()
+
+	return nil
+}
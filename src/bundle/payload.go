@@ -0,0 +1,30 @@
+package bundle
+
+import (
+	"context"
+
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Bundle item passed between the stages of the bundler.
+// Trace isn't a part of the database model, so it's carried alongside.
+type Payload struct {
+	*model.BundleItem
+
+	// Spans of the stages that handled the item
+	Trace tracing.Carrier
+}
+
+// Bundle item's path through the pipeline starts when it's collected
+func newPayload(ctx context.Context, name string, item *model.BundleItem) (self *Payload) {
+	self = &Payload{BundleItem: item}
+	_, span := self.Trace.StartSpan(ctx, name,
+		trace.WithNewRoot(),
+		trace.WithAttributes(attribute.Int("interaction_id", item.InteractionID)))
+	span.End()
+	return
+}
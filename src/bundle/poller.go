@@ -22,7 +22,7 @@ type Poller struct {
 	monitor monitoring.Monitor
 
 	// Data about the interactions that need to be bundled
-	output chan *model.BundleItem
+	output chan *Payload
 }
 
 func NewPoller(config *config.Config) (self *Poller) {
@@ -45,7 +45,7 @@ func (self *Poller) WithDB(db *gorm.DB) *Poller {
 	return self
 }
 
-func (self *Poller) WithOutputChannel(bundleItems chan *model.BundleItem) *Poller {
+func (self *Poller) WithOutputChannel(bundleItems chan *Payload) *Poller {
 	self.output = bundleItems
 	return self
 }
@@ -59,18 +59,41 @@ func (self *Poller) handleNewTransactions() (repeat bool, err error) {
 	ctx, cancel := context.WithTimeout(self.Ctx, self.Config.Bundler.PollerTimeout)
 	defer cancel()
 
-	// Inserts interactions that weren't yet bundled into bundle_items table
+	// Takes a window of the oldest pending items and picks a batch that's fair between contracts:
+	// - high priority items (explicit priority, allowlisted contract or tag) go first,
+	// - then contracts take turns, each contract contributes its oldest items.
 	var bundleItems []model.BundleItem
 	err = self.db.WithContext(ctx).
-		Raw(`UPDATE bundle_items
-			SET state = 'UPLOADING'::bundle_state, updated_at = NOW()
-			WHERE interaction_id IN (SELECT interaction_id
-				FROM bundle_items
-				WHERE state = 'PENDING'::bundle_state
-				ORDER BY interaction_id ASC
+		Raw(`WITH candidates AS (
+				SELECT b.interaction_id, i.contract_id,
+					(b.priority > 0
+						OR COALESCE(i.contract_id IN ?, FALSE)
+						OR EXISTS (SELECT 1
+							FROM jsonb_array_elements(CASE WHEN jsonb_typeof(b.tags) = 'array' THEN b.tags ELSE '[]'::jsonb END) AS t
+							WHERE COALESCE((t->>'name') || '=' || (t->>'value') IN ?, FALSE))
+					) AS is_priority
+				FROM bundle_items b
+				JOIN interactions i ON i.id = b.interaction_id
+				WHERE b.state = 'PENDING'::bundle_state
+				ORDER BY b.priority DESC, b.interaction_id ASC
 				LIMIT ?
-				FOR UPDATE SKIP LOCKED)
-			RETURNING *`, self.Config.Bundler.PollerMaxBatchSize).
+				FOR UPDATE OF b SKIP LOCKED
+			), selected AS (
+				SELECT interaction_id
+				FROM (SELECT interaction_id, is_priority, ROW_NUMBER() OVER (PARTITION BY contract_id ORDER BY interaction_id ASC) AS turn
+					FROM candidates) AS ranked
+				ORDER BY is_priority DESC, turn ASC, interaction_id ASC
+				LIMIT ?
+			)
+			UPDATE bundle_items
+			SET state = 'UPLOADING'::bundle_state, updated_at = NOW()
+			FROM selected
+			WHERE bundle_items.interaction_id = selected.interaction_id
+			RETURNING bundle_items.*, (SELECT contract_id FROM interactions WHERE id = bundle_items.interaction_id) AS contract_id`,
+			self.Config.Bundler.Scheduler.PriorityContracts,
+			self.Config.Bundler.Scheduler.PriorityTags,
+			max(self.Config.Bundler.Scheduler.PollerFairnessWindow, self.Config.Bundler.PollerMaxBatchSize),
+			self.Config.Bundler.PollerMaxBatchSize).
 		Scan(&bundleItems).Error
 
 	if err != nil {
@@ -90,7 +113,7 @@ func (self *Poller) handleNewTransactions() (repeat bool, err error) {
 		select {
 		case <-self.StopChannel:
 			return
-		case self.output <- &bundleItems[i]:
+		case self.output <- newPayload(self.Ctx, self.Name, &bundleItems[i]):
 		}
 
 		// Update metrics
@@ -146,7 +169,7 @@ func (self *Poller) handleRetrying() (repeat bool, err error) {
 		select {
 		case <-self.StopChannel:
 			return
-		case self.output <- &bundleItems[i]:
+		case self.output <- newPayload(self.Ctx, self.Name, &bundleItems[i]):
 		}
 
 		// Update metrics
@@ -0,0 +1,104 @@
+package bundle
+
+import (
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+const (
+	LaneHigh = iota
+	LaneNormal
+)
+
+// Puts bundle items into priority lanes and passes them to the bundler fairly between contracts.
+// Prevents a backlog of one contract from delaying everyone else.
+type Scheduler struct {
+	*task.Scheduler[*Payload]
+
+	monitor monitoring.Monitor
+
+	priorityContracts map[string]struct{}
+	priorityTags      map[string]struct{}
+}
+
+func NewScheduler(config *config.Config) (self *Scheduler) {
+	self = new(Scheduler)
+
+	self.priorityContracts = make(map[string]struct{}, len(config.Bundler.Scheduler.PriorityContracts))
+	for _, contractId := range config.Bundler.Scheduler.PriorityContracts {
+		self.priorityContracts[contractId] = struct{}{}
+	}
+
+	self.priorityTags = make(map[string]struct{}, len(config.Bundler.Scheduler.PriorityTags))
+	for _, tag := range config.Bundler.Scheduler.PriorityTags {
+		self.priorityTags[tag] = struct{}{}
+	}
+
+	self.Scheduler = task.NewScheduler[*Payload](config, "scheduler").
+		WithCapacity(config.Bundler.Scheduler.QueueSize).
+		WithLaneWeights(config.Bundler.Scheduler.HighLaneWeight, config.Bundler.Scheduler.NormalLaneWeight).
+		WithKeyRateLimit(config.Bundler.Scheduler.ContractRateLimit, config.Bundler.Scheduler.ContractRateBurst).
+		WithGetLane(self.getLane).
+		WithGetKey(func(item *Payload) string {
+			return item.GetContractId()
+		})
+
+	self.Scheduler.Task = self.Scheduler.Task.
+		WithPeriodicSubtaskFunc(time.Second, self.updateMetrics)
+
+	return
+}
+
+func (self *Scheduler) WithInputChannel(v chan *Payload) *Scheduler {
+	self.Scheduler.WithInputChannel(v)
+	return self
+}
+
+func (self *Scheduler) WithMonitor(monitor monitoring.Monitor) *Scheduler {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Scheduler) getLane(item *Payload) int {
+	if self.isPriority(item) {
+		self.monitor.GetReport().Bundler.State.PriorityItems.Inc()
+		return LaneHigh
+	}
+	return LaneNormal
+}
+
+func (self *Scheduler) isPriority(item *Payload) bool {
+	if item.Priority > 0 {
+		return true
+	}
+
+	if _, ok := self.priorityContracts[item.GetContractId()]; ok {
+		return true
+	}
+
+	if len(self.priorityTags) == 0 {
+		return false
+	}
+
+	tags, err := item.GetTags()
+	if err != nil {
+		return false
+	}
+	for _, tag := range tags {
+		if _, ok := self.priorityTags[tag.Name+"="+tag.Value]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (self *Scheduler) updateMetrics() error {
+	state := &self.monitor.GetReport().Bundler.State
+	state.HighLaneQueueSize.Store(int64(self.GetLaneSize(LaneHigh)))
+	state.NormalLaneQueueSize.Store(int64(self.GetLaneSize(LaneNormal)))
+	return nil
+}
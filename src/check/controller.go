@@ -1,13 +1,12 @@
 package check
 
 import (
-	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/listener"
-	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_checker "github.com/warp-contracts/syncer/src/utils/monitoring/checker"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 	"github.com/warp-contracts/syncer/src/utils/turbo"
 )
@@ -17,24 +16,23 @@ type Controller struct {
 }
 
 // Main class that orchestrates everything
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "checker-controller")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "checker")
+	db, err := resources.GetDB(self.Ctx, config, "checker")
 	if err != nil {
 		return
 	}
 
 	// Arweave client
-	client := arweave.NewClient(self.Ctx, config)
+	client := resources.GetArweaveClient(self.Ctx, config)
 
 	// Monitoring
 	monitor := monitor_checker.NewMonitor()
 
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "check", monitor)
 
 	// Bundlr client
 	irysClient := bundlr.NewClient(self.Ctx, &config.Bundlr)
@@ -67,13 +65,18 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithInputChannel(checker.Output).
 		WithMonitor(monitor)
 
+	// Saves sampled latencies for the slo command
+	recorder := freshness.NewRecorder(config).
+		WithDB(db)
+
 	// Setup everything, will start upon calling Controller.Start()
 	self.Task.
-		WithSubtask(server.Task).
+		WithConditionalSubtask(server != nil, server).
 		WithSubtask(store.Task).
 		WithSubtask(networkMonitor.Task).
 		WithSubtask(monitor.Task).
 		WithSubtask(poller.Task).
-		WithSubtask(checker.Task)
+		WithSubtask(checker.Task).
+		WithConditionalSubtask(config.Freshness.Enabled, recorder.Task)
 	return
 }
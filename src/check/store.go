@@ -1,9 +1,11 @@
 package check
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
@@ -15,8 +17,9 @@ import (
 // SinkTask handles caching data and periodically calling flush function
 type Store struct {
 	*task.Hole[*Payload]
-	db      *gorm.DB
-	monitor monitoring.Monitor
+	db        *gorm.DB
+	monitor   monitoring.Monitor
+	freshness *freshness.Stage
 }
 
 func NewStore(config *config.Config) (self *Store) {
@@ -27,6 +30,9 @@ func NewStore(config *config.Config) (self *Store) {
 		WithBatchSize(50).
 		WithBackoff(10*time.Minute, 10*time.Second)
 
+	// Latency from the sequencer block till the interaction is confirmed on Arweave
+	self.freshness = freshness.NewStage(config, freshness.PipelineL2, freshness.StageOnArweave)
+
 	return
 }
 
@@ -97,5 +103,35 @@ func (self *Store) flush(payloads []*Payload) error {
 	// Update monitoring
 	self.monitor.GetReport().Checker.State.DbStateUpdated.Inc()
 
+	self.observeFreshness(bundleItemIds)
+
 	return nil
 }
+
+// Sequencer timestamps are only in the tags, so they're fetched for sampled items only
+func (self *Store) observeFreshness(bundleItemIds []int) {
+	sampled := make([]int, 0, len(bundleItemIds))
+	for _, id := range bundleItemIds {
+		if self.freshness.IsSampled(strconv.Itoa(id)) {
+			sampled = append(sampled, id)
+		}
+	}
+	if len(sampled) == 0 {
+		return
+	}
+
+	var bundleItems []*model.BundleItem
+	err := self.db.WithContext(self.Ctx).
+		Select("interaction_id", "tags").
+		Where("interaction_id IN ?", sampled).
+		Find(&bundleItems).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Warn("Failed to get tags of confirmed bundle items")
+		return
+	}
+
+	for _, bundleItem := range bundleItems {
+		self.freshness.Record(strconv.Itoa(bundleItem.InteractionID), bundleItem.GetSequencerTimestamp())
+	}
+}
@@ -15,7 +15,7 @@ var bundleCmd = &cobra.Command{
 	Use:   "bundle",
 	Short: "Download new interactions and bundle them together in Arweave",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := bundle.NewController(conf)
+		controller, err := bundle.NewController(conf, nil)
 		if err != nil {
 			return
 		}
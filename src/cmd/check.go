@@ -15,7 +15,7 @@ var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Updates bundle status after it's in the FINALIZED state in bundlr.network",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := check.NewController(conf)
+		controller, err := check.NewController(conf, nil)
 		if err != nil {
 			return
 		}
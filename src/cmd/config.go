@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+
+	"github.com/spf13/cobra"
+)
+
+var configValidateMode string
+
+func init() {
+	configValidateCmd.Flags().StringVar(&configValidateMode, "mode", "", "Validate only sections used by this mode, one of: "+strings.Join(config.GetModes(), ", "))
+
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	RootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspects and validates the configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Prints the effective configuration (file, env and defaults merged) with secrets redacted",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+		return printJSON(conf.Redacted())
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Checks ranges and relations between configuration values",
+	// Errors point to the configuration, not to the usage
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+
+		err = conf.Validate(configValidateMode)
+		if err != nil {
+			return
+		}
+
+		fmt.Println("Configuration is valid")
+		return
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Prints JSON schema of the configuration file",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+		return printJSON(config.Schema())
+	},
+}
+
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
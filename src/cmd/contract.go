@@ -23,7 +23,7 @@ var (
 		Use:   "contract",
 		Short: "Synchronizes contracts from L1. Src and init state as well",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			controller, err := contract.NewController(conf, startBlockHeight, stopBlockHeight, replaceExistingData)
+			controller, err := contract.NewController(conf, nil, startBlockHeight, stopBlockHeight, replaceExistingData)
 			if err != nil {
 				return
 			}
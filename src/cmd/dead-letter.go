@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/warp-contracts/syncer/src/contract"
+	"github.com/warp-contracts/syncer/src/forward"
+	"github.com/warp-contracts/syncer/src/relay"
+	"github.com/warp-contracts/syncer/src/sync"
+	"github.com/warp-contracts/syncer/src/utils/deadletter"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/publisher"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	deadLetterSource          string
+	deadLetterStage           string
+	deadLetterFile            string
+	deadLetterLimit           int
+	deadLetterIncludeReplayed bool
+	deadLetterIds             []int64
+)
+
+func init() {
+	deadLetterCmd.PersistentFlags().StringVar(&deadLetterFile, "file", "", "Read dead letters from this NDJSON file instead of the database, ids are line numbers")
+	deadLetterListCmd.Flags().StringVar(&deadLetterSource, "source", "", "Only dead letters from this mode, e.g. syncer")
+	deadLetterListCmd.Flags().StringVar(&deadLetterStage, "stage", "", "Only dead letters from this stage, e.g. store")
+	deadLetterListCmd.Flags().IntVar(&deadLetterLimit, "limit", 100, "Max number of listed dead letters")
+	deadLetterListCmd.Flags().BoolVar(&deadLetterIncludeReplayed, "include-replayed", false, "List also dead letters that were already replayed")
+	deadLetterReplayCmd.Flags().Int64SliceVar(&deadLetterIds, "id", nil, "Ids of the replayed dead letters")
+	_ = deadLetterReplayCmd.MarkFlagRequired("id")
+
+	deadLetterCmd.AddCommand(deadLetterListCmd)
+	deadLetterCmd.AddCommand(deadLetterReplayCmd)
+	RootCmd.AddCommand(deadLetterCmd)
+}
+
+var deadLetterCmd = &cobra.Command{
+	Use:   "dead-letter",
+	Short: "Inspects and replays items that pipeline stages failed to process",
+}
+
+var deadLetterListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Prints dead letters as NDJSON, newest first",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+
+		var deadLetters []*model.DeadLetter
+		if deadLetterFile != "" {
+			deadLetters, err = readDeadLetterFile()
+			if deadLetterLimit > 0 && len(deadLetters) > deadLetterLimit {
+				deadLetters = deadLetters[:deadLetterLimit]
+			}
+		} else {
+			var replayer *deadletter.Replayer
+			replayer, err = newDeadLetterReplayer()
+			if err != nil {
+				return
+			}
+			deadLetters, err = replayer.List(applicationCtx, deadLetterSource, deadLetterStage, deadLetterIncludeReplayed, deadLetterLimit)
+		}
+		if err != nil {
+			return
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		for _, deadLetter := range deadLetters {
+			err = encoder.Encode(deadLetter)
+			if err != nil {
+				return
+			}
+		}
+		return
+	},
+}
+
+var deadLetterReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Passes dead letters again through their stage. Replayed items from the database are marked",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+
+		replayer, err := newDeadLetterReplayer()
+		if err != nil {
+			return
+		}
+
+		if deadLetterFile == "" {
+			return replayer.ReplayIds(applicationCtx, deadLetterIds)
+		}
+
+		deadLetters, err := readDeadLetterFile()
+		if err != nil {
+			return
+		}
+
+		replayed := 0
+		for _, deadLetter := range deadLetters {
+			if !slices.Contains(deadLetterIds, deadLetter.Id) {
+				continue
+			}
+			err = replayer.Replay(applicationCtx, deadLetter)
+			if err != nil {
+				return
+			}
+			replayed++
+		}
+		if replayed != len(deadLetterIds) {
+			return fmt.Errorf("found %d of %d dead letters", replayed, len(deadLetterIds))
+		}
+		return
+	},
+}
+
+// Stages whose failed items can be replayed
+func newDeadLetterReplayer() (replayer *deadletter.Replayer, err error) {
+	db, err := model.NewConnection(applicationCtx, conf, "dead-letter")
+	if err != nil {
+		return
+	}
+
+	replayer = deadletter.NewReplayer(db).
+		WithHandler("syncer", "store", sync.ReplayInteraction).
+		WithHandler("relayer", "store", relay.ReplayPayload).
+		WithHandler("contract", "store-contract", contract.ReplayContractData)
+
+	// Batches rejected by webhooks are sent again to the same endpoint
+	for i := range conf.Webhook {
+		replayer.
+			WithHandler("contract", fmt.Sprintf(contract.WebhookPublisherName, i), publisher.ReplayWebhookBatch(conf.Webhook[i])).
+			WithHandler("forwarder", fmt.Sprintf(forward.WebhookPublisherName, i), publisher.ReplayWebhookBatch(conf.Webhook[i]))
+	}
+	return
+}
+
+// Newest first, like in the database
+func readDeadLetterFile() (out []*model.DeadLetter, err error) {
+	out, err = deadletter.ReadFile(deadLetterFile)
+	if err != nil {
+		return
+	}
+
+	filtered := out[:0]
+	for _, deadLetter := range out {
+		if (deadLetterSource == "" || deadLetter.Source == deadLetterSource) &&
+			(deadLetterStage == "" || deadLetter.Stage == deadLetterStage) {
+			filtered = append(filtered, deadLetter)
+		}
+	}
+	slices.Reverse(filtered)
+	return filtered, nil
+}
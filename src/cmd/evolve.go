@@ -15,7 +15,7 @@ var evolveCmd = &cobra.Command{
 	Use:   "evolve",
 	Short: "Indexing new contract sources based on evolve interactions",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := evolve.NewController(conf)
+		controller, err := evolve.NewController(conf, nil)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/warp-contracts/syncer/src/forward"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayPublisher    string
+	replayAfterSortKey string
+	replayUntilSortKey string
+)
+
+func init() {
+	forwardReplayCmd.PersistentFlags().StringVar(&replayPublisher, "publisher", "", "Name of the forwarder's publisher, e.g. interaction-redis-publisher-0")
+	forwardReplayCmd.PersistentFlags().StringVar(&replayAfterSortKey, "after-sort-key", "", "Replay interactions after this sort key (exclusive)")
+	forwardReplayCmd.PersistentFlags().StringVar(&replayUntilSortKey, "until-sort-key", "", "Replay interactions up to this sort key (inclusive)")
+	forwardReplayCmd.PersistentFlags().Uint64Var(&startBlockHeight, "start", 0, "Start block height")
+	forwardReplayCmd.PersistentFlags().Uint64Var(&stopBlockHeight, "stop", 0, "Stop block height (inclusive)")
+	_ = forwardReplayCmd.MarkPersistentFlagRequired("publisher")
+	RootCmd.AddCommand(forwardReplayCmd)
+}
+
+var forwardReplayCmd = &cobra.Command{
+	Use:   "forward-replay",
+	Short: "Re-publishes interactions from a sort key or block height range to one of the forwarder's publishers",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		controller, err := forward.NewReplayController(conf, replayPublisher, replayAfterSortKey, replayUntilSortKey, startBlockHeight, stopBlockHeight)
+		if err != nil {
+			return
+		}
+
+		err = controller.Start()
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-controller.CtxRunning.Done():
+		case <-applicationCtx.Done():
+		}
+
+		controller.StopWait()
+
+		return
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) (err error) {
+		log := logger.NewSublogger("root-cmd")
+		log.Debug("Finished forward-replay command")
+		applicationCtxCancel()
+		return
+	},
+}
@@ -15,7 +15,7 @@ var forwardCmd = &cobra.Command{
 	Use:   "forward",
 	Short: "Forwards interactions to Redis. This is the single point of joining L1 and L2 interactiopns.",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := forward.NewController(conf)
+		controller, err := forward.NewController(conf, nil)
 		if err != nil {
 			return
 		}
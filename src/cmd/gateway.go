@@ -15,7 +15,7 @@ var gatewayCmd = &cobra.Command{
 	Use:   "gateway",
 	Short: "Serves various info from the database",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := gateway.NewController(conf)
+		controller, err := gateway.NewController(conf, nil)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"github.com/warp-contracts/syncer/src/relay"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveDir string
+
+func init() {
+	relayArchiveCmd.PersistentFlags().StringVar(&archiveDir, "dir", "relay-archive", "Directory with archive segments")
+	relayArchiveCmd.PersistentFlags().Uint64Var(&startBlockHeight, "start", 0, "Start sequencer block height")
+	relayArchiveCmd.PersistentFlags().Uint64Var(&stopBlockHeight, "stop", 0, "Stop sequencer block height (inclusive)")
+	relayArchiveCmd.AddCommand(relayArchiveExportCmd)
+	relayArchiveCmd.AddCommand(relayArchiveImportCmd)
+	RootCmd.AddCommand(relayArchiveCmd)
+}
+
+var (
+	relayArchiveCmd = &cobra.Command{
+		Use:   "relay-archive",
+		Short: "Exports relayer's payloads to compressed files or imports them back to the database",
+	}
+
+	relayArchiveExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Downloads sequencer blocks from the range and writes processed payloads to the archive",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			controller, err := relay.NewArchiveExportController(conf, archiveDir, startBlockHeight, stopBlockHeight)
+			if err != nil {
+				return
+			}
+			return runArchiveController(controller)
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) (err error) {
+			log := logger.NewSublogger("root-cmd")
+			log.Debug("Finished relay-archive export command")
+			applicationCtxCancel()
+			return
+		},
+	}
+
+	relayArchiveImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Saves payloads from the archive to the database, like the relayer does",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			controller, err := relay.NewArchiveImportController(conf, archiveDir, startBlockHeight, stopBlockHeight)
+			if err != nil {
+				return
+			}
+			return runArchiveController(controller)
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) (err error) {
+			log := logger.NewSublogger("root-cmd")
+			log.Debug("Finished relay-archive import command")
+			applicationCtxCancel()
+			return
+		},
+	}
+)
+
+func runArchiveController(controller *relay.ArchiveController) (err error) {
+	err = controller.Start()
+	if err != nil {
+		return
+	}
+
+	select {
+	case <-controller.CtxRunning.Done():
+	case <-applicationCtx.Done():
+	}
+
+	controller.StopWait()
+
+	return controller.Err()
+}
@@ -15,7 +15,7 @@ var relayCmd = &cobra.Command{
 	Use:   "relay",
 	Short: "Sends interactions from Warp's Sequencer to Arweave",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := relay.NewController(conf)
+		controller, err := relay.NewController(conf, nil)
 		if err != nil {
 			return
 		}
@@ -2,12 +2,14 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/spf13/cobra"
 )
@@ -31,6 +33,10 @@ var (
 				return
 			}
 
+			// Reloaded configuration needs to be valid for this mode
+			config.SetReloadModes(cmd.Name())
+			conf = conf.WithMode(cmd.Name())
+
 			go func() {
 				select {
 				case <-signalChannel:
@@ -45,7 +51,16 @@ var (
 				return
 			}
 
-			// FIXME: Log configuration
+			// Setup exporting traces, no-op if disabled
+			err = tracing.Init(conf)
+			if err != nil {
+				return
+			}
+
+			// Log configuration, without secrets
+			if buf, err := json.Marshal(conf.Redacted()); err == nil {
+				logger.NewSublogger("root-cmd").WithField("config", string(buf)).Debug("Configuration loaded")
+			}
 
 			return
 		},
@@ -59,6 +74,11 @@ var (
 			// log := logger.NewSublogger("root-cmd")
 			<-applicationCtx.Done()
 			// log.Debug("Finished")
+
+			// Export remaining spans
+			ctx, cancel := context.WithTimeout(context.Background(), conf.Tracing.ExportTimeout)
+			defer cancel()
+			err = tracing.Shutdown(ctx)
 			return
 		},
 
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/supervisor"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+
+	"github.com/spf13/cobra"
+)
+
+var runModes []string
+
+func init() {
+	runCmd.PersistentFlags().StringSliceVar(&runModes, "modes", nil, "Comma separated modes to run, one of: "+strings.Join(supervisor.GetModes(), ", "))
+	RootCmd.AddCommand(runCmd)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Runs several modes in one process, sharing the DB connection, Arweave client and monitoring endpoint",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		config.SetReloadModes(runModes...)
+
+		controller, err := supervisor.NewController(conf, runModes)
+		if err != nil {
+			return
+		}
+
+		err = controller.Start()
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-controller.CtxRunning.Done():
+		case <-applicationCtx.Done():
+		}
+
+		controller.StopWait()
+
+		return
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) (err error) {
+		log := logger.NewSublogger("root-cmd")
+		log.Debug("Finished run command")
+		applicationCtxCancel()
+		return
+	},
+}
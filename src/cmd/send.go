@@ -15,7 +15,7 @@ var sendCmd = &cobra.Command{
 	Use:   "send",
 	Short: "Sendign data items to bundling service",
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		controller, err := send.NewController(conf)
+		controller, err := send.NewController(conf, nil)
 		if err != nil {
 			return
 		}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/warp-contracts/syncer/src/signer"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(signerCmd)
+}
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Signs data with keys kept outside of other processes",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		controller, err := signer.NewController(conf)
+		if err != nil {
+			return
+		}
+
+		err = controller.Start()
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-controller.CtxRunning.Done():
+		case <-applicationCtx.Done():
+		}
+
+		controller.StopWait()
+
+		return
+	},
+	PostRunE: func(cmd *cobra.Command, args []string) (err error) {
+		log := logger.NewSublogger("root-cmd")
+		log.Debug("Finished signer command")
+		applicationCtxCancel()
+		return
+	},
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/freshness"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	sloWindow time.Duration
+	sloJSON   bool
+)
+
+func init() {
+	sloCmd.Flags().DurationVar(&sloWindow, "window", 24*time.Hour, "Aggregate samples saved within this window")
+	sloCmd.Flags().BoolVar(&sloJSON, "json", false, "Print summaries as JSON instead of a table")
+	RootCmd.AddCommand(sloCmd)
+}
+
+var sloCmd = &cobra.Command{
+	Use:   "slo",
+	Short: "Reports freshness of L1 and L2 interactions per stage, from latencies sampled by all modes",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer applicationCtxCancel()
+
+		db, err := model.NewConnection(applicationCtx, conf, "slo")
+		if err != nil {
+			return
+		}
+
+		summaries, err := freshness.GetSummaries(applicationCtx, db, conf, time.Now().Add(-sloWindow))
+		if err != nil {
+			return
+		}
+
+		if sloJSON {
+			return printJSON(summaries)
+		}
+
+		fmt.Printf("Window: %s, objective: %.2f%% within %s (L1) and %s (L2)\n\n",
+			sloWindow, conf.Freshness.Objective*100, conf.Freshness.L1Target, conf.Freshness.L2Target)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PIPELINE\tSTAGE\tCOUNT\tP50\tP90\tP99\tWITHIN TARGET\tBURN RATE\tSINCE PREVIOUS P50\tSINCE PREVIOUS P99")
+		for _, s := range summaries {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%.2f%%\t%.2f\t%s\t%s\n",
+				s.Pipeline, s.Stage, s.Count,
+				s.P50.Round(time.Second), s.P90.Round(time.Second), s.P99.Round(time.Second),
+				s.WithinTarget*100, s.BurnRate,
+				s.SincePreviousP50.Round(time.Second), s.SincePreviousP99.Round(time.Second))
+		}
+		return w.Flush()
+	},
+}
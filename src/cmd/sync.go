@@ -19,7 +19,7 @@ var (
 		Use:   "sync",
 		Short: "Save L1 interactions to the database",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			controller, err := sync.NewController(conf, startBlockHeight, stopBlockHeight, replaceExistingData)
+			controller, err := sync.NewController(conf, nil, startBlockHeight, stopBlockHeight, replaceExistingData)
 			if err != nil {
 				return
 			}
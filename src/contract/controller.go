@@ -2,26 +2,29 @@ package contract
 
 import (
 	"fmt"
-	"time"
 
-	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/deadletter"
+	"github.com/warp-contracts/syncer/src/utils/leader"
 	"github.com/warp-contracts/syncer/src/utils/listener"
 	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_contract "github.com/warp-contracts/syncer/src/utils/monitoring/contract"
 	"github.com/warp-contracts/syncer/src/utils/peer_monitor"
 	"github.com/warp-contracts/syncer/src/utils/publisher"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 )
 
+// Publisher name, also used to select the dead-letter replay handler
+const WebhookPublisherName = "contract-webhook-publisher-%d"
+
 type Controller struct {
 	*task.Task
 }
 
 // Main class that orchestrates main syncer functionalities
 // Setups listening and storing interactions
-func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint64, replaceExisting bool) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources, startBlockHeight, stopBlockHeight uint64, replaceExisting bool) (self *Controller, err error) {
 	self = new(Controller)
 
 	self.Task = task.NewTask(config, "contract-controller")
@@ -29,16 +32,16 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 	monitor := monitor_contract.NewMonitor(config).
 		WithMaxHistorySize(30)
 
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "contract", monitor)
 
-	watched := func() *task.Task {
-		db, err := model.NewConnection(self.Ctx, self.Config, "contract")
-		if err != nil {
-			panic(err)
-		}
+	// Connection pool survives restarts of the watched task
+	db, err := resources.GetDB(self.Ctx, config, "contract")
+	if err != nil {
+		return
+	}
 
-		client := arweave.NewClient(self.Ctx, config)
+	watched := func() *task.Task {
+		client := resources.GetArweaveClient(self.Ctx, config)
 
 		peerMonitor := peer_monitor.NewPeerMonitor(config).
 			WithClient(client).
@@ -77,10 +80,14 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 			WithClient(client).
 			WithDB(db)
 
+		// Failed items, to be replayed with the dead-letter command
+		errorSink := deadletter.NewSink(config, db, "contract")
+
 		store := NewStore(config).
 			WithInputChannel(loader.Output).
 			WithReplaceExistingData(replaceExisting).
 			WithMonitor(monitor).
+			WithErrorSink(errorSink).
 			WithDB(db)
 
 		flattener := task.NewFlattener[*ContractData](config, "contract-flattener").
@@ -95,8 +102,9 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 		redisMapper := redisMapper(config).
 			WithInputChannel(duplicator.NextChannel())
 
-		redisDuplicator := task.NewDuplicator[*model.ContractNotification](config, "redis-duplicator").
-			WithOutputChannels(len(config.Redis), 0).
+		// Notifications go to all redis instances and webhooks
+		notificationDuplicator := task.NewDuplicator[*model.ContractNotification](config, "notification-duplicator").
+			WithOutputChannels(len(config.Redis)+len(config.Webhook), 0).
 			WithInputChannel(redisMapper.Output)
 
 		redisPublishers := make([]*task.Task, 0, len(config.Redis))
@@ -104,10 +112,20 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 			redisPublisher := publisher.NewRedisPublisher[*model.ContractNotification](config, config.Redis[i], fmt.Sprintf("contract-redis-publisher-%d", i)).
 				WithChannelName(config.Contract.PublisherRedisChannelName).
 				WithMonitor(monitor, i).
-				WithInputChannel(redisDuplicator.NextChannel())
+				WithInputChannel(notificationDuplicator.NextChannel())
 			redisPublishers = append(redisPublishers, redisPublisher.Task)
 		}
 
+		webhookPublishers := make([]*task.Task, 0, len(config.Webhook))
+		for i := range config.Webhook {
+			webhookPublisher := publisher.NewWebhookPublisher[*model.ContractNotification](config, config.Webhook[i], fmt.Sprintf(WebhookPublisherName, i)).
+				WithDB(db).
+				WithMonitor(monitor, i).
+				WithErrorSink(errorSink).
+				WithInputChannel(notificationDuplicator.NextChannel())
+			webhookPublishers = append(webhookPublishers, webhookPublisher.Task)
+		}
+
 		// Publish to AppSync
 		appSyncMapper := appSyncMapper(config, config.Contract.PublisherAppSyncChannelName).
 			WithInputChannel(duplicator.NextChannel())
@@ -117,7 +135,7 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 			WithInputChannel(appSyncMapper.Output)
 
 		return task.NewTask(config, "watched-contract").
-			WithSubtask(peerMonitor.Task).
+			WithConditionalSubtask(!resources.IsPeerMonitorShared(), peerMonitor.Task).
 			WithSubtask(networkMonitor.Task).
 			WithSubtask(blockDownloader.Task).
 			WithSubtask(transactionDownloader.Task).
@@ -125,24 +143,33 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 			WithSubtask(store.Task).
 			WithSubtask(flattener.Task).
 			WithSubtask(redisMapper.Task).
-			WithSubtask(redisDuplicator.Task).
+			WithSubtask(notificationDuplicator.Task).
 			WithSubtask(appSyncMapper.Task).
 			WithSubtask(duplicator.Task).
 			WithSubtaskSlice(redisPublishers).
+			WithSubtaskSlice(webhookPublishers).
 			WithSubtask(appSyncPublisher.Task)
 	}
 
 	watchdog := task.NewWatchdog(config).
 		WithTask(watched).
-		WithIsOK(30*time.Second, func() bool {
+		WithRunReport(monitor.GetReport().Run).
+		WithIsOK(config.Watchdog.CheckInterval, func() bool {
 			isOK := monitor.IsOK()
 			if !isOK {
 				monitor.Clear()
-				monitor.GetReport().Run.Errors.NumWatchdogRestarts.Inc()
 			}
 			return isOK
 		})
 
+	if config.LeaderElection.Enabled {
+		// Only the leader runs the pipeline, standby waits for the lease
+		elector := leader.WatchStandby(config, "contract", db, monitor, watchdog)
+		self.Task.
+			WithSubtask(elector.Task).
+			WithOnAfterStop(elector.Release)
+	}
+
 	self.Task = self.Task.
 		// WithOnBeforeStart(func() error {
 		// 	b, _ := json.Marshal(config)
@@ -150,7 +177,7 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 		// 	return nil
 		// }).
 		WithSubtask(monitor.Task).
-		WithSubtask(server.Task).
+		WithConditionalSubtask(server != nil, server).
 		WithSubtask(watchdog.Task)
 
 	return
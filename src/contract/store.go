@@ -1,6 +1,8 @@
 package contract
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -39,9 +41,17 @@ func NewStore(config *config.Config) (self *Store) {
 		WithOnProcess(self.process).
 		WithBackoff(config.Contract.StoreBackoffMaxElapsedTime, config.Contract.StoreBackoffMaxInterval)
 
+	// Batching is adjusted upon configuration file change
+	self.Processor.WithOnConfigChange(self.reload)
+
 	return
 }
 
+func (self *Store) reload(config *config.Config) {
+	self.SetBatchSize(config.Contract.StoreBatchSize)
+	self.SetFlushInterval(config.Contract.StoreInterval)
+}
+
 func (self *Store) WithMonitor(v monitoring.Monitor) *Store {
 	self.monitor = v
 	return self
@@ -52,6 +62,11 @@ func (self *Store) WithInputChannel(v chan *Payload) *Store {
 	return self
 }
 
+func (self *Store) WithErrorSink(v task.ErrorSink) *Store {
+	self.Processor = self.Processor.WithErrorSink(v)
+	return self
+}
+
 func (self *Store) WithReplaceExistingData(replace bool) *Store {
 	self.replaceExistingData = replace
 	return self
@@ -178,3 +193,37 @@ func (self *Store) flush(data []*ContractData) (out []*ContractData, err error)
 	out = data
 	return
 }
+
+// Saves a contract with its source that the store failed to save, used to replay dead letters
+func ReplayContractData(ctx context.Context, db *gorm.DB, payload []byte) (err error) {
+	var data ContractData
+	err = json.Unmarshal(payload, &data)
+	if err != nil {
+		return
+	}
+	if data.Contract == nil {
+		return errors.New("missing contract")
+	}
+
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			err := tx.Table(model.TableContract).
+				Clauses(clause.OnConflict{
+					DoNothing: true,
+					Columns:   []clause.Column{{Name: "contract_id"}},
+				}).
+				Create(data.Contract).
+				Error
+			if err != nil || data.Source == nil {
+				return err
+			}
+
+			return tx.Table(model.TableContractSource).
+				Clauses(clause.OnConflict{
+					DoNothing: true,
+					Columns:   []clause.Column{{Name: "src_tx_id"}},
+				}).
+				Create(data.Source).
+				Error
+		})
+}
@@ -1,11 +1,9 @@
 package evolve
 
 import (
-	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/config"
-	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_evolver "github.com/warp-contracts/syncer/src/utils/monitoring/evolver"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 )
 
@@ -13,23 +11,22 @@ type Controller struct {
 	*task.Task
 }
 
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "evolver")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "evolver")
+	db, err := resources.GetDB(self.Ctx, config, "evolver")
 	if err != nil {
 		return
 	}
 
 	// Arweave client
-	client := arweave.NewClient(self.Ctx, config)
+	client := resources.GetArweaveClient(self.Ctx, config)
 
 	// Monitoring
 	monitor := monitor_evolver.NewMonitor()
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "evolve", monitor)
 
 	// Gets new contract sources from the database
 	poller := NewPoller(config).
@@ -54,6 +51,6 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithSubtask(downloader.Task).
 		WithSubtask(store.Task).
 		WithSubtask(monitor.Task).
-		WithSubtask(server.Task)
+		WithConditionalSubtask(server != nil, server)
 	return
 }
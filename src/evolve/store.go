@@ -25,9 +25,17 @@ func NewStore(config *config.Config) (self *Store) {
 		WithOnFlush(config.Evolver.StoreInterval, self.flush).
 		WithBackoff(config.Evolver.StoreBackoffMaxElapsedTime, config.Evolver.StoreBackoffMaxInterval)
 
+	// Batching is adjusted upon configuration file change
+	self.Hole.WithOnConfigChange(self.reload)
+
 	return
 }
 
+func (self *Store) reload(config *config.Config) {
+	self.SetBatchSize(config.Evolver.StoreBatchSize)
+	self.SetFlushInterval(config.Evolver.StoreInterval)
+}
+
 func (self *Store) WithInputChannel(input chan *model.ContractSource) *Store {
 	self.Hole.WithInputChannel(input)
 	return self
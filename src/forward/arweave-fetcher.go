@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgtype"
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
@@ -22,7 +23,8 @@ type ArweaveFetcher struct {
 	*task.Task
 	db *gorm.DB
 
-	monitor monitoring.Monitor
+	monitor   monitoring.Monitor
+	freshness *freshness.Stage
 
 	input  chan uint64
 	Output chan *Payload
@@ -33,6 +35,9 @@ func NewArweaveFetcher(config *config.Config) (self *ArweaveFetcher) {
 
 	self.Output = make(chan *Payload, config.Forwarder.ArweaveFetcherQueueSize)
 
+	// Latency from the Arweave block till passing interactions to publishers
+	self.freshness = freshness.NewStage(config, freshness.PipelineL1, freshness.StageForward)
+
 	self.Task = task.NewTask(config, "arweave-fetcher").
 		WithSubtaskFunc(self.run)
 
@@ -156,6 +161,7 @@ func (self *ArweaveFetcher) run() (err error) {
 						isSkipSending = true
 						break
 					case self.Output <- payload:
+						self.freshness.Observe(interaction.InteractionId.Base64(), time.Unix(interaction.BlockTimestamp, 0))
 					}
 
 					// Increment L1 interaction counter
@@ -2,34 +2,44 @@ package forward
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/deadletter"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_forwarder "github.com/warp-contracts/syncer/src/utils/monitoring/forwarder"
 	"github.com/warp-contracts/syncer/src/utils/publisher"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 )
 
+// Publisher names, also used to select the publisher in the forward-replay command
+const (
+	RedisPublisherName           = "interaction-redis-publisher-%d"
+	WebhookPublisherName         = "interaction-webhook-publisher-%d"
+	AppSyncPublisherName         = "interaction-appsync-publisher"
+	AppSyncContractPublisherName = "interaction-appsync-contract-publisher"
+)
+
 type Controller struct {
 	*task.Task
 }
 
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "forwarder")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "forwarder")
+	db, err := resources.GetDB(self.Ctx, config, "forwarder")
 	if err != nil {
 		return
 	}
 
 	// Monitoring
 	monitor := monitor_forwarder.NewMonitor(config)
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "forward", monitor)
 
 	// Block height changes from sequencer
 	sequencer := NewSequencer(config).
@@ -44,6 +54,7 @@ func NewController(config *config.Config) (self *Controller, err error) {
 
 	// Gets L2 interactions (just the needed fields) through Postgres notifications, parses and passes further
 	interactionStreamer := NewInteractionStreamer(config).
+		WithDB(db).
 		WithMonitor(monitor)
 
 	// Joins L1 and L2 interactions.
@@ -52,75 +63,194 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithInputChannel(fetcher.Output).
 		WithInputChannel(interactionStreamer.Output)
 
+	// Each publisher gets its own copy of interactions
 	duplicator := task.NewDuplicator[*Payload](config, "interaction-duplicator").
-		WithOutputChannels(3, 0).
+		WithOutputChannels(len(config.Redis)+len(config.Webhook)+2, 0).
 		WithInputChannel(joiner.Output)
 
-	// Publish to all redis instances
-	redisMapper := redisMapper(config).
-		WithInputChannel(duplicator.NextChannel())
+	// Interactions are filtered before being mapped to notifications, separately for each publisher
+	filters := make([]*task.Task, 0, len(config.Redis)+len(config.Webhook)+2)
+	mappers := make([]*task.Task, 0, len(config.Redis)+len(config.Webhook)+2)
+
+	redisFilters := make([]*Filter, len(config.Redis))
+	redisMappers := make([]*task.Mapper[*Payload, *model.InteractionNotification], len(config.Redis))
+	for i := range config.Redis {
+		redisFilters[i] = NewFilter(config, fmt.Sprintf("interaction-redis-filter-%d", i)).
+			WithFilter(config.Redis[i].Filter).
+			WithMonitor(monitor).
+			WithInputChannel(duplicator.NextChannel())
+		redisMappers[i] = redisMapper(config).
+			WithInputChannel(redisFilters[i].Output)
+		filters = append(filters, redisFilters[i].Task)
+		mappers = append(mappers, redisMappers[i].Task)
+	}
+
+	webhookFilters := make([]*Filter, len(config.Webhook))
+	webhookMappers := make([]*task.Mapper[*Payload, *model.InteractionNotification], len(config.Webhook))
+	for i := range config.Webhook {
+		webhookFilters[i] = NewFilter(config, fmt.Sprintf("interaction-webhook-filter-%d", i)).
+			WithFilter(config.Webhook[i].Filter).
+			WithMonitor(monitor).
+			WithInputChannel(duplicator.NextChannel())
+		webhookMappers[i] = redisMapper(config).
+			WithInputChannel(webhookFilters[i].Output)
+		filters = append(filters, webhookFilters[i].Task)
+		mappers = append(mappers, webhookMappers[i].Task)
+	}
+
+	// First AppSync publisher sends to contract's channels, second to the common one
+	appSyncFilters := make([]*Filter, 2)
+	appSyncMappers := make([]*task.Mapper[*Payload, *publisher.AppSyncPayload[*model.InteractionNotification]], 2)
+	for i, forContract := range [2]bool{true, false} {
+		appSyncFilters[i] = NewFilter(config, fmt.Sprintf("interaction-appsync-filter-%d", i)).
+			WithFilter(config.Forwarder.PublisherAppSyncFilter).
+			WithMonitor(monitor).
+			WithInputChannel(duplicator.NextChannel())
+		appSyncMappers[i] = appSyncMapper(config, config.Forwarder.PublisherAppSyncChannelName, forContract).
+			WithInputChannel(appSyncFilters[i].Output)
+		filters = append(filters, appSyncFilters[i].Task)
+		mappers = append(mappers, appSyncMappers[i].Task)
+	}
+
+	// Filters are taken from the configuration file upon change
+	watchFilters(redisFilters, webhookFilters, appSyncFilters)
+
+	// Saves what each publisher delivered, interactions after that are replayed on start.
+	// Survives restarts of the publishers
+	var tracker publisher.DeliveryTracker
+	outbox := NewOutbox(config).
+		WithDB(db).
+		WithMonitor(monitor)
+	if config.Forwarder.OutboxEnabled {
+		tracker = outbox
+	}
+
+	// Replays what the publisher didn't deliver before it was (re)started
+	newReplayer := func(name string, filter *Filter) *Replayer[*model.InteractionNotification] {
+		outbox.Reset(name)
+		return NewReplayer[*model.InteractionNotification](config, name).
+			WithDB(db).
+			WithMonitor(monitor).
+			WithOutbox().
+			WithFilter(filter).
+			WithMapper(interactionNotification)
+	}
 
-	appSyncMapperInteraction := appSyncMapper(config, config.Forwarder.PublisherAppSyncChannelName, true).
-		WithInputChannel(duplicator.NextChannel())
-	appSyncMapperInteractionForContract := appSyncMapper(config, config.Forwarder.PublisherAppSyncChannelName, false).
-		WithInputChannel(duplicator.NextChannel())
-	appSyncMappers := [2]*task.Mapper[*Payload, *publisher.AppSyncPayload[*model.InteractionNotification]]{appSyncMapperInteraction, appSyncMapperInteractionForContract}
+	// Batches that webhooks won't accept, to be replayed with the dead-letter command
+	errorSink := deadletter.NewSink(config, db, "forwarder")
 
 	watched := func() *task.Task {
-		redisDuplicator := task.NewDuplicator[*model.InteractionNotification](config, "redis-duplicator").
-			WithOutputChannels(len(config.Redis), 0).
-			WithInputChannel(redisMapper.Output)
+		replayers := make([]*task.Task, 0)
 
-		redisPublishers := make([]*task.Task, 0, len(config.Redis))
-		for i := range config.Redis {
-			redisPublisher := publisher.NewRedisPublisher[*model.InteractionNotification](config, config.Redis[i], fmt.Sprintf("interaction-redis-publisher-%d", i)).
-				WithChannelName(config.Forwarder.PublisherRedisChannelName).
+		webhookPublishers := make([]*task.Task, 0, len(config.Webhook))
+		for i := range config.Webhook {
+			name := fmt.Sprintf(WebhookPublisherName, i)
+			webhookPublisher := publisher.NewWebhookPublisher[*model.InteractionNotification](config, config.Webhook[i], name).
+				WithDB(db).
 				WithMonitor(monitor, i).
-				WithDiscardWhenDisconnected(true).
-				WithInputChannel(redisDuplicator.NextChannel())
-			redisPublishers = append(redisPublishers, redisPublisher.Task)
+				WithDeliveryTracker(tracker).
+				WithErrorSink(errorSink).
+				WithInputChannel(webhookMappers[i].Output)
+			if config.Forwarder.OutboxEnabled {
+				replayer := newReplayer(name, webhookFilters[i])
+				webhookPublisher.WithReplayChannel(replayer.Output)
+				replayers = append(replayers, replayer.Task)
+			}
+			webhookPublishers = append(webhookPublishers, webhookPublisher.Task)
 		}
 
 		// Publish to AppSync
 		appSyncPublishers := make([]*task.Task, 0, 2)
+		for i, forContract := range [2]bool{true, false} {
+			name := AppSyncPublisherName
+			if forContract {
+				name = AppSyncContractPublisherName
+			}
 
-		for _, appSyncMapper := range appSyncMappers {
-			appSyncPublisher := publisher.NewAppSyncPublisher[*model.InteractionNotification](config, "interaction-appsync-publisher").
+			appSyncPublisher := publisher.NewAppSyncPublisher[*model.InteractionNotification](config, name).
 				WithMonitor(monitor).
-				WithInputChannel(appSyncMapper.Output)
+				WithDeliveryTracker(tracker).
+				WithInputChannel(appSyncMappers[i].Output)
+			if config.Forwarder.OutboxEnabled {
+				forContract := forContract
+				outbox.Reset(name)
+				replayer := NewReplayer[*publisher.AppSyncPayload[*model.InteractionNotification]](config, name).
+					WithDB(db).
+					WithMonitor(monitor).
+					WithOutbox().
+					WithFilter(appSyncFilters[i]).
+					WithMapper(func(data *Payload) (*publisher.AppSyncPayload[*model.InteractionNotification], error) {
+						return appSyncPayload(data, config.Forwarder.PublisherAppSyncChannelName, forContract)
+					})
+				appSyncPublisher.WithReplayChannel(replayer.Output)
+				replayers = append(replayers, replayer.Task)
+			}
 			appSyncPublishers = append(appSyncPublishers, appSyncPublisher.Task)
 		}
 
 		return task.NewTask(config, "watched").
-			WithSubtask(redisDuplicator.Task).
-			WithSubtaskSlice(redisPublishers).
-			WithSubtaskSlice(appSyncPublishers)
+			WithSubtaskSlice(webhookPublishers).
+			WithSubtaskSlice(appSyncPublishers).
+			WithSubtaskSlice(replayers)
 	}
 
 	watchdog := task.NewWatchdog(config).
 		WithTask(watched).
-		WithIsOK(30*time.Second, func() bool {
-			isOK := monitor.IsOK()
-			if !isOK {
-				monitor.Clear()
-				monitor.GetReport().Run.Errors.NumWatchdogRestarts.Inc()
+		WithRunReport(monitor.GetReport().Run).
+		WithIsOK(config.Watchdog.CheckInterval, func() bool {
+			return !monitor.IsFatalError.Load()
+		})
+
+	// Each Redis publisher is restarted on its own when it stops delivering, other publishers keep running
+	for i := range config.Redis {
+		i := i
+		name := fmt.Sprintf(RedisPublisherName, i)
+		var startTimestamp atomic.Int64
+
+		watchdog.WithSubtree(config.Watchdog.CheckInterval, func() *task.Task {
+			redisPublisher := publisher.NewRedisPublisher[*model.InteractionNotification](config, config.Redis[i], name).
+				WithChannelName(config.Forwarder.PublisherRedisChannelName).
+				WithMonitor(monitor, i).
+				WithDiscardWhenDisconnected(true).
+				WithDeliveryTracker(tracker).
+				WithInputChannel(redisMappers[i].Output)
+			subtree := task.NewTask(config, name+"-watched").
+				WithOnBeforeStart(func() error {
+					// Grace period counts from the start, not the construction
+					startTimestamp.Store(time.Now().Unix())
+					return nil
+				}).
+				WithSubtask(redisPublisher.Task)
+
+			if config.Forwarder.OutboxEnabled {
+				replayer := newReplayer(name, redisFilters[i])
+				redisPublisher.WithReplayChannel(replayer.Output)
+				subtree.WithSubtask(replayer.Task)
 			}
-			return isOK
+			return subtree
+		}, func() bool {
+			return monitor.IsRedisPublisherOK(i, startTimestamp.Load())
 		})
+	}
+
+	// Saves sampled latencies for the slo command
+	recorder := freshness.NewRecorder(config).
+		WithDB(db)
 
 	// Setup everything, will start upon calling Controller.Start()
 	self.Task.
 		WithSubtask(sequencer.Task).
 		WithSubtask(monitor.Task).
 		WithSubtask(joiner.Task).
-		WithSubtask(redisMapper.Task).
 		WithSubtask(fetcher.Task).
 		WithSubtask(watchdog.Task).
+		WithConditionalSubtask(config.Forwarder.OutboxEnabled, outbox.Task).
 		WithSubtask(interactionStreamer.Task).
-		WithSubtask(server.Task).
-		WithSubtask(appSyncMapperInteraction.Task).
-		WithSubtask(appSyncMapperInteractionForContract.Task).
-		WithSubtask(duplicator.Task)
+		WithConditionalSubtask(server != nil, server).
+		WithSubtaskSlice(filters).
+		WithSubtaskSlice(mappers).
+		WithSubtask(duplicator.Task).
+		WithConditionalSubtask(config.Freshness.Enabled, recorder.Task)
 
 	return
 }
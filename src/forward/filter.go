@@ -0,0 +1,214 @@
+package forward
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/smartweave"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Passes only interactions that match the publisher's filter, others are dropped.
+// Filter can be replaced while running.
+type Filter struct {
+	*task.Mapper[*Payload, *Payload]
+
+	monitor monitoring.Monitor
+
+	rules atomic.Pointer[filterRules]
+}
+
+// Filter's lists converted to sets
+type filterRules struct {
+	isEmpty bool
+
+	includeContracts map[string]struct{}
+	excludeContracts map[string]struct{}
+	includeSources   map[string]struct{}
+	excludeSources   map[string]struct{}
+	includeFunctions map[string]struct{}
+	excludeFunctions map[string]struct{}
+	includeTags      map[string]struct{}
+	excludeTags      map[string]struct{}
+}
+
+// Fields of the interaction needed to check tags
+type filteredInteraction struct {
+	Tags []smartweave.Tag `json:"tags"`
+}
+
+func NewFilter(config *config.Config, name string) (self *Filter) {
+	self = new(Filter)
+
+	self.rules.Store(&filterRules{isEmpty: true})
+
+	self.Mapper = task.NewMapper[*Payload, *Payload](config, name).
+		WithWorkerPool(1, config.Forwarder.FetcherBatchSize).
+		WithProcessFunc(self.process)
+
+	return
+}
+
+func (self *Filter) WithInputChannel(v chan *Payload) *Filter {
+	self.Mapper = self.Mapper.WithInputChannel(v)
+	return self
+}
+
+func (self *Filter) WithMonitor(monitor monitoring.Monitor) *Filter {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Filter) WithFilter(filter config.Filter) *Filter {
+	self.SetFilter(filter)
+	return self
+}
+
+// Replaces the filter, used upon configuration change
+func (self *Filter) SetFilter(filter config.Filter) {
+	self.rules.Store(&filterRules{
+		isEmpty:          filter.IsEmpty(),
+		includeContracts: toSet(filter.IncludeContracts),
+		excludeContracts: toSet(filter.ExcludeContracts),
+		includeSources:   toSet(filter.IncludeSources),
+		excludeSources:   toSet(filter.ExcludeSources),
+		includeFunctions: toSet(filter.IncludeFunctions),
+		excludeFunctions: toSet(filter.ExcludeFunctions),
+		includeTags:      toSet(filter.IncludeTags),
+		excludeTags:      toSet(filter.ExcludeTags),
+	})
+}
+
+// Replaces publishers' filters upon configuration file change. Adding or removing publishers requires a restart
+func watchFilters(redisFilters, webhookFilters, appSyncFilters []*Filter) {
+	config.OnChange(func(newConfig *config.Config) {
+		for i := range redisFilters {
+			if i < len(newConfig.Redis) {
+				redisFilters[i].SetFilter(newConfig.Redis[i].Filter)
+			}
+		}
+		for i := range webhookFilters {
+			if i < len(newConfig.Webhook) {
+				webhookFilters[i].SetFilter(newConfig.Webhook[i].Filter)
+			}
+		}
+		for _, filter := range appSyncFilters {
+			filter.SetFilter(newConfig.Forwarder.PublisherAppSyncFilter)
+		}
+	})
+}
+
+func (self *Filter) process(data *Payload, out chan *Payload) (err error) {
+	// Neglect empty messages
+	if data.Interaction == nil {
+		return nil
+	}
+
+	if !self.Matches(data) {
+		self.monitor.GetReport().Forwarder.State.FilteredInteractions.Inc()
+		return nil
+	}
+
+	select {
+	case <-self.Ctx.Done():
+	case out <- data:
+	}
+
+	return nil
+}
+
+// Checks if the interaction should be passed to the publisher
+func (self *Filter) Matches(data *Payload) bool {
+	rules := self.rules.Load()
+	if rules.isEmpty {
+		return true
+	}
+
+	if !isIncluded(rules.includeContracts, rules.excludeContracts, data.Interaction.ContractId) {
+		return false
+	}
+
+	if !isIncluded(rules.includeSources, rules.excludeSources, data.SrcTxId) {
+		return false
+	}
+
+	if len(rules.includeFunctions) == 0 && len(rules.excludeFunctions) == 0 &&
+		len(rules.includeTags) == 0 && len(rules.excludeTags) == 0 {
+		return true
+	}
+
+	// Tags are parsed only when needed
+	var interaction filteredInteraction
+	err := json.Unmarshal(data.Interaction.Interaction.Bytes, &interaction)
+	if err != nil {
+		self.Log.WithError(err).WithField("contract_id", data.Interaction.ContractId).Warn("Failed to parse interaction tags, filtering it out")
+		return false
+	}
+
+	// L2 interactions come without the function name, it's in the input tag
+	function := data.Interaction.Function
+	if function == "" {
+		function = getFunction(interaction.Tags)
+	}
+	if !isIncluded(rules.includeFunctions, rules.excludeFunctions, function) {
+		return false
+	}
+
+	return areTagsIncluded(rules, interaction.Tags)
+}
+
+func toSet(values []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		out[value] = struct{}{}
+	}
+	return out
+}
+
+func isIncluded(include, exclude map[string]struct{}, value string) bool {
+	if _, ok := exclude[value]; ok {
+		return false
+	}
+	if len(include) == 0 {
+		return true
+	}
+	_, ok := include[value]
+	return ok
+}
+
+// Tags match as "name" or "name=value"
+func areTagsIncluded(rules *filterRules, tags []smartweave.Tag) bool {
+	isIncluded := len(rules.includeTags) == 0
+	for _, tag := range tags {
+		for _, key := range [2]string{tag.Name, tag.Name + "=" + tag.Value} {
+			if _, ok := rules.excludeTags[key]; ok {
+				return false
+			}
+			if _, ok := rules.includeTags[key]; ok {
+				isIncluded = true
+			}
+		}
+	}
+	return isIncluded
+}
+
+func getFunction(tags []smartweave.Tag) string {
+	for _, tag := range tags {
+		if !strings.EqualFold(tag.Name, smartweave.TagInput) {
+			continue
+		}
+
+		var input struct {
+			Function string `json:"function"`
+		}
+		err := json.Unmarshal([]byte(tag.Value), &input)
+		if err != nil {
+			return ""
+		}
+		return input.Function
+	}
+	return ""
+}
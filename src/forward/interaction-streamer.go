@@ -9,14 +9,19 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/streamer"
 	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
 )
 
 // Produces current syncer's height
 type InteractionStreamer struct {
 	*task.Task
 
-	streamer *streamer.Streamer
-	monitor  monitoring.Monitor
+	db         *gorm.DB
+	streamer   *streamer.Streamer
+	replicator *streamer.Replicator
+	monitor    monitoring.Monitor
 
 	// Current Syncer's block height
 	Output chan *Payload
@@ -27,16 +32,23 @@ func NewInteractionStreamer(config *config.Config) (self *InteractionStreamer) {
 
 	self.Output = make(chan *Payload, config.Forwarder.InteractionsStreamerQueueSize)
 
-	self.streamer = streamer.NewStreamer(config, "interaction-stream").
-		WithNotificationChannelName("interactions").
-		WithCapacity(10)
-
 	self.Task = task.NewTask(config, "interaction").
-		// Live source of interactions
-		WithSubtask(self.streamer.Task).
 		// Parse and pass the interaction
 		WithSubtaskFunc(self.run)
 
+	// Live source of interactions
+	if config.Replication.Enabled {
+		self.replicator = streamer.NewReplicator(config, "forwarder_interactions").
+			WithTables(model.TableInteraction).
+			WithCapacity(10)
+		self.Task = self.Task.WithSubtask(self.replicator.Task)
+	} else {
+		self.streamer = streamer.NewStreamer(config, "interaction-stream").
+			WithNotificationChannelName("interactions").
+			WithCapacity(10)
+		self.Task = self.Task.WithSubtask(self.streamer.Task)
+	}
+
 	return
 }
 
@@ -45,7 +57,17 @@ func (self *InteractionStreamer) WithMonitor(monitor monitoring.Monitor) *Intera
 	return self
 }
 
+// Needed only with replication, to get the contract source
+func (self *InteractionStreamer) WithDB(db *gorm.DB) *InteractionStreamer {
+	self.db = db
+	return self
+}
+
 func (self *InteractionStreamer) run() (err error) {
+	if self.replicator != nil {
+		return self.runReplication()
+	}
+
 	for {
 		select {
 		case <-self.Ctx.Done():
@@ -69,19 +91,83 @@ func (self *InteractionStreamer) run() (err error) {
 				Interaction: &model.Interaction{
 					ContractId:  notification.ContractId,
 					Interaction: notification.Interaction,
+					SortKey:     notification.SortKey,
+					Source:      sourceSequencer,
 				},
 				SrcTxId: notification.SrcTxId,
 			}
 
-			// Pass the interaction to the output channel
-			select {
-			case <-self.Ctx.Done():
-				return errors.New("InteractionStreamer stopped")
-			case self.Output <- payload:
+			err = self.emit(payload)
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Inserted L2 interactions come with all columns, contract source is taken from the database
+func (self *InteractionStreamer) runReplication() (err error) {
+	for {
+		select {
+		case <-self.Ctx.Done():
+			self.Log.Debug("Stop passing interactions")
+			return nil
+		case change, ok := <-self.replicator.Output:
+			if !ok {
+				self.Log.Error("Replicator closed, can't receive interactions!")
+				return nil
+			}
+
+			source, _ := change.Get("source")
+			if change.Operation != streamer.OperationInsert || source != sourceSequencer {
+				// Only new L2 interactions
+				self.replicator.Ack(change)
+				continue
 			}
 
-			// Update monitoring
-			self.monitor.GetReport().Forwarder.State.L2Interactions.Inc()
+			interaction := new(model.Interaction)
+			err = change.Scan(interaction)
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to scan interaction")
+				self.monitor.GetReport().Forwarder.Errors.DbFetchL2Interactions.Inc()
+				return
+			}
+
+			var srcTxId pgtype.Text
+			err = self.db.WithContext(self.Ctx).
+				Table(model.TableContract).
+				Select("src_tx_id").
+				Where("contract_id = ?", interaction.ContractId).
+				Scan(&srcTxId).
+				Error
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to get contract source")
+				self.monitor.GetReport().Forwarder.Errors.DbFetchL2Interactions.Inc()
+				return
+			}
+
+			err = self.emit(&Payload{
+				Interaction: interaction,
+				SrcTxId:     srcTxId.String,
+			})
+			if err != nil {
+				return
+			}
+
+			self.replicator.Ack(change)
 		}
 	}
 }
+
+func (self *InteractionStreamer) emit(payload *Payload) error {
+	// Pass the interaction to the output channel
+	select {
+	case <-self.Ctx.Done():
+		return errors.New("InteractionStreamer stopped")
+	case self.Output <- payload:
+	}
+
+	// Update monitoring
+	self.monitor.GetReport().Forwarder.State.L2Interactions.Inc()
+	return nil
+}
@@ -20,7 +20,7 @@ func redisMapper(config *config.Config) (self *task.Mapper[*Payload, *model.Inte
 
 			self.Log.WithField("contract_id", data.Interaction.ContractId).Trace("Publishing interaction to Redis")
 
-			interactionStr, err := data.Interaction.Interaction.MarshalJSON()
+			notification, err := interactionNotification(data)
 			if err != nil {
 				self.Log.WithField("contract_id", data.Interaction.ContractId).Warn("Failed to marshal interaction")
 				return err
@@ -29,13 +29,7 @@ func redisMapper(config *config.Config) (self *task.Mapper[*Payload, *model.Inte
 			// TODO: Neglect messages that are too big
 			select {
 			case <-self.Ctx.Done():
-			case out <- &model.InteractionNotification{
-				ContractTxId: data.Interaction.ContractId,
-				Test:         false,
-				Source:       "warp-gw",
-				Interaction:  string(interactionStr),
-				SrcTxId:      data.SrcTxId,
-			}:
+			case out <- notification:
 			}
 
 			return nil
@@ -46,8 +40,6 @@ func appSyncMapper(config *config.Config, channelName string, forContract bool)
 	return task.NewMapper[*Payload, *publisher.AppSyncPayload[*model.InteractionNotification]](config, "map-appsync-notification").
 		WithWorkerPool(1, config.Contract.StoreBatchSize).
 		WithProcessFunc(func(data *Payload, out chan *publisher.AppSyncPayload[*model.InteractionNotification]) (err error) {
-			channelName := channelName
-
 			// Neglect empty messages
 			if data.Interaction == nil {
 				return nil
@@ -55,29 +47,54 @@ func appSyncMapper(config *config.Config, channelName string, forContract bool)
 
 			self.Log.WithField("contract_id", data.Interaction.ContractId).Trace("Publishing interaction to AppSync")
 
-			interactionStr, err := data.Interaction.Interaction.MarshalJSON()
+			payload, err := appSyncPayload(data, channelName, forContract)
 			if err != nil {
 				self.Log.WithField("contract_id", data.Interaction.ContractId).Warn("Failed to marshal interaction")
 				return err
 			}
 
-			if forContract {
-				channelName = fmt.Sprintf("%s/%s", channelName, data.Interaction.ContractId)
-			}
-
 			select {
 			case <-self.Ctx.Done():
-			case out <- &publisher.AppSyncPayload[*model.InteractionNotification]{In: &model.InteractionNotification{
-				ContractTxId: data.Interaction.ContractId,
-				Test:         false,
-				Source:       "warp-gw",
-				Interaction:  string(interactionStr),
-				SrcTxId:      data.SrcTxId,
-			},
-				ChannelName: channelName,
-			}:
+			case out <- payload:
 			}
 
 			return nil
 		})
 }
+
+// Notification sent to Redis and webhooks
+func interactionNotification(data *Payload) (out *model.InteractionNotification, err error) {
+	interactionStr, err := data.Interaction.Interaction.MarshalJSON()
+	if err != nil {
+		return
+	}
+
+	out = &model.InteractionNotification{
+		ContractTxId:      data.Interaction.ContractId,
+		Test:              false,
+		Source:            "warp-gw",
+		Interaction:       string(interactionStr),
+		SrcTxId:           data.SrcTxId,
+		SortKey:           data.Interaction.SortKey,
+		InteractionSource: data.Interaction.Source,
+	}
+	return
+}
+
+// Notification sent to AppSync, optionally to the contract's channel
+func appSyncPayload(data *Payload, channelName string, forContract bool) (out *publisher.AppSyncPayload[*model.InteractionNotification], err error) {
+	notification, err := interactionNotification(data)
+	if err != nil {
+		return
+	}
+
+	if forContract {
+		channelName = fmt.Sprintf("%s/%s", channelName, data.Interaction.ContractId)
+	}
+
+	out = &publisher.AppSyncPayload[*model.InteractionNotification]{
+		In:          notification,
+		ChannelName: channelName,
+	}
+	return
+}
@@ -6,4 +6,5 @@ type Notification struct {
 	Interaction pgtype.JSONB `json:"interaction"`
 	ContractId  string       `json:"contractId"`
 	SrcTxId     string       `json:"srcTxId"`
+	SortKey     string       `json:"sortKey"`
 }
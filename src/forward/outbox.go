@@ -0,0 +1,261 @@
+package forward
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Tracks interactions delivered by each publisher and periodically saves the sort key of the last one, separately for L1 and L2.
+// Publishers may deliver in parallel, so the saved sort key is of the last interaction
+// such that all interactions the publisher took before it are delivered or failed.
+// Failed interactions are saved separately and replayed after the restart.
+type Outbox struct {
+	*task.Task
+
+	db      *gorm.DB
+	monitor monitoring.Monitor
+
+	maxPending int
+
+	mtx     sync.Mutex
+	entries map[outboxKey]*outboxEntry
+}
+
+type outboxKey struct {
+	publisher string
+	source    string
+}
+
+type outboxMessage struct {
+	sortKey string
+	isDone  bool
+}
+
+type outboxEntry struct {
+	// Messages taken by the publisher, in order
+	pending []*outboxMessage
+
+	// All messages up to this sort key are delivered or failed
+	sortKey string
+
+	// Sort key changed since the last save
+	isDirty bool
+
+	// Sort keys of failed messages that aren't saved yet
+	failed []string
+}
+
+// Moves past messages that are done
+func (self *outboxEntry) advance() {
+	for len(self.pending) > 0 && self.pending[0].isDone {
+		if self.pending[0].sortKey > self.sortKey {
+			self.sortKey = self.pending[0].sortKey
+			self.isDirty = true
+		}
+		self.pending = self.pending[1:]
+	}
+}
+
+// Message will be replayed, it doesn't block the sort key
+func (self *outboxEntry) fail(message *outboxMessage) {
+	message.isDone = true
+	self.failed = append(self.failed, message.sortKey)
+	self.advance()
+}
+
+func NewOutbox(config *config.Config) (self *Outbox) {
+	self = new(Outbox)
+
+	self.entries = make(map[outboxKey]*outboxEntry)
+	self.maxPending = config.Forwarder.OutboxMaxPending
+
+	self.Task = task.NewTask(config, "outbox").
+		WithPeriodicSubtaskFunc(config.Forwarder.OutboxFlushInterval, self.flush).
+		WithOnAfterStop(func() {
+			// Context is already cancelled
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := self.save(ctx)
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to save delivery state before stopping")
+			}
+		})
+
+	return
+}
+
+func (self *Outbox) WithDB(db *gorm.DB) *Outbox {
+	self.db = db
+	return self
+}
+
+func (self *Outbox) WithMonitor(monitor monitoring.Monitor) *Outbox {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Outbox) OnDispatched(publisher, source, sortKey string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	key := outboxKey{publisher: publisher, source: source}
+	entry, ok := self.entries[key]
+	if !ok {
+		entry = new(outboxEntry)
+		self.entries[key] = entry
+	}
+
+	entry.pending = append(entry.pending, &outboxMessage{sortKey: sortKey})
+
+	if len(entry.pending) > self.maxPending {
+		// Publisher is stuck, e.g. waiting for a reconnection
+		self.Log.WithField("publisher", publisher).WithField("sort_key", entry.pending[0].sortKey).
+			Warn("Too many undelivered interactions, the oldest one will be replayed after the restart")
+		entry.fail(entry.pending[0])
+	}
+}
+
+func (self *Outbox) OnDelivered(publisher, source, sortKey string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	entry, ok := self.entries[outboxKey{publisher: publisher, source: source}]
+	if !ok {
+		return
+	}
+
+	for _, message := range entry.pending {
+		if message.sortKey == sortKey && !message.isDone {
+			message.isDone = true
+			break
+		}
+	}
+
+	entry.advance()
+}
+
+// Forgets messages the publisher took but didn't deliver, e.g. when it's restarted.
+// They're replayed from the saved sort key by the new publisher's replayer
+func (self *Outbox) Reset(publisher string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	for key, entry := range self.entries {
+		if key.publisher == publisher {
+			entry.pending = nil
+		}
+	}
+}
+
+// Failed message is saved and replayed after the restart, the saved sort key moves past it
+func (self *Outbox) OnFailed(publisher, source, sortKey string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	entry, ok := self.entries[outboxKey{publisher: publisher, source: source}]
+	if !ok {
+		return
+	}
+
+	for _, message := range entry.pending {
+		if message.sortKey == sortKey && !message.isDone {
+			entry.fail(message)
+			return
+		}
+	}
+}
+
+func (self *Outbox) flush() (err error) {
+	err = self.save(self.Ctx)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to save delivery state")
+	}
+	return nil
+}
+
+// Upserts changed sort keys along with the failed messages they moved past
+func (self *Outbox) save(ctx context.Context) (err error) {
+	self.mtx.Lock()
+	rows := make([]*model.ForwarderOutbox, 0, len(self.entries))
+	var failed []*model.ForwarderOutboxFailed
+	for key, entry := range self.entries {
+		for _, sortKey := range entry.failed {
+			failed = append(failed, &model.ForwarderOutboxFailed{
+				Publisher: key.publisher,
+				Source:    key.source,
+				SortKey:   sortKey,
+			})
+		}
+		entry.failed = nil
+
+		if !entry.isDirty {
+			continue
+		}
+		rows = append(rows, &model.ForwarderOutbox{
+			Publisher: key.publisher,
+			Source:    key.source,
+			SortKey:   entry.sortKey,
+		})
+	}
+	self.mtx.Unlock()
+
+	if len(rows) == 0 && len(failed) == 0 {
+		return
+	}
+
+	err = self.db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if len(failed) > 0 {
+				err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+					CreateInBatches(&failed, 500).
+					Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(rows) == 0 {
+				return nil
+			}
+
+			return tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "publisher"}, {Name: "source"}},
+				DoUpdates: clause.AssignmentColumns([]string{"sort_key", "updated_at"}),
+			}).
+				Create(&rows).
+				Error
+		})
+	if err != nil {
+		self.monitor.GetReport().Forwarder.Errors.DbOutbox.Inc()
+
+		// Failed messages will be saved next time
+		self.mtx.Lock()
+		defer self.mtx.Unlock()
+		for _, row := range failed {
+			entry := self.entries[outboxKey{publisher: row.Publisher, source: row.Source}]
+			entry.failed = append(entry.failed, row.SortKey)
+		}
+		return
+	}
+
+	// Entries that changed in the meantime will be saved next time
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	for _, row := range rows {
+		entry := self.entries[outboxKey{publisher: row.Publisher, source: row.Source}]
+		if entry.sortKey == row.SortKey {
+			entry.isDirty = false
+		}
+	}
+
+	return
+}
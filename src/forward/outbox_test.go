@@ -0,0 +1,52 @@
+package forward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+func newTestOutbox(maxPending int) *Outbox {
+	c := config.Default()
+	c.Forwarder.OutboxMaxPending = maxPending
+	return NewOutbox(c)
+}
+
+func (self *Outbox) entry(publisher, source string) *outboxEntry {
+	return self.entries[outboxKey{publisher: publisher, source: source}]
+}
+
+func TestOutboxMovesPastFailed(t *testing.T) {
+	outbox := newTestOutbox(10)
+
+	outbox.OnDispatched("redis", sourceArweave, "1")
+	outbox.OnDispatched("redis", sourceArweave, "2")
+	outbox.OnDispatched("redis", sourceArweave, "3")
+
+	outbox.OnDelivered("redis", sourceArweave, "2")
+	require.Equal(t, "", outbox.entry("redis", sourceArweave).sortKey)
+
+	outbox.OnFailed("redis", sourceArweave, "1")
+	entry := outbox.entry("redis", sourceArweave)
+	require.Equal(t, "2", entry.sortKey)
+	require.Equal(t, []string{"1"}, entry.failed)
+	require.Len(t, entry.pending, 1)
+}
+
+func TestOutboxMaxPending(t *testing.T) {
+	outbox := newTestOutbox(2)
+
+	outbox.OnDispatched("redis", sourceArweave, "1")
+	outbox.OnDispatched("redis", sourceArweave, "2")
+	outbox.OnDispatched("redis", sourceArweave, "3")
+
+	entry := outbox.entry("redis", sourceArweave)
+	require.Len(t, entry.pending, 2)
+	require.Equal(t, "1", entry.sortKey)
+	require.Equal(t, []string{"1"}, entry.failed)
+
+	// Late delivery of the dropped message changes nothing
+	outbox.OnDelivered("redis", sourceArweave, "1")
+	require.Len(t, entry.pending, 2)
+}
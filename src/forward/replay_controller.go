@@ -0,0 +1,187 @@
+package forward
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	monitor_forwarder "github.com/warp-contracts/syncer/src/utils/monitoring/forwarder"
+	"github.com/warp-contracts/syncer/src/utils/publisher"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Re-publishes interactions from a sort key or block height range to a single forwarder's publisher, using the publisher's filter.
+// Doesn't update the outbox. Stops by itself once all interactions are delivered.
+type ReplayController struct {
+	*task.Task
+
+	mtx              sync.Mutex
+	numReplayed      int
+	numDelivered     int
+	numFailed        int
+	isReplayFinished bool
+}
+
+func NewReplayController(config *config.Config, publisherName, afterSortKey, untilSortKey string, startHeight, stopHeight uint64) (self *ReplayController, err error) {
+	isSortKeyRange := afterSortKey != "" || untilSortKey != ""
+	isHeightRange := startHeight != 0 || stopHeight != 0
+	if isSortKeyRange && isHeightRange {
+		err = errors.New("sort key range and block height range can't be used together")
+		return
+	}
+	if stopHeight != 0 && stopHeight < startHeight {
+		err = errors.New("stop height can't be lower than start height")
+		return
+	}
+
+	self = new(ReplayController)
+	self.Task = task.NewTask(config, "forward-replay")
+
+	db, err := model.NewConnection(self.Ctx, config, "forward-replay")
+	if err != nil {
+		return
+	}
+
+	monitor := monitor_forwarder.NewMonitor(config)
+
+	// Live input is never used, it's closed so that the publisher exits
+	var redisIdx, webhookIdx int
+	var publisherTask *task.Task
+	switch {
+	case parsePublisherName(publisherName, RedisPublisherName, &redisIdx):
+		if redisIdx >= len(config.Redis) {
+			err = fmt.Errorf("redis publisher %d isn't configured", redisIdx)
+			return
+		}
+
+		replayer := newRangeReplayer[*model.InteractionNotification](config, publisherName, afterSortKey, untilSortKey, startHeight, stopHeight).
+			WithDB(db).
+			WithMonitor(monitor).
+			WithFilter(NewFilter(config, "replay-filter").WithFilter(config.Redis[redisIdx].Filter)).
+			WithMapper(interactionNotification).
+			WithOnFinished(self.onReplayFinished)
+		input := make(chan *model.InteractionNotification)
+		redisPublisher := publisher.NewRedisPublisher[*model.InteractionNotification](config, config.Redis[redisIdx], publisherName).
+			WithChannelName(config.Forwarder.PublisherRedisChannelName).
+			WithMonitor(monitor, redisIdx).
+			WithDeliveryTracker(self).
+			WithReplayChannel(replayer.Output).
+			WithInputChannel(input)
+		self.Task = self.Task.
+			WithSubtask(replayer.Task).
+			WithOnStop(func() { close(input) })
+		publisherTask = redisPublisher.Task
+
+	case parsePublisherName(publisherName, WebhookPublisherName, &webhookIdx):
+		if webhookIdx >= len(config.Webhook) {
+			err = fmt.Errorf("webhook publisher %d isn't configured", webhookIdx)
+			return
+		}
+
+		replayer := newRangeReplayer[*model.InteractionNotification](config, publisherName, afterSortKey, untilSortKey, startHeight, stopHeight).
+			WithDB(db).
+			WithMonitor(monitor).
+			WithFilter(NewFilter(config, "replay-filter").WithFilter(config.Webhook[webhookIdx].Filter)).
+			WithMapper(interactionNotification).
+			WithOnFinished(self.onReplayFinished)
+		input := make(chan *model.InteractionNotification)
+		webhookPublisher := publisher.NewWebhookPublisher[*model.InteractionNotification](config, config.Webhook[webhookIdx], publisherName).
+			WithDB(db).
+			WithMonitor(monitor, webhookIdx).
+			WithDeliveryTracker(self).
+			WithReplayChannel(replayer.Output).
+			WithInputChannel(input)
+		self.Task = self.Task.
+			WithSubtask(replayer.Task).
+			WithOnStop(func() { close(input) })
+		publisherTask = webhookPublisher.Task
+
+	case publisherName == AppSyncPublisherName || publisherName == AppSyncContractPublisherName:
+		forContract := publisherName == AppSyncContractPublisherName
+		replayer := newRangeReplayer[*publisher.AppSyncPayload[*model.InteractionNotification]](config, publisherName, afterSortKey, untilSortKey, startHeight, stopHeight).
+			WithDB(db).
+			WithMonitor(monitor).
+			WithFilter(NewFilter(config, "replay-filter").WithFilter(config.Forwarder.PublisherAppSyncFilter)).
+			WithMapper(func(data *Payload) (*publisher.AppSyncPayload[*model.InteractionNotification], error) {
+				return appSyncPayload(data, config.Forwarder.PublisherAppSyncChannelName, forContract)
+			}).
+			WithOnFinished(self.onReplayFinished)
+		input := make(chan *publisher.AppSyncPayload[*model.InteractionNotification])
+		appSyncPublisher := publisher.NewAppSyncPublisher[*model.InteractionNotification](config, publisherName).
+			WithMonitor(monitor).
+			WithDeliveryTracker(self).
+			WithReplayChannel(replayer.Output).
+			WithInputChannel(input)
+		self.Task = self.Task.
+			WithSubtask(replayer.Task).
+			WithOnStop(func() { close(input) })
+		publisherTask = appSyncPublisher.Task
+
+	default:
+		err = fmt.Errorf("unknown publisher: %s", publisherName)
+		return
+	}
+
+	self.Task = self.Task.
+		WithSubtask(monitor.Task).
+		WithSubtask(publisherTask)
+
+	return
+}
+
+func newRangeReplayer[Out any](config *config.Config, publisherName, afterSortKey, untilSortKey string, startHeight, stopHeight uint64) *Replayer[Out] {
+	replayer := NewReplayer[Out](config, publisherName)
+	if startHeight != 0 || stopHeight != 0 {
+		return replayer.WithHeightRange(startHeight, stopHeight)
+	}
+	return replayer.WithSortKeyRange(afterSortKey, untilSortKey)
+}
+
+// Checks if the name matches the format with the publisher's index
+func parsePublisherName(name, format string, idx *int) bool {
+	_, err := fmt.Sscanf(name, format, idx)
+	return err == nil && fmt.Sprintf(format, *idx) == name
+}
+
+func (self *ReplayController) OnDispatched(publisher, source, sortKey string) {}
+
+func (self *ReplayController) OnDelivered(publisher, source, sortKey string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.numDelivered++
+	self.stopIfDone()
+}
+
+// Failed interactions aren't retried, the command can be run again for their range
+func (self *ReplayController) OnFailed(publisher, source, sortKey string) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.numFailed++
+	self.Log.WithField("sort_key", sortKey).Warn("Failed to deliver interaction")
+	self.stopIfDone()
+}
+
+func (self *ReplayController) onReplayFinished(numReplayed int) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.numReplayed = numReplayed
+	self.isReplayFinished = true
+	self.stopIfDone()
+}
+
+func (self *ReplayController) stopIfDone() {
+	if !self.isReplayFinished || self.numDelivered+self.numFailed < self.numReplayed {
+		return
+	}
+
+	if self.numFailed > 0 {
+		self.Log.WithField("num", self.numDelivered).WithField("failed", self.numFailed).Warn("Replay finished, some interactions weren't delivered")
+	} else {
+		self.Log.WithField("num", self.numDelivered).Info("All interactions delivered")
+	}
+
+	// Called from a subtask, stopping waits for it
+	go self.Stop()
+}
@@ -0,0 +1,363 @@
+package forward
+
+import (
+	"context"
+	"errors"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
+)
+
+const (
+	sourceArweave   = "arweave"
+	sourceSequencer = "redstone-sequencer"
+)
+
+// Sends interactions saved in the database to a single publisher, ordered by sort key.
+// Range of interactions is taken from the outbox or set explicitly.
+// Output channel is closed when all interactions are sent.
+type Replayer[Out any] struct {
+	*task.Task
+
+	db      *gorm.DB
+	monitor monitoring.Monitor
+
+	// Publisher that gets the interactions
+	publisher string
+
+	// Converts interactions to publisher's messages
+	mapper func(*Payload) (Out, error)
+
+	// Optional, interactions the publisher doesn't get are skipped
+	filter *Filter
+
+	// Ranges are taken from the outbox
+	isFromOutbox bool
+	ranges       []*replayRange
+
+	// Called after the last interaction is sent, with the number of sent interactions
+	onFinished  func(int)
+	numReplayed int
+	isFinished  bool
+
+	Output chan Out
+}
+
+type replayRange struct {
+	// Source of interactions, empty means all
+	source string
+
+	// Exclusive, empty means no limit
+	afterSortKey string
+
+	// Inclusive, empty means no limit
+	untilSortKey string
+
+	// Inclusive, 0 means no limit
+	startHeight uint64
+	stopHeight  uint64
+
+	// Only these interactions, saved as failed in the outbox. Removed from the outbox once replayed
+	failedSortKeys []string
+}
+
+// Interaction with the fields needed for the notification
+type replayedInteraction struct {
+	model.Interaction
+	SrcTxId pgtype.Text
+}
+
+func NewReplayer[Out any](config *config.Config, publisher string) (self *Replayer[Out]) {
+	self = new(Replayer[Out])
+
+	self.publisher = publisher
+	self.Output = make(chan Out)
+
+	self.Task = task.NewTask(config, "replayer-"+publisher).
+		WithSubtaskFunc(self.run).
+		WithOnAfterStop(func() {
+			if !self.isFinished {
+				close(self.Output)
+			}
+		})
+
+	return
+}
+
+func (self *Replayer[Out]) WithDB(db *gorm.DB) *Replayer[Out] {
+	self.db = db
+	return self
+}
+
+func (self *Replayer[Out]) WithMonitor(monitor monitoring.Monitor) *Replayer[Out] {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Replayer[Out]) WithMapper(f func(*Payload) (Out, error)) *Replayer[Out] {
+	self.mapper = f
+	return self
+}
+
+func (self *Replayer[Out]) WithFilter(filter *Filter) *Replayer[Out] {
+	self.filter = filter
+	return self
+}
+
+// Replays interactions the publisher didn't deliver before the restart
+func (self *Replayer[Out]) WithOutbox() *Replayer[Out] {
+	self.isFromOutbox = true
+	return self
+}
+
+// Replays interactions from the range, empty values mean no limit. Lower limit is exclusive
+func (self *Replayer[Out]) WithSortKeyRange(afterSortKey, untilSortKey string) *Replayer[Out] {
+	self.ranges = []*replayRange{{afterSortKey: afterSortKey, untilSortKey: untilSortKey}}
+	return self
+}
+
+// Replays interactions from the block height range, inclusive. 0 means no limit
+func (self *Replayer[Out]) WithHeightRange(start, stop uint64) *Replayer[Out] {
+	self.ranges = []*replayRange{{startHeight: start, stopHeight: stop}}
+	return self
+}
+
+func (self *Replayer[Out]) WithOnFinished(f func(int)) *Replayer[Out] {
+	self.onFinished = f
+	return self
+}
+
+func (self *Replayer[Out]) run() (err error) {
+	if self.isFinished {
+		// Wait till the context is done
+		<-self.Ctx.Done()
+		return nil
+	}
+
+	if self.isFromOutbox {
+		err = self.retry(self.initOutboxRanges)
+		if err != nil {
+			return nil
+		}
+		self.isFromOutbox = false
+	}
+
+	// Ranges are updated as interactions are sent, so restarted replay continues where it stopped
+	for len(self.ranges) > 0 {
+		err = self.replay(self.ranges[0])
+		if err != nil {
+			return nil
+		}
+		if len(self.ranges[0].failedSortKeys) > 0 {
+			err = self.retry(func() error { return self.removeFailed(self.ranges[0]) })
+			if err != nil {
+				return nil
+			}
+		}
+		self.ranges = self.ranges[1:]
+	}
+
+	self.Log.WithField("num", self.numReplayed).Info("Finished replaying interactions")
+
+	self.isFinished = true
+	close(self.Output)
+
+	if self.onFinished != nil {
+		self.onFinished(self.numReplayed)
+	}
+
+	// Wait till the context is done
+	<-self.Ctx.Done()
+
+	return nil
+}
+
+// Database errors are retried until the task is stopped
+func (self *Replayer[Out]) retry(f func() error) error {
+	return task.NewRetry().
+		WithContext(self.Ctx).
+		WithMaxElapsedTime(0).
+		WithMaxInterval(self.Config.Forwarder.HeightDelay * 10).
+		WithOnError(func(err error, isDurationAcceptable bool) error {
+			if errors.Is(err, context.Canceled) && self.IsStopping.Load() {
+				// Stopping
+				return backoff.Permanent(err)
+			}
+
+			self.Log.WithError(err).Warn("Failed to get interactions to replay, retrying")
+			self.monitor.GetReport().Forwarder.Errors.DbReplay.Inc()
+			return err
+		}).
+		Run(f)
+}
+
+// One range per source the publisher delivered before
+func (self *Replayer[Out]) initOutboxRanges() (err error) {
+	var rows []*model.ForwarderOutbox
+	err = self.db.WithContext(self.Ctx).
+		Where("publisher = ?", self.publisher).
+		Find(&rows).
+		Error
+	if err != nil {
+		return
+	}
+
+	var state model.State
+	err = self.db.WithContext(self.Ctx).
+		Table(model.TableState).
+		Find(&state, model.SyncedComponentForwarder).
+		Error
+	if err != nil {
+		return
+	}
+
+	self.ranges = make([]*replayRange, 0, len(rows))
+
+	// Failed interactions are older than the saved sort keys, they go first
+	var failed []*model.ForwarderOutboxFailed
+	err = self.db.WithContext(self.Ctx).
+		Where("publisher = ?", self.publisher).
+		Order("source, sort_key").
+		Find(&failed).
+		Error
+	if err != nil {
+		return
+	}
+
+	for len(failed) > 0 {
+		// Keep the number of query parameters low
+		r := &replayRange{source: failed[0].Source}
+		for len(failed) > 0 && failed[0].Source == r.source && len(r.failedSortKeys) < self.Config.Forwarder.ReplayBatchSize {
+			r.failedSortKeys = append(r.failedSortKeys, failed[0].SortKey)
+			failed = failed[1:]
+		}
+
+		self.Log.WithField("source", r.source).
+			WithField("num", len(r.failedSortKeys)).
+			Info("Replaying interactions that failed")
+
+		self.ranges = append(self.ranges, r)
+	}
+
+	for _, row := range rows {
+		r := &replayRange{
+			source:       row.Source,
+			afterSortKey: row.SortKey,
+		}
+
+		if row.Source == sourceArweave {
+			// Later blocks are sent by the ArweaveFetcher
+			if state.FinishedBlockHeight == 0 {
+				continue
+			}
+			r.stopHeight = state.FinishedBlockHeight
+		} else {
+			// Later interactions come through notifications, some may be sent twice
+			var untilSortKey pgtype.Text
+			err = self.db.WithContext(self.Ctx).
+				Table(model.TableInteraction).
+				Select("MAX(sort_key)").
+				Where("source = ?", row.Source).
+				Scan(&untilSortKey).
+				Error
+			if err != nil {
+				return
+			}
+			if untilSortKey.Status != pgtype.Present {
+				continue
+			}
+			r.untilSortKey = untilSortKey.String
+		}
+
+		self.Log.WithField("source", r.source).
+			WithField("after_sort_key", r.afterSortKey).
+			WithField("until_sort_key", r.untilSortKey).
+			WithField("stop_height", r.stopHeight).
+			Info("Replaying interactions that weren't delivered")
+
+		self.ranges = append(self.ranges, r)
+	}
+
+	return
+}
+
+func (self *Replayer[Out]) replay(r *replayRange) (err error) {
+	for {
+		var interactions []*replayedInteraction
+		err = self.retry(func() error {
+			query := self.db.WithContext(self.Ctx).
+				Table(model.TableInteraction).
+				Select("interactions.*, contracts.src_tx_id").
+				Joins("LEFT JOIN contracts ON contracts.contract_id = interactions.contract_id").
+				Where("interactions.sort_key > ?", r.afterSortKey).
+				Order("interactions.sort_key ASC").
+				Limit(self.Config.Forwarder.ReplayBatchSize)
+			if r.source != "" {
+				query = query.Where("interactions.source = ?", r.source)
+			}
+			if r.untilSortKey != "" {
+				query = query.Where("interactions.sort_key <= ?", r.untilSortKey)
+			}
+			if r.startHeight > 0 {
+				query = query.Where("interactions.block_height >= ?", r.startHeight)
+			}
+			if r.stopHeight > 0 {
+				query = query.Where("interactions.block_height <= ?", r.stopHeight)
+			}
+			if len(r.failedSortKeys) > 0 {
+				query = query.Where("interactions.sort_key IN ?", r.failedSortKeys)
+			}
+			return query.Find(&interactions).Error
+		})
+		if err != nil {
+			return
+		}
+
+		for _, interaction := range interactions {
+			payload := &Payload{
+				Interaction: &interaction.Interaction,
+				SrcTxId:     interaction.SrcTxId.String,
+			}
+			if self.filter != nil && !self.filter.Matches(payload) {
+				r.afterSortKey = interaction.SortKey
+				continue
+			}
+
+			var out Out
+			out, err = self.mapper(payload)
+			if err != nil {
+				self.Log.WithError(err).WithField("sort_key", interaction.SortKey).Error("Failed to map interaction, skipping")
+			} else {
+				select {
+				case <-self.Ctx.Done():
+					return errors.New("task closing")
+				case self.Output <- out:
+				}
+				self.numReplayed++
+				self.monitor.GetReport().Forwarder.State.ReplayedInteractions.Inc()
+			}
+
+			r.afterSortKey = interaction.SortKey
+		}
+
+		if len(interactions) < self.Config.Forwarder.ReplayBatchSize {
+			return nil
+		}
+	}
+}
+
+// Failed interactions are replayed, they are removed from the outbox
+func (self *Replayer[Out]) removeFailed(r *replayRange) error {
+	return self.db.WithContext(self.Ctx).
+		Where("publisher = ?", self.publisher).
+		Where("source = ?", r.source).
+		Where("sort_key IN ?", r.failedSortKeys).
+		Delete(&model.ForwarderOutboxFailed{}).
+		Error
+}
@@ -19,8 +19,9 @@ type Sequencer struct {
 	*task.Task
 	db *gorm.DB
 
-	streamer *streamer.Streamer
-	monitor  monitoring.Monitor
+	streamer   *streamer.Streamer
+	replicator *streamer.Replicator
+	monitor    monitoring.Monitor
 
 	// Current Syncer's block height
 	Output chan uint64
@@ -34,16 +35,23 @@ func NewSequencer(config *config.Config) (self *Sequencer) {
 
 	self.Output = make(chan uint64)
 
-	self.streamer = streamer.NewStreamer(config, "sequence-sync-state").
-		WithNotificationChannelName("sync_state_syncer").
-		WithCapacity(10)
-
 	self.Task = task.NewTask(config, "sequencer").
-		// Live source of interactions that need to be bundled
-		WithSubtask(self.streamer.Task).
 		// Interactions that somehow wasn't sent through the notification channel. Probably because of a restart.
 		WithSubtaskFunc(self.run)
 
+	// Live source of sync state changes
+	if config.Replication.Enabled {
+		self.replicator = streamer.NewReplicator(config, "forwarder_sync_state").
+			WithTables(model.TableState).
+			WithCapacity(10)
+		self.Task = self.Task.WithSubtask(self.replicator.Task)
+	} else {
+		self.streamer = streamer.NewStreamer(config, "sequence-sync-state").
+			WithNotificationChannelName("sync_state_syncer").
+			WithCapacity(10)
+		self.Task = self.Task.WithSubtask(self.streamer.Task)
+	}
+
 	return
 }
 
@@ -64,38 +72,72 @@ func (self *Sequencer) run() (err error) {
 		return
 	}
 
+	// Only one of them is set
+	var notifications chan string
+	var changes chan *streamer.Change
+	if self.streamer != nil {
+		notifications = self.streamer.Output
+	} else {
+		changes = self.replicator.Output
+	}
+
 	for {
+		var state model.State
 		select {
 		case <-self.Ctx.Done():
 			self.Log.Debug("Stop passing sync state")
 			return nil
-		case msg, ok := <-self.streamer.Output:
+		case msg, ok := <-notifications:
 			if !ok {
 				self.Log.Error("Streamer closed, can't receive sequencer's state changes!")
 				return nil
 			}
 
-			var state model.State
 			err = json.Unmarshal([]byte(msg), &state)
 			if err != nil {
 				self.Log.WithError(err).Error("Failed to unmarshal sequencer sync state")
 				return
 			}
 
-			if state.FinishedBlockHeight <= self.currentHeight {
-				// There was no change, neglect
-				continue
-			}
-
-			// Emit height change one by one
-			err = self.emit(state.FinishedBlockHeight, false /* sleep before emitting to let outstanding GW requests finish*/)
+			err = self.handle(&state)
 			if err != nil {
 				return
 			}
+		case change, ok := <-changes:
+			if !ok {
+				self.Log.Error("Replicator closed, can't receive sequencer's state changes!")
+				return nil
+			}
+
+			name, _ := change.Get("name")
+			if name == string(model.SyncedComponentInteractions) && change.Operation != streamer.OperationDelete {
+				err = change.Scan(&state)
+				if err != nil {
+					self.Log.WithError(err).Error("Failed to scan sequencer sync state")
+					return
+				}
+
+				err = self.handle(&state)
+				if err != nil {
+					return
+				}
+			}
+
+			self.replicator.Ack(change)
 		}
 	}
 }
 
+func (self *Sequencer) handle(state *model.State) (err error) {
+	if state.FinishedBlockHeight <= self.currentHeight {
+		// There was no change, neglect
+		return
+	}
+
+	// Emit height change one by one
+	return self.emit(state.FinishedBlockHeight, false /* sleep before emitting to let outstanding GW requests finish*/)
+}
+
 func (self *Sequencer) catchUp() (err error) {
 	// Get the last block height from the database
 	var syncerState, forwarderState model.State
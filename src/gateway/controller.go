@@ -3,8 +3,8 @@ package gateway
 import (
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_gateway "github.com/warp-contracts/syncer/src/utils/monitoring/gateway"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 )
 
@@ -13,12 +13,12 @@ type Controller struct {
 }
 
 // Main class that orchestrates everything
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "gateway-controller")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "gateway")
+	db, err := resources.GetDB(self.Ctx, config, "gateway")
 	if err != nil {
 		return
 	}
@@ -31,8 +31,7 @@ func NewController(config *config.Config) (self *Controller, err error) {
 	// Monitoring
 	monitor := monitor_gateway.NewMonitor()
 
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "gateway", monitor)
 
 	// Gateway's REST API
 	rest := NewServer(config).
@@ -42,7 +41,7 @@ func NewController(config *config.Config) (self *Controller, err error) {
 
 	// Setup everything, will start upon calling Controller.Start()
 	self.Task.
-		WithSubtask(server.Task).
+		WithConditionalSubtask(server != nil, server).
 		WithSubtask(rest.Task).
 		WithSubtask(monitor.Task)
 
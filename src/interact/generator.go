@@ -41,7 +41,7 @@ func NewGenerator(config *config.Config) (self *Generator) {
 
 	// Ethereum signer because it's faster
 	var err error
-	self.signer, err = bundlr.NewEthereumSigner(config.Interactor.GeneratorEthereumKey)
+	self.signer, err = bundlr.NewSigner(config.Interactor.GeneratorEthereumKey, bundlr.SignatureTypeEthereum)
 	if err != nil {
 		panic(err)
 	}
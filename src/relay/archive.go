@@ -0,0 +1,306 @@
+package relay
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	sequencertypes "github.com/warp-contracts/sequencer/x/sequencer/types"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/model"
+)
+
+// Archive is a directory with segment files. Each segment is a gzipped stream of gob encoded payloads,
+// ordered by sequencer height. Segment's name contains the range of heights it holds.
+const (
+	archiveSegmentPattern = "relay-*.gob.gz"
+	archiveSegmentFormat  = "relay-%012d-%012d.gob.gz"
+	archiveTmpSuffix      = ".tmp"
+)
+
+// Payload in a form that can be written to the archive.
+// Protobuf messages are stored as bytes, they don't survive gob encoding.
+type archivedPayload struct {
+	SequencerBlockHash      []byte
+	SequencerBlockHeight    int64
+	SequencerBlockTimestamp int64
+
+	LastArweaveBlock *sequencertypes.ArweaveBlockInfo
+
+	// Messages packed into Any
+	Messages [][]byte
+
+	Interactions []*model.Interaction
+	BundleItems  map[string]*model.BundleItem
+
+	ArweaveBlocks []*archivedArweaveBlock
+}
+
+type archivedArweaveBlock struct {
+	Message []byte
+
+	// Only fields used when storing the payload
+	Height    int64
+	Timestamp int64
+	IndepHash arweave.Base64String
+
+	Interactions      []*model.Interaction
+	MetaInfoDataItems []*model.DataItem
+}
+
+// Converts payloads to and from the archived form
+type archiveCodec struct {
+	cdc *codec.ProtoCodec
+}
+
+func newArchiveCodec() *archiveCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	sequencertypes.RegisterInterfaces(registry)
+	return &archiveCodec{cdc: codec.NewProtoCodec(registry)}
+}
+
+func (self *archiveCodec) encode(payload *Payload) (out *archivedPayload, err error) {
+	out = &archivedPayload{
+		SequencerBlockHash:      payload.SequencerBlockHash,
+		SequencerBlockHeight:    payload.SequencerBlockHeight,
+		SequencerBlockTimestamp: payload.SequencerBlockTimestamp,
+		LastArweaveBlock:        payload.LastArweaveBlock,
+		Messages:                make([][]byte, 0, len(payload.Messages)),
+		Interactions:            payload.Interactions,
+		BundleItems:             payload.BundleItems,
+		ArweaveBlocks:           make([]*archivedArweaveBlock, 0, len(payload.ArweaveBlocks)),
+	}
+
+	for _, msg := range payload.Messages {
+		var any *codectypes.Any
+		any, err = codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			return
+		}
+
+		var buf []byte
+		buf, err = self.cdc.Marshal(any)
+		if err != nil {
+			return
+		}
+		out.Messages = append(out.Messages, buf)
+	}
+
+	for _, arweaveBlock := range payload.ArweaveBlocks {
+		if arweaveBlock.Block == nil {
+			err = errors.New("arweave block not downloaded")
+			return
+		}
+
+		archived := &archivedArweaveBlock{
+			Height:            arweaveBlock.Block.Height,
+			Timestamp:         arweaveBlock.Block.Timestamp,
+			IndepHash:         arweaveBlock.Block.IndepHash,
+			Interactions:      arweaveBlock.Interactions,
+			MetaInfoDataItems: arweaveBlock.MetaInfoDataItems,
+		}
+
+		if arweaveBlock.Message != nil {
+			archived.Message, err = arweaveBlock.Message.Marshal()
+			if err != nil {
+				return
+			}
+		}
+
+		out.ArweaveBlocks = append(out.ArweaveBlocks, archived)
+	}
+
+	return
+}
+
+func (self *archiveCodec) decode(archived *archivedPayload) (out *Payload, err error) {
+	out = &Payload{
+		SequencerBlockHash:      archived.SequencerBlockHash,
+		SequencerBlockHeight:    archived.SequencerBlockHeight,
+		SequencerBlockTimestamp: archived.SequencerBlockTimestamp,
+		LastArweaveBlock:        archived.LastArweaveBlock,
+		Messages:                make([]cosmostypes.Msg, 0, len(archived.Messages)),
+		Interactions:            archived.Interactions,
+		BundleItems:             archived.BundleItems,
+		ArweaveBlocks:           make([]*ArweaveBlock, 0, len(archived.ArweaveBlocks)),
+	}
+
+	for _, buf := range archived.Messages {
+		var any codectypes.Any
+		err = self.cdc.Unmarshal(buf, &any)
+		if err != nil {
+			return
+		}
+
+		var msg cosmostypes.Msg
+		err = self.cdc.UnpackAny(&any, &msg)
+		if err != nil {
+			return
+		}
+		out.Messages = append(out.Messages, msg)
+	}
+
+	for _, archivedBlock := range archived.ArweaveBlocks {
+		arweaveBlock := &ArweaveBlock{
+			Block: &arweave.Block{
+				Height:    archivedBlock.Height,
+				Timestamp: archivedBlock.Timestamp,
+				IndepHash: archivedBlock.IndepHash,
+			},
+			Interactions:      archivedBlock.Interactions,
+			MetaInfoDataItems: archivedBlock.MetaInfoDataItems,
+		}
+
+		if archivedBlock.Message != nil {
+			arweaveBlock.Message = new(sequencertypes.MsgArweaveBlock)
+			err = arweaveBlock.Message.Unmarshal(archivedBlock.Message)
+			if err != nil {
+				return
+			}
+		}
+
+		out.ArweaveBlocks = append(out.ArweaveBlocks, arweaveBlock)
+	}
+
+	return
+}
+
+// Segment file that's being written. It gets its final name only after it's closed,
+// so interrupted exports don't leave incomplete segments behind.
+type archiveSegmentWriter struct {
+	dir   string
+	file  *os.File
+	gzip  *gzip.Writer
+	enc   *gob.Encoder
+	start int64
+	stop  int64
+	count int
+}
+
+func newArchiveSegmentWriter(dir string, start int64) (self *archiveSegmentWriter, err error) {
+	self = &archiveSegmentWriter{
+		dir:   dir,
+		start: start,
+	}
+
+	self.file, err = os.CreateTemp(dir, fmt.Sprintf("relay-%012d-*%s", start, archiveTmpSuffix))
+	if err != nil {
+		return
+	}
+
+	self.gzip = gzip.NewWriter(self.file)
+	self.enc = gob.NewEncoder(self.gzip)
+
+	return
+}
+
+func (self *archiveSegmentWriter) write(payload *archivedPayload) (err error) {
+	err = self.enc.Encode(payload)
+	if err != nil {
+		return
+	}
+	self.stop = payload.SequencerBlockHeight
+	self.count++
+	return
+}
+
+// Flushes the data and renames the file. Empty segments are removed.
+func (self *archiveSegmentWriter) close() (err error) {
+	err = self.gzip.Close()
+	if err != nil {
+		self.file.Close()
+		return
+	}
+
+	err = self.file.Sync()
+	if err != nil {
+		self.file.Close()
+		return
+	}
+
+	err = self.file.Close()
+	if err != nil {
+		return
+	}
+
+	if self.count == 0 {
+		return os.Remove(self.file.Name())
+	}
+
+	return os.Rename(self.file.Name(), filepath.Join(self.dir, fmt.Sprintf(archiveSegmentFormat, self.start, self.stop)))
+}
+
+type archiveSegment struct {
+	path  string
+	start int64
+	stop  int64
+}
+
+// Segments from the directory that overlap with the range, sorted by height. Zero stop means no upper limit
+func listArchiveSegments(dir string, start, stop int64) (out []*archiveSegment, err error) {
+	paths, err := filepath.Glob(filepath.Join(dir, archiveSegmentPattern))
+	if err != nil {
+		return
+	}
+
+	for _, path := range paths {
+		segment := &archiveSegment{path: path}
+		_, err = fmt.Sscanf(filepath.Base(path), archiveSegmentFormat, &segment.start, &segment.stop)
+		if err != nil {
+			err = fmt.Errorf("invalid segment name %s: %w", path, err)
+			return
+		}
+
+		if segment.stop < start || (stop > 0 && segment.start > stop) {
+			continue
+		}
+
+		out = append(out, segment)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].start < out[j].start
+	})
+
+	return
+}
+
+// Calls the callback for each payload from the segment, in order
+func readArchiveSegment(path string, f func(*archivedPayload) error) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	dec := gob.NewDecoder(reader)
+	for {
+		payload := new(archivedPayload)
+		err = dec.Decode(payload)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return
+		}
+
+		err = f(payload)
+		if err != nil {
+			return
+		}
+	}
+}
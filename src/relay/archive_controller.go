@@ -0,0 +1,125 @@
+package relay
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	monitor_relayer "github.com/warp-contracts/syncer/src/utils/monitoring/relayer"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Exports relayer's payloads to an archive or imports them back to the database.
+// Stops by itself once the whole range is processed.
+type ArchiveController struct {
+	*task.Task
+
+	mtx sync.Mutex
+	err error
+}
+
+// Downloads blocks from the range, processes them like the relayer does and writes payloads to the archive directory
+func NewArchiveExportController(config *config.Config, dir string, startHeight, stopHeight uint64) (self *ArchiveController, err error) {
+	if startHeight == 0 {
+		startHeight = 1
+	}
+	if stopHeight < startHeight {
+		err = errors.New("stop height needs to be set and can't be lower than start height")
+		return
+	}
+
+	self = new(ArchiveController)
+	self.Task = task.NewTask(config, "relay-archive-export")
+
+	monitor := monitor_relayer.NewMonitor(config)
+
+	sequencerPool := NewSequencerPool(config).
+		WithMonitor(monitor)
+
+	client := arweave.NewClient(self.Ctx, config)
+
+	// Only blocks from the range, without the streamer
+	source := NewSource(config).
+		WithMonitor(monitor).
+		WithSequencerPool(sequencerPool).
+		WithHeightRange(startHeight, stopHeight)
+
+	signer, err := bundlr.NewSigner(config.Bundlr.Wallet, bundlr.SignatureTypeArweave)
+	if err != nil {
+		return
+	}
+
+	pipeline := newPipeline(config, nil, nil, monitor, client, sequencerPool, signer, source.Output)
+
+	writer := NewArchiveWriter(config).
+		WithMonitor(monitor).
+		WithInputChannel(pipeline.Output).
+		WithDirectory(dir).
+		WithStopHeight(int64(stopHeight), self.onFinished)
+
+	self.Task = self.Task.
+		WithSubtask(monitor.Task).
+		WithSubtask(sequencerPool.Task).
+		WithSubtask(pipeline.WithSubtask(source.Task)).
+		WithSubtask(writer.Task)
+
+	return
+}
+
+// Replays payloads from the archive directory into the database. Zero stop height means the whole archive
+func NewArchiveImportController(config *config.Config, dir string, startHeight, stopHeight uint64) (self *ArchiveController, err error) {
+	if stopHeight != 0 && stopHeight < startHeight {
+		err = errors.New("stop height can't be lower than start height")
+		return
+	}
+
+	self = new(ArchiveController)
+	self.Task = task.NewTask(config, "relay-archive-import")
+
+	monitor := monitor_relayer.NewMonitor(config)
+
+	db, err := model.NewConnection(self.Ctx, config, "relayer-archive")
+	if err != nil {
+		return
+	}
+
+	reader := NewArchiveReader(config).
+		WithDB(db).
+		WithMonitor(monitor).
+		WithDirectory(dir).
+		WithHeightRange(int64(startHeight), int64(stopHeight)).
+		WithOnFinished(self.onFinished)
+
+	// Same as in the relayer, data that's already there gets replaced
+	store := NewStore(config).
+		WithInputChannel(reader.Output).
+		WithMonitor(monitor).
+		WithIsReplacing(true).
+		WithDB(db)
+
+	self.Task = self.Task.
+		WithSubtask(monitor.Task).
+		WithSubtask(store.Task).
+		WithSubtask(reader.Task)
+
+	return
+}
+
+func (self *ArchiveController) onFinished(err error) {
+	self.mtx.Lock()
+	self.err = err
+	self.mtx.Unlock()
+
+	// Called from a subtask, stopping waits for it
+	go self.Stop()
+}
+
+// Error that stopped the export or import, nil if all payloads were processed
+func (self *ArchiveController) Err() error {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	return self.err
+}
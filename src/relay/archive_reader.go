@@ -0,0 +1,182 @@
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Replays payloads from archive segments, in order of sequencer height.
+// Finishes once Store saved the last payload.
+type ArchiveReader struct {
+	*task.Task
+
+	db      *gorm.DB
+	monitor monitoring.Monitor
+	codec   *archiveCodec
+
+	dir         string
+	startHeight int64
+	stopHeight  int64
+	onFinished  func(error)
+
+	// Height of the last payload sent to the output
+	lastHeight int64
+
+	Output chan *Payload
+}
+
+func NewArchiveReader(config *config.Config) (self *ArchiveReader) {
+	self = new(ArchiveReader)
+
+	self.codec = newArchiveCodec()
+
+	self.Output = make(chan *Payload)
+
+	self.Task = task.NewTask(config, "archive-reader").
+		WithOnBeforeStart(self.init).
+		WithSubtaskFunc(self.run).
+		WithOnAfterStop(func() {
+			close(self.Output)
+		})
+
+	return
+}
+
+func (self *ArchiveReader) WithMonitor(monitor monitoring.Monitor) *ArchiveReader {
+	self.monitor = monitor
+	return self
+}
+
+func (self *ArchiveReader) WithDB(db *gorm.DB) *ArchiveReader {
+	self.db = db
+	return self
+}
+
+func (self *ArchiveReader) WithDirectory(v string) *ArchiveReader {
+	self.dir = v
+	return self
+}
+
+// Zero stop height means all payloads from the archive
+func (self *ArchiveReader) WithHeightRange(start, stop int64) *ArchiveReader {
+	self.startHeight = start
+	self.stopHeight = stop
+	return self
+}
+
+// Callback is run once all payloads are saved, or reading failed
+func (self *ArchiveReader) WithOnFinished(f func(error)) *ArchiveReader {
+	self.onFinished = f
+	return self
+}
+
+// Store only updates the sync state, it needs to exist for the relayer to continue from the imported height.
+// Height saved by the relayer is kept if it's ahead of the imported blocks
+func (self *ArchiveReader) init() (err error) {
+	err = self.db.WithContext(self.Ctx).
+		Table(model.TableState).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.State{Name: model.SyncedComponentRelayer}).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to initialize sync state of the relayer")
+	}
+	return
+}
+
+func (self *ArchiveReader) send(archived *archivedPayload) (err error) {
+	if archived.SequencerBlockHeight < self.startHeight ||
+		archived.SequencerBlockHeight <= self.lastHeight ||
+		(self.stopHeight > 0 && archived.SequencerBlockHeight > self.stopHeight) {
+		return
+	}
+
+	if self.lastHeight != 0 && archived.SequencerBlockHeight != self.lastHeight+1 {
+		return fmt.Errorf("gap in the archive between %d and %d", self.lastHeight, archived.SequencerBlockHeight)
+	}
+
+	payload, err := self.codec.decode(archived)
+	if err != nil {
+		return
+	}
+
+	select {
+	case <-self.Ctx.Done():
+		return errors.New("task closing")
+	case self.Output <- payload:
+		self.lastHeight = payload.SequencerBlockHeight
+	}
+
+	return
+}
+
+func (self *ArchiveReader) replay() (err error) {
+	segments, err := listArchiveSegments(self.dir, self.startHeight, self.stopHeight)
+	if err != nil {
+		return
+	}
+
+	if len(segments) == 0 {
+		return errors.New("no archive segments in the range")
+	}
+
+	for _, segment := range segments {
+		self.Log.WithField("path", segment.path).Info("Importing archive segment")
+
+		err = readArchiveSegment(segment.path, self.send)
+		if err != nil {
+			return
+		}
+	}
+
+	if self.stopHeight > 0 && self.lastHeight < self.stopHeight {
+		return fmt.Errorf("archive ends at %d, before the stop height %d", self.lastHeight, self.stopHeight)
+	}
+
+	return
+}
+
+// Store saves payloads in batches, importing is done only after the last batch is flushed
+func (self *ArchiveReader) waitForStore() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for self.monitor.GetReport().Relayer.State.SequencerFinishedHeight.Load() < self.lastHeight {
+		select {
+		case <-self.Ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+
+	self.Log.WithField("sequencer_height", self.lastHeight).Info("Finished importing payloads")
+}
+
+func (self *ArchiveReader) run() (err error) {
+	err = self.replay()
+	if err != nil {
+		if self.IsStopping.Load() {
+			return nil
+		}
+
+		self.Log.WithError(err).WithField("sequencer_height", self.lastHeight).Error("Failed to import archive")
+		self.monitor.SetPermanentError(err)
+	} else {
+		self.waitForStore()
+	}
+
+	self.onFinished(err)
+
+	// Wait till the context is done
+	<-self.Ctx.Done()
+
+	return nil
+}
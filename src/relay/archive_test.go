@@ -0,0 +1,135 @@
+package relay
+
+import (
+	"os"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	sequencertypes "github.com/warp-contracts/sequencer/x/sequencer/types"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/model"
+)
+
+type ArchiveTestSuite struct {
+	suite.Suite
+
+	codec *archiveCodec
+	dir   string
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
+
+func (s *ArchiveTestSuite) SetupTest() {
+	s.codec = newArchiveCodec()
+	s.dir = s.T().TempDir()
+}
+
+func (s *ArchiveTestSuite) payload(height int64) *Payload {
+	blockInfo := &sequencertypes.ArweaveBlockInfo{Height: 1000, Timestamp: 1700000000, Hash: "hash"}
+
+	bundleItem := &model.BundleItem{
+		InteractionID: 1,
+		State:         model.BundleStatePending,
+		Tags:          pgtype.JSONB{Bytes: []byte(`[{"name":"Sequencer-Timestamp","value":"1700000000000"}]`), Status: pgtype.Present},
+		DataItem:      pgtype.Bytea{Bytes: []byte{1, 2, 3}, Status: pgtype.Present},
+	}
+
+	return &Payload{
+		SequencerBlockHash:      []byte{0xaa, 0xbb},
+		SequencerBlockHeight:    height,
+		SequencerBlockTimestamp: 1700000000000 + height,
+		LastArweaveBlock:        blockInfo,
+		Messages: []cosmostypes.Msg{
+			&sequencertypes.MsgArweaveBlock{BlockInfo: blockInfo},
+		},
+		Interactions: []*model.Interaction{
+			{ContractId: "contract", SortKey: "sort-key"},
+		},
+		BundleItems: map[string]*model.BundleItem{
+			"interaction": bundleItem,
+		},
+		ArweaveBlocks: []*ArweaveBlock{{
+			Message: &sequencertypes.MsgArweaveBlock{BlockInfo: blockInfo},
+			Block: &arweave.Block{
+				Height:    1000,
+				Timestamp: 1700000000,
+				IndepHash: arweave.Base64String{1, 2, 3},
+			},
+			Interactions: []*model.Interaction{
+				{ContractId: "l1-contract", SortKey: "l1-sort-key"},
+			},
+		}},
+	}
+}
+
+func (s *ArchiveTestSuite) TestCodecRoundTrip() {
+	payload := s.payload(10)
+
+	archived, err := s.codec.encode(payload)
+	require.Nil(s.T(), err)
+
+	decoded, err := s.codec.decode(archived)
+	require.Nil(s.T(), err)
+
+	require.Equal(s.T(), payload.SequencerBlockHash, decoded.SequencerBlockHash)
+	require.Equal(s.T(), payload.SequencerBlockHeight, decoded.SequencerBlockHeight)
+	require.Equal(s.T(), payload.SequencerBlockTimestamp, decoded.SequencerBlockTimestamp)
+	require.Equal(s.T(), payload.LastArweaveBlock, decoded.LastArweaveBlock)
+	require.Equal(s.T(), payload.Messages, decoded.Messages)
+	require.Equal(s.T(), payload.Interactions, decoded.Interactions)
+	require.Equal(s.T(), payload.BundleItems, decoded.BundleItems)
+	require.Len(s.T(), decoded.ArweaveBlocks, 1)
+	require.Equal(s.T(), payload.ArweaveBlocks[0].Message, decoded.ArweaveBlocks[0].Message)
+	require.Equal(s.T(), payload.ArweaveBlocks[0].Block.IndepHash, decoded.ArweaveBlocks[0].Block.IndepHash)
+	require.Equal(s.T(), payload.ArweaveBlocks[0].Interactions, decoded.ArweaveBlocks[0].Interactions)
+}
+
+func (s *ArchiveTestSuite) TestSegmentRoundTrip() {
+	writer, err := newArchiveSegmentWriter(s.dir, 10)
+	require.Nil(s.T(), err)
+
+	for height := int64(10); height <= 12; height++ {
+		archived, err := s.codec.encode(s.payload(height))
+		require.Nil(s.T(), err)
+		require.Nil(s.T(), writer.write(archived))
+	}
+	require.Nil(s.T(), writer.close())
+
+	segments, err := listArchiveSegments(s.dir, 0, 0)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), segments, 1)
+	require.Equal(s.T(), int64(10), segments[0].start)
+	require.Equal(s.T(), int64(12), segments[0].stop)
+
+	var heights []int64
+	err = readArchiveSegment(segments[0].path, func(archived *archivedPayload) error {
+		payload, err := s.codec.decode(archived)
+		if err != nil {
+			return err
+		}
+		require.Equal(s.T(), s.payload(payload.SequencerBlockHeight).BundleItems, payload.BundleItems)
+		heights = append(heights, payload.SequencerBlockHeight)
+		return nil
+	})
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), []int64{10, 11, 12}, heights)
+}
+
+func (s *ArchiveTestSuite) TestEmptySegmentIsRemoved() {
+	writer, err := newArchiveSegmentWriter(s.dir, 10)
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), writer.close())
+
+	entries, err := os.ReadDir(s.dir)
+	require.Nil(s.T(), err)
+	require.Empty(s.T(), entries)
+
+	segments, err := listArchiveSegments(s.dir, 0, 0)
+	require.Nil(s.T(), err)
+	require.Empty(s.T(), segments)
+}
@@ -0,0 +1,149 @@
+package relay
+
+import (
+	"os"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Writes payloads to archive segments in the directory.
+// New segment is started every ArchiveSegmentSize payloads.
+type ArchiveWriter struct {
+	*task.Task
+
+	monitor monitoring.Monitor
+	codec   *archiveCodec
+
+	dir        string
+	stopHeight int64
+	onFinished func(error)
+
+	segment *archiveSegmentWriter
+
+	input chan *Payload
+}
+
+func NewArchiveWriter(config *config.Config) (self *ArchiveWriter) {
+	self = new(ArchiveWriter)
+
+	self.codec = newArchiveCodec()
+
+	self.Task = task.NewTask(config, "archive-writer").
+		WithOnBeforeStart(self.init).
+		WithSubtaskFunc(self.run).
+		WithOnAfterStop(func() {
+			// Keep what's been exported so far
+			err := self.closeSegment()
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to close archive segment")
+			}
+		})
+
+	return
+}
+
+func (self *ArchiveWriter) WithMonitor(monitor monitoring.Monitor) *ArchiveWriter {
+	self.monitor = monitor
+	return self
+}
+
+func (self *ArchiveWriter) WithInputChannel(v chan *Payload) *ArchiveWriter {
+	self.input = v
+	return self
+}
+
+func (self *ArchiveWriter) WithDirectory(v string) *ArchiveWriter {
+	self.dir = v
+	return self
+}
+
+// Callback is run once the payload at this height is written, or writing failed
+func (self *ArchiveWriter) WithStopHeight(height int64, onFinished func(error)) *ArchiveWriter {
+	self.stopHeight = height
+	self.onFinished = onFinished
+	return self
+}
+
+func (self *ArchiveWriter) init() (err error) {
+	err = os.MkdirAll(self.dir, 0o755)
+	if err != nil {
+		self.Log.WithError(err).WithField("dir", self.dir).Error("Failed to create archive directory")
+	}
+	return
+}
+
+func (self *ArchiveWriter) closeSegment() (err error) {
+	if self.segment == nil {
+		return
+	}
+
+	segment := self.segment
+	self.segment = nil
+
+	err = segment.close()
+	if err != nil {
+		return
+	}
+
+	self.Log.WithField("start", segment.start).
+		WithField("stop", segment.stop).
+		WithField("num", segment.count).
+		Info("Saved archive segment")
+	return
+}
+
+func (self *ArchiveWriter) write(payload *Payload) (err error) {
+	archived, err := self.codec.encode(payload)
+	if err != nil {
+		return
+	}
+
+	if self.segment == nil {
+		self.segment, err = newArchiveSegmentWriter(self.dir, payload.SequencerBlockHeight)
+		if err != nil {
+			return
+		}
+	}
+
+	err = self.segment.write(archived)
+	if err != nil {
+		return
+	}
+
+	if self.segment.count >= self.Config.Relayer.ArchiveSegmentSize {
+		err = self.closeSegment()
+	}
+
+	return
+}
+
+func (self *ArchiveWriter) run() (err error) {
+	for payload := range self.input {
+		err = self.write(payload)
+		if err == nil && payload.SequencerBlockHeight < self.stopHeight {
+			continue
+		}
+
+		if err == nil {
+			err = self.closeSegment()
+		}
+
+		if err != nil {
+			// Gaps in the archive aren't allowed, exporting stops here
+			self.Log.WithError(err).WithField("sequencer_height", payload.SequencerBlockHeight).Error("Failed to archive payload")
+			self.monitor.SetPermanentError(err)
+		} else {
+			self.Log.WithField("sequencer_height", payload.SequencerBlockHeight).Info("Finished exporting payloads")
+		}
+
+		self.onFinished(err)
+
+		// Wait till the context is done
+		<-self.Ctx.Done()
+		return nil
+	}
+
+	return nil
+}
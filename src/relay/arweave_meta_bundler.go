@@ -2,6 +2,7 @@ package relay
 
 import (
 	"crypto/sha256"
+	"database/sql"
 	"errors"
 
 	"github.com/jackc/pgtype"
@@ -10,6 +11,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 // Uses MsgArweaveBlock messages to create one nested bundle
@@ -18,7 +20,7 @@ type ArweaveMetaBundler struct {
 	*task.Task
 
 	monitor monitoring.Monitor
-	signer  *bundlr.ArweaveSigner
+	signer  bundlr.Signer
 
 	input  <-chan *Payload
 	Output chan *Payload
@@ -29,12 +31,6 @@ func NewArweaveMetaBundler(config *config.Config) (self *ArweaveMetaBundler) {
 
 	self.Output = make(chan *Payload)
 
-	var err error
-	self.signer, err = bundlr.NewArweaveSigner(config.Bundlr.Wallet)
-	if err != nil {
-		self.Log.WithError(err).Panic("Failed to create bundlr signer")
-	}
-
 	self.Task = task.NewTask(config, "arweave_meta_bundler").
 		WithSubtaskFunc(self.run).
 		WithOnAfterStop(func() {
@@ -49,6 +45,12 @@ func (self *ArweaveMetaBundler) WithMonitor(monitor monitoring.Monitor) *Arweave
 	return self
 }
 
+// Signer is created once by the controller, it survives restarts of the pipeline
+func (self *ArweaveMetaBundler) WithSigner(v bundlr.Signer) *ArweaveMetaBundler {
+	self.signer = v
+	return self
+}
+
 func (self *ArweaveMetaBundler) WithInputChannel(v <-chan *Payload) *ArweaveMetaBundler {
 	self.input = v
 	return self
@@ -102,6 +104,7 @@ func (self *ArweaveMetaBundler) fill(payload *Payload) (err error) {
 					BlockHeight: pgtype.Int8{Status: pgtype.Null},
 					Response:    pgtype.JSONB{Status: pgtype.Null},
 					Service:     pgtype.Text{Status: pgtype.Null},
+					ContractId:  sql.NullString{String: arweaveBlock.Interactions[i].ContractId, Valid: true},
 				},
 			)
 		}
@@ -112,7 +115,9 @@ func (self *ArweaveMetaBundler) fill(payload *Payload) (err error) {
 
 func (self *ArweaveMetaBundler) run() (err error) {
 	for payload := range self.input {
+		_, span := payload.startSpan(self.Ctx, self.Name)
 		err = self.fill(payload)
+		tracing.End(span, err)
 		if err != nil {
 			if self.IsStopping.Load() {
 				// Neglect, we're stopping anyway
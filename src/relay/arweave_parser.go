@@ -9,6 +9,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/smartweave"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 	"github.com/warp-contracts/syncer/src/utils/warp"
 )
 
@@ -58,6 +59,7 @@ func (self *ArweaveParser) WithInputChannel(v chan *Payload) *ArweaveParser {
 
 func (self *ArweaveParser) run() error {
 	for payload := range self.input {
+		_, span := payload.startSpan(self.Ctx, self.Name)
 		sequencerBlock := &smartweave.SequencerBlock {
 			Height: payload.SequencerBlockHeight,
 			Timestamp: payload.SequencerBlockTimestamp,
@@ -66,6 +68,7 @@ func (self *ArweaveParser) run() error {
 			var err error
 			payload.ArweaveBlocks[i].Interactions, err = self.parseAll(arweaveBlock, sequencerBlock)
 			if err != nil {
+				tracing.End(span, err)
 				if self.IsStopping.Load() {
 					// Neglect those transactions, we're stopping anyway
 					return nil
@@ -91,6 +94,8 @@ func (self *ArweaveParser) run() error {
 				Debug("Parsed interactions")
 		}
 
+		span.End()
+
 		select {
 		case <-self.Ctx.Done():
 			return nil
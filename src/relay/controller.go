@@ -1,63 +1,65 @@
 package relay
 
 import (
-	"time"
-
-	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cometbft/cometbft/types"
 	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/deadletter"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
+	"github.com/warp-contracts/syncer/src/utils/leader"
 	"github.com/warp-contracts/syncer/src/utils/listener"
 	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_relayer "github.com/warp-contracts/syncer/src/utils/monitoring/relayer"
 	"github.com/warp-contracts/syncer/src/utils/peer_monitor"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"gorm.io/gorm"
 )
 
 type Controller struct {
 	*task.Task
 }
 
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "relayer")
 
 	// Monitoring
 	monitor := monitor_relayer.NewMonitor(config).
 		WithMaxHistorySize(30)
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "relay", monitor)
 
-	watched := func() *task.Task {
-		// SQL database
-		db, err := model.NewConnection(self.Ctx, config, "relayer")
-		if err != nil {
-			panic(err)
-		}
-
-		// Arweave client
-		client := arweave.NewClient(self.Ctx, config)
+	// Sequencer/Cosmos nodes, health checked. Survives restarts of the watched task
+	sequencerPool := NewSequencerPool(config).
+		WithMonitor(monitor)
 
-		// Monitor current network height (output is disabled)
-		networkMonitor := listener.NewNetworkMonitor(config).
-			WithClient(client).
-			WithMonitor(monitor).
-			WithInterval(config.NetworkMonitor.Period).
-			WithEnableOutput(false)
+	// SQL database. Shadow relayer only reads, it doesn't even run migrations.
+	// Connection pool survives restarts of the watched task
+	var db *gorm.DB
+	if config.Relayer.ShadowEnabled {
+		db, err = model.NewReadOnlyConnection(self.Ctx, config, "relayer-shadow")
+	} else {
+		db, err = resources.GetDB(self.Ctx, config, "relayer")
+	}
+	if err != nil {
+		return
+	}
 
-		peerMonitor := peer_monitor.NewPeerMonitor(config).
-			WithClient(client).
-			WithMonitor(monitor)
+	// Signs nested bundles with L1 interactions. Remote signer fetches the owner here, it may be unreachable
+	signer, err := bundlr.NewSigner(config.Bundlr.Wallet, bundlr.SignatureTypeArweave)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to create bundlr signer")
+		return
+	}
 
-		// Sequencer/Cosmos client
-		sequencerClient, err := rpchttp.New(config.Relayer.SequencerUrl, "/websocket")
-		if err != nil {
-			panic(err)
-		}
+	watched := func() *task.Task {
+		// Arweave client
+		client := resources.GetArweaveClient(self.Ctx, config)
 
 		// Events from Warp's sequencer
 		streamer := NewStreamer(config).
-			WithClient(sequencerClient).
+			WithSequencerPool(sequencerPool).
 			WithMonitor(monitor)
 		streamer.Resume()
 
@@ -65,90 +67,165 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		source := NewSource(config).
 			WithDB(db).
 			WithMonitor(monitor).
-			WithClient(sequencerClient).
+			WithSequencerPool(sequencerPool).
 			WithInputChannel(streamer.Output)
 
-		// Decodes messages, creates payload
-		decoder := NewDecoder(config).
-			WithMonitor(monitor).
-			WithInputChannel(source.Output)
-
-		// Fills in arweave block info in the payload
-		msgArweaveBlockParser := NewMsgArweaveBlockParser(config).
-			WithMonitor(monitor).
-			WithInputChannel(decoder.Output)
+		// Turns blocks into payloads
+		pipeline := newPipeline(config, resources, db, monitor, client, sequencerPool, signer, source.Output)
 
-		lastArweaveBlockProvider := NewLastArweaveBlockProvider(config).
-			WithInputChannel(msgArweaveBlockParser.Output).
-			WithClient(sequencerClient).
-			WithDecoder(decoder).
-			WithMonitor(monitor)
-
-		// Parses blocks into payload
-		msgDataItemParser := NewMsgDataItemParser(config).
-			WithMonitor(monitor).
-			WithInputChannel(lastArweaveBlockProvider.Output)
-
-		// Fill in Arweave blocks
-		blockDownloader := NewOneBlockDownloader(config).
-			WithMonitor(monitor).
-			WithClient(client).
-			WithInputChannel(msgDataItemParser.Output)
-
-		// Download transactions from Arweave, but only those specified by the sequencer
-		transactionDownloader := NewTransactionDownloader(config).
-			WithMonitor(monitor).
-			WithClient(client).
-			WithInputChannel(blockDownloader.Output)
-
-		// Parse arweave transactions into interactions
-		arweaveParser := NewArweaveParser(config).
-			WithMonitor(monitor).
-			WithInputChannel(transactionDownloader.Output)
+		if config.Relayer.ShadowEnabled {
+			// Only compare with what the production relayer saved
+			shadow := NewShadow(config).
+				WithInputChannel(pipeline.Output).
+				WithMonitor(monitor).
+				WithDB(db)
 
-		arweaveMetaBundler := NewArweaveMetaBundler(config).
-			WithMonitor(monitor).
-			WithInputChannel(arweaveParser.Output)
+			return pipeline.Task.
+				WithSubtask(source.Task).
+				WithSubtask(shadow.Task).
+				WithSubtask(streamer.Task)
+		}
 
 		// Store blocks in the database, in batches
 		store := NewStore(config).
-			WithInputChannel(arweaveMetaBundler.Output).
+			WithInputChannel(pipeline.Output).
 			WithMonitor(monitor).
 			WithIsReplacing(true).
+			WithErrorSink(deadletter.NewSink(config, db, "relayer")).
+			WithDB(db)
+
+		// Saves sampled latencies for the slo command
+		recorder := freshness.NewRecorder(config).
 			WithDB(db)
 
-		return task.NewTask(config, "watched").
+		return pipeline.Task.
 			WithSubtask(source.Task).
-			WithSubtask(decoder.Task).
-			WithSubtask(msgArweaveBlockParser.Task).
-			WithSubtask(lastArweaveBlockProvider.Task).
-			WithSubtask(msgDataItemParser.Task).
-			WithSubtask(networkMonitor.Task).
-			WithSubtask(blockDownloader.Task).
-			WithSubtask(transactionDownloader.Task).
-			WithSubtask(arweaveParser.Task).
-			WithSubtask(arweaveMetaBundler.Task).
 			WithSubtask(store.Task).
 			WithSubtask(streamer.Task).
-			WithSubtask(peerMonitor.Task)
+			WithConditionalSubtask(config.Freshness.Enabled, recorder.Task)
 	}
 
 	watchdog := task.NewWatchdog(config).
 		WithTask(watched).
-		WithIsOK(30*time.Second, func() bool {
+		WithRunReport(monitor.GetReport().Run).
+		WithIsOK(config.Watchdog.CheckInterval, func() bool {
 			isOK := monitor.IsOK()
 			if !isOK {
 				monitor.Clear()
-				monitor.GetReport().Run.Errors.NumWatchdogRestarts.Inc()
 			}
 			return isOK
 		})
 
+	if config.LeaderElection.Enabled && !config.Relayer.ShadowEnabled {
+		// Only the leader runs the pipeline, standby waits for the lease
+		elector := leader.WatchStandby(config, "relayer", db, monitor, watchdog)
+		self.Task.
+			WithSubtask(elector.Task).
+			WithOnAfterStop(elector.Release)
+	}
+
 	// Setup everything, will start upon calling Controller.Start()
 	self.Task.
 		WithSubtask(monitor.Task).
-		WithSubtask(server.Task).
+		WithSubtask(sequencerPool.Task).
+		WithConditionalSubtask(server != nil, server).
 		WithSubtask(watchdog.Task)
 
 	return
 }
+
+// Tasks that turn Sequencer's blocks into payloads ready to be stored
+type pipeline struct {
+	*task.Task
+
+	Output chan *Payload
+}
+
+func newPipeline(config *config.Config, resources *shared.Resources, db *gorm.DB, monitor *monitor_relayer.Monitor, client *arweave.Client, sequencerPool *SequencerPool, signer bundlr.Signer, blocks chan *types.Block) (self *pipeline) {
+	self = new(pipeline)
+
+	// Monitor current network height (output is disabled)
+	networkMonitor := listener.NewNetworkMonitor(config).
+		WithClient(client).
+		WithMonitor(monitor).
+		WithInterval(config.NetworkMonitor.Period).
+		WithEnableOutput(false)
+
+	peerMonitor := peer_monitor.NewPeerMonitor(config).
+		WithClient(client).
+		WithMonitor(monitor)
+
+	// Verifies blocks with the light client, if enabled
+	var verifier *Verifier
+	if config.Relayer.LightClientEnabled {
+		verifier = NewVerifier(config).
+			WithDB(db).
+			WithMonitor(monitor).
+			WithSequencerPool(sequencerPool).
+			WithInputChannel(blocks)
+		blocks = verifier.Output
+	}
+
+	// Decodes messages, creates payload
+	decoder := NewDecoder(config).
+		WithMonitor(monitor).
+		WithInputChannel(blocks)
+
+	// Fills in arweave block info in the payload
+	msgArweaveBlockParser := NewMsgArweaveBlockParser(config).
+		WithMonitor(monitor).
+		WithInputChannel(decoder.Output)
+
+	lastArweaveBlockProvider := NewLastArweaveBlockProvider(config).
+		WithInputChannel(msgArweaveBlockParser.Output).
+		WithSequencerPool(sequencerPool).
+		WithDecoder(decoder).
+		WithMonitor(monitor)
+
+	// Parses blocks into payload
+	msgDataItemParser := NewMsgDataItemParser(config).
+		WithMonitor(monitor).
+		WithInputChannel(lastArweaveBlockProvider.Output)
+
+	// Fill in Arweave blocks
+	blockDownloader := NewOneBlockDownloader(config).
+		WithMonitor(monitor).
+		WithClient(client).
+		WithInputChannel(msgDataItemParser.Output)
+
+	// Download transactions from Arweave, but only those specified by the sequencer
+	transactionDownloader := NewTransactionDownloader(config).
+		WithMonitor(monitor).
+		WithClient(client).
+		WithInputChannel(blockDownloader.Output)
+
+	// Parse arweave transactions into interactions
+	arweaveParser := NewArweaveParser(config).
+		WithMonitor(monitor).
+		WithInputChannel(transactionDownloader.Output)
+
+	arweaveMetaBundler := NewArweaveMetaBundler(config).
+		WithSigner(signer).
+		WithMonitor(monitor).
+		WithInputChannel(arweaveParser.Output)
+
+	self.Output = arweaveMetaBundler.Output
+
+	self.Task = task.NewTask(config, "watched").
+		WithSubtask(decoder.Task).
+		WithSubtask(msgArweaveBlockParser.Task).
+		WithSubtask(lastArweaveBlockProvider.Task).
+		WithSubtask(msgDataItemParser.Task).
+		WithSubtask(networkMonitor.Task).
+		WithSubtask(blockDownloader.Task).
+		WithSubtask(transactionDownloader.Task).
+		WithSubtask(arweaveParser.Task).
+		WithSubtask(arweaveMetaBundler.Task).
+		WithConditionalSubtask(!resources.IsPeerMonitorShared(), peerMonitor.Task)
+
+	if verifier != nil {
+		self.Task.WithSubtask(verifier.Task)
+	}
+
+	return
+}
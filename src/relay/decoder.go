@@ -17,6 +17,9 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Parses Sequencer's blocks into payload
@@ -156,7 +159,12 @@ func (self *Decoder) run() (err error) {
 	// Each payload has a slice of transactions
 	var payload *Payload
 	for block := range self.input {
+		// Payload's path through the pipeline starts here
+		ctx, span := tracing.Start(self.Ctx, self.Name,
+			trace.WithNewRoot(),
+			trace.WithAttributes(attribute.Int64("sequencer_height", block.Height)))
 		payload, err = self.decodeBlock(block)
+		tracing.End(span, err)
 		if err != nil {
 			if self.IsStopping.Load() {
 				// Neglect those transactions, we're stopping anyway
@@ -169,6 +177,7 @@ func (self *Decoder) run() (err error) {
 			// We can't neglect parsing errors
 			panic(err)
 		}
+		payload.Trace.SetFromContext(ctx)
 
 		select {
 		case <-self.Ctx.Done():
@@ -6,11 +6,11 @@ import (
 	"fmt"
 	"time"
 
-	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	sequencertypes "github.com/warp-contracts/sequencer/x/sequencer/types"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 // Fills the last arweave block in the Payload
@@ -19,7 +19,7 @@ type LastArweaveBlockProvider struct {
 	*task.Task
 
 	monitor monitoring.Monitor
-	client  *rpchttp.HTTP
+	pool    *SequencerPool
 	decoder *Decoder
 
 	input  <-chan *Payload
@@ -54,8 +54,8 @@ func (self *LastArweaveBlockProvider) WithInputChannel(v <-chan *Payload) *LastA
 	return self
 }
 
-func (self *LastArweaveBlockProvider) WithClient(client *rpchttp.HTTP) *LastArweaveBlockProvider {
-	self.client = client
+func (self *LastArweaveBlockProvider) WithSequencerPool(pool *SequencerPool) *LastArweaveBlockProvider {
+	self.pool = pool
 	return self
 }
 
@@ -71,8 +71,10 @@ func (self *LastArweaveBlockProvider) getLastBlockHeight(payload *Payload) (out
 	query := fmt.Sprintf("tx.height <= %d AND message.action='/sequencer.sequencer.MsgArweaveBlock'", payload.SequencerBlockHeight)
 	page := 1
 	perPage := 1
-	results, err := self.client.TxSearch(ctx, query, false /*prove*/, &page /*page*/, &perPage /*per page*/, "desc" /*order by*/)
+	endpoint := self.pool.Best()
+	results, err := endpoint.Client.TxSearch(ctx, query, false /*prove*/, &page /*page*/, &perPage /*per page*/, "desc" /*order by*/)
 	if err != nil {
+		self.pool.OnError(endpoint)
 		return
 	}
 
@@ -128,7 +130,9 @@ func (self *LastArweaveBlockProvider) fill(payload *Payload) (err error) {
 
 func (self *LastArweaveBlockProvider) run() (err error) {
 	for payload := range self.input {
+		_, span := payload.startSpan(self.Ctx, self.Name)
 		err = self.fill(payload)
+		tracing.End(span, err)
 		if err != nil {
 			if self.IsStopping.Load() {
 				// Neglect, we're stopping anyway
@@ -9,6 +9,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 // Parses sequencer.sequencer.MsgArweaveBlock
@@ -71,7 +72,9 @@ func (self *MsgArweaveBlockParser) parse(payload *Payload) (out *Payload, err er
 func (self *MsgArweaveBlockParser) run() (err error) {
 	var payload *Payload
 	for inPayload := range self.input {
+		_, span := inPayload.startSpan(self.Ctx, self.Name)
 		payload, err = self.parse(inPayload)
+		tracing.End(span, err)
 		if err != nil {
 			if self.IsStopping.Load() {
 				// Neglect, we're stopping anyway
@@ -21,6 +21,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/smartweave"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 	"github.com/warp-contracts/syncer/src/utils/warp"
 )
 
@@ -207,67 +208,93 @@ func (self *MsgDataItemParser) parse(payload *Payload) (err error) {
 	// Parse transactions in parallel
 	var wg sync.WaitGroup
 	wg.Add(len(payload.Messages))
-	var mtx sync.Mutex
 
-	payload.Interactions = make([]*model.Interaction, 0, len(payload.Messages))
-	payload.BundleItems = make([]*model.BundleItem, 0, len(payload.Messages))
+	// Results are put in the order of messages
+	interactions := make([]*model.Interaction, len(payload.Messages))
+	bundleItems := make([]*model.BundleItem, len(payload.Messages))
 
 	for i := range payload.Messages {
 		i := i
 
 		self.SubmitToWorker(func() {
-			var (
-				interaction *model.Interaction
-				bundleItem  *model.BundleItem
-				errMsg      error
-			)
+			defer wg.Done()
 
 			if proto.MessageName(payload.Messages[i]) != "sequencer.sequencer.MsgDataItem" {
-				goto done
+				return
 			}
 
-			interaction, bundleItem, errMsg = self.parseMessage(payload.Messages[i], payload)
-
-			mtx.Lock()
-			defer mtx.Unlock()
-
+			interaction, bundleItem, errMsg := self.parseMessage(payload.Messages[i], payload)
 			if errMsg != nil {
 				self.monitor.GetReport().Relayer.Errors.SequencerPermanentParsingError.Inc()
-				self.Log.WithError(err).
+				self.Log.WithError(errMsg).
 					WithField("idx", i).
 					WithField("sequencer_height", payload.SequencerBlockHeight).
 					Error("Failed to parse MsgDataItem")
-				goto done
+				return
 			}
 
-			// Append data
-			payload.Interactions = append(payload.Interactions, interaction)
-			payload.BundleItems = append(payload.BundleItems, bundleItem)
+			interactions[i] = interaction
+			bundleItems[i] = bundleItem
 
 			// Update monitoring
 			self.monitor.GetReport().Relayer.State.SequencerTransactionsParsed.Inc()
-
-		done:
-			wg.Done()
 		})
 	}
 
 	// Wait for all transactions to be parsed
 	wg.Wait()
 
+	payload.Interactions = make([]*model.Interaction, 0, len(payload.Messages))
+	for _, interaction := range interactions {
+		if interaction != nil {
+			payload.Interactions = append(payload.Interactions, interaction)
+		}
+	}
+
+	// Sort keys are assigned by the sequencer to every message, including duplicates
 	err = self.validateSortKeys(payload, payload.Interactions)
 	if err != nil {
 		self.Log.WithField("sequencer_height", payload.SequencerBlockHeight).WithError(err).Error("Failed to validate sort key")
 		return
 	}
 
+	payload.Interactions, payload.BundleItems = self.removeDuplicates(payload, interactions, bundleItems)
+
+	return
+}
+
+// Interaction that appears more than once in the block is stored only once, the first occurrence wins
+func (self *MsgDataItemParser) removeDuplicates(payload *Payload, interactions []*model.Interaction, bundleItems []*model.BundleItem) (outInteractions []*model.Interaction, outBundleItems map[string]*model.BundleItem) {
+	outInteractions = make([]*model.Interaction, 0, len(interactions))
+	outBundleItems = make(map[string]*model.BundleItem, len(interactions))
+
+	for i, interaction := range interactions {
+		if interaction == nil {
+			continue
+		}
+
+		id := interaction.InteractionId.Base64()
+		if _, ok := outBundleItems[id]; ok {
+			self.Log.WithField("sequencer_height", payload.SequencerBlockHeight).
+				WithField("interaction_id", id).
+				WithField("sort_key", interaction.SortKey).
+				Warn("Duplicate interaction in the block, skipping")
+			continue
+		}
+
+		outInteractions = append(outInteractions, interaction)
+		outBundleItems[id] = bundleItems[i]
+	}
+
 	return
 }
 
 func (self *MsgDataItemParser) run() (err error) {
 	// Each payload has a slice of transactions
 	for payload := range self.input {
+		_, span := payload.startSpan(self.Ctx, self.Name)
 		err = self.parse(payload)
+		tracing.End(span, err)
 		if err != nil {
 			if self.IsStopping.Load() {
 				// Neglect those transactions, we're stopping anyway
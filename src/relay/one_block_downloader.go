@@ -11,6 +11,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 // Task for filling in the arweave blocks in Payload
@@ -57,8 +58,8 @@ func (self *OneBlockDownloader) WithInputChannel(v chan *Payload) *OneBlockDownl
 	return self
 }
 
-func (self *OneBlockDownloader) downloadBlock(arweaveBlock *ArweaveBlock) (block *arweave.Block, err error) {
-	ctx, cancel := context.WithTimeout(self.Ctx, self.Config.Relayer.ArweaveBlockDownloadTimeout)
+func (self *OneBlockDownloader) downloadBlock(ctx context.Context, arweaveBlock *ArweaveBlock) (block *arweave.Block, err error) {
+	ctx, cancel := context.WithTimeout(ctx, self.Config.Relayer.ArweaveBlockDownloadTimeout)
 	defer cancel()
 
 	// Download block
@@ -142,7 +143,7 @@ func (self *OneBlockDownloader) downloadBlock(arweaveBlock *ArweaveBlock) (block
 	return
 }
 
-func (self *OneBlockDownloader) download(arweaveBlock *ArweaveBlock) (out *arweave.Block, err error) {
+func (self *OneBlockDownloader) download(ctx context.Context, arweaveBlock *ArweaveBlock) (out *arweave.Block, err error) {
 	self.Log.
 		WithField("last_height", self.lastBlockHeight).
 		WithField("last_hash", self.lastBlockHash.Base64()).
@@ -179,7 +180,7 @@ func (self *OneBlockDownloader) download(arweaveBlock *ArweaveBlock) (out *arwea
 			return err
 		}).
 		Run(func() (err error) {
-			out, err = self.downloadBlock(arweaveBlock)
+			out, err = self.downloadBlock(ctx, arweaveBlock)
 			return
 		})
 
@@ -202,6 +203,8 @@ func (self *OneBlockDownloader) run() (err error) {
 			return payload.ArweaveBlocks[i].Message.BlockInfo.Height < payload.ArweaveBlocks[j].Message.BlockInfo.Height
 		})
 
+		ctx, span := payload.startSpan(self.Ctx, self.Name)
+
 		// Download blocks one by one
 		for i, arweaveBlock := range payload.ArweaveBlocks {
 			if arweaveBlock == nil {
@@ -209,8 +212,9 @@ func (self *OneBlockDownloader) run() (err error) {
 				self.Log.WithField("idx", i).Warn("Arweave block is nil, skipping")
 				continue
 			}
-			payload.ArweaveBlocks[i].Block, err = self.download(arweaveBlock)
+			payload.ArweaveBlocks[i].Block, err = self.download(ctx, arweaveBlock)
 			if err != nil {
+				tracing.End(span, err)
 				if self.IsStopping.Load() {
 					// Neglect the block, we're stopping anyway
 					return nil
@@ -234,6 +238,8 @@ func (self *OneBlockDownloader) run() (err error) {
 			self.monitor.GetReport().BlockDownloader.State.CurrentHeight.Store(arweaveBlock.Block.Height)
 		}
 
+		span.End()
+
 		// Arweave blocks filled
 		self.Output <- payload
 	}
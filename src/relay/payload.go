@@ -1,11 +1,16 @@
 package relay
 
 import (
+	"context"
+
 	"github.com/cometbft/cometbft/libs/bytes"
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/warp-contracts/sequencer/x/sequencer/types"
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ArweaveBlock struct {
@@ -39,9 +44,17 @@ type Payload struct {
 	// L2 interactions parsed from Sequencer's txs
 	Interactions []*model.Interaction
 
-	// Bundle items that will be sent to bundlr.network
-	BundleItems []*model.BundleItem
+	// Bundle items that will be sent to bundlr.network, by id of the corresponding L2 interaction.
+	// Ids in the database are known only after interactions are inserted
+	BundleItems map[string]*model.BundleItem
 
 	// Info about Arweave blocks
 	ArweaveBlocks []*ArweaveBlock
+
+	Trace tracing.Carrier
+}
+
+// Starts the stage's span as a child of the previous stage's span
+func (self *Payload) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return self.Trace.StartSpan(ctx, name, trace.WithAttributes(attribute.Int64("sequencer_height", self.SequencerBlockHeight)))
 }
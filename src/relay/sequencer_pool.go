@@ -0,0 +1,207 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/monitoring/report"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+type SequencerEndpoint struct {
+	Url string
+
+	// Used only for plain HTTP requests, websocket connections are created separately
+	Client *rpchttp.HTTP
+
+	state *report.SequencerEndpointState
+}
+
+// Set of Sequencer's nodes. Periodically checks their latest height.
+// Requests are spread round-robin between healthy nodes, new blocks are streamed from the best one.
+// Lives outside the watchdog, so its state survives restarts of the pipeline.
+type SequencerPool struct {
+	*task.Task
+
+	monitor   monitoring.Monitor
+	endpoints []*SequencerEndpoint
+
+	// Index of the node with the highest block
+	mtx  sync.RWMutex
+	best int
+
+	// Round robin counter
+	next atomic.Uint64
+
+	// Notified when the best node changes
+	BestChanged chan struct{}
+}
+
+func NewSequencerPool(config *config.Config) (self *SequencerPool) {
+	self = new(SequencerPool)
+
+	self.BestChanged = make(chan struct{}, 1)
+
+	self.Task = task.NewTask(config, "sequencer-pool").
+		WithOnBeforeStart(self.init).
+		WithPeriodicSubtaskFunc(config.Relayer.SequencerHealthCheckInterval, self.check)
+
+	return
+}
+
+func (self *SequencerPool) WithMonitor(monitor monitoring.Monitor) *SequencerPool {
+	self.monitor = monitor
+	return self
+}
+
+func (self *SequencerPool) init() (err error) {
+	if len(self.Config.Relayer.SequencerUrls) == 0 {
+		err = errors.New("no sequencer urls")
+		self.Log.WithError(err).Error("At least one sequencer node is needed")
+		return
+	}
+
+	self.endpoints = make([]*SequencerEndpoint, 0, len(self.Config.Relayer.SequencerUrls))
+	states := make([]*report.SequencerEndpointState, 0, len(self.Config.Relayer.SequencerUrls))
+
+	for _, url := range self.Config.Relayer.SequencerUrls {
+		endpoint := &SequencerEndpoint{
+			Url:   url,
+			state: &report.SequencerEndpointState{Url: url},
+		}
+
+		endpoint.Client, err = rpchttp.New(url, "/websocket")
+		if err != nil {
+			self.Log.WithError(err).WithField("url", url).Error("Failed to create sequencer client")
+			return
+		}
+
+		self.endpoints = append(self.endpoints, endpoint)
+		states = append(states, endpoint.state)
+	}
+
+	self.endpoints[0].state.IsBest.Store(true)
+	self.monitor.GetReport().Relayer.Sequencers = states
+
+	// Pick the best node before other tasks start
+	return self.check()
+}
+
+func (self *SequencerPool) checkEndpoint(endpoint *SequencerEndpoint) {
+	ctx, cancel := context.WithTimeout(self.Ctx, self.Config.Relayer.SequencerHealthCheckTimeout)
+	defer cancel()
+
+	endpoint.state.LastCheckTimestamp.Store(time.Now().Unix())
+
+	status, err := endpoint.Client.Status(ctx)
+	if err != nil {
+		self.Log.WithError(err).WithField("url", endpoint.Url).Warn("Sequencer node health check failed")
+		endpoint.state.Errors.Inc()
+		endpoint.state.IsHealthy.Store(false)
+		return
+	}
+
+	endpoint.state.LatestHeight.Store(status.SyncInfo.LatestBlockHeight)
+
+	// Node that's still syncing can't be used
+	endpoint.state.IsHealthy.Store(!status.SyncInfo.CatchingUp)
+}
+
+func (self *SequencerPool) check() (err error) {
+	var wg sync.WaitGroup
+	wg.Add(len(self.endpoints))
+	for _, endpoint := range self.endpoints {
+		endpoint := endpoint
+		go func() {
+			defer wg.Done()
+			self.checkEndpoint(endpoint)
+		}()
+	}
+	wg.Wait()
+
+	// Nodes too far behind are unhealthy
+	var maxHeight int64
+	for _, endpoint := range self.endpoints {
+		if endpoint.state.IsHealthy.Load() && endpoint.state.LatestHeight.Load() > maxHeight {
+			maxHeight = endpoint.state.LatestHeight.Load()
+		}
+	}
+	for _, endpoint := range self.endpoints {
+		if endpoint.state.LatestHeight.Load() < maxHeight-self.Config.Relayer.SequencerMaxHeightLag {
+			endpoint.state.IsHealthy.Store(false)
+		}
+	}
+
+	self.pickBest()
+
+	return nil
+}
+
+// Current best node is kept as long as it's healthy, to avoid switching the subscription back and forth
+func (self *SequencerPool) pickBest() {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	best := self.best
+	if !self.endpoints[best].state.IsHealthy.Load() {
+		for i, endpoint := range self.endpoints {
+			if !endpoint.state.IsHealthy.Load() {
+				continue
+			}
+			if !self.endpoints[best].state.IsHealthy.Load() ||
+				endpoint.state.LatestHeight.Load() > self.endpoints[best].state.LatestHeight.Load() {
+				best = i
+			}
+		}
+	}
+
+	if best == self.best {
+		return
+	}
+
+	self.Log.WithField("from", self.endpoints[self.best].Url).
+		WithField("to", self.endpoints[best].Url).
+		Warn("Switching to another sequencer node")
+
+	self.endpoints[self.best].state.IsBest.Store(false)
+	self.endpoints[best].state.IsBest.Store(true)
+	self.best = best
+
+	select {
+	case self.BestChanged <- struct{}{}:
+	default:
+	}
+}
+
+// Node with the highest block. If all nodes are unhealthy it's the last one that worked.
+func (self *SequencerPool) Best() *SequencerEndpoint {
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+	return self.endpoints[self.best]
+}
+
+// Next healthy node, round-robin. Falls back to the best node if none is healthy
+func (self *SequencerPool) Next() *SequencerEndpoint {
+	for range self.endpoints {
+		idx := self.next.Add(1) % uint64(len(self.endpoints))
+		if self.endpoints[idx].state.IsHealthy.Load() {
+			return self.endpoints[idx]
+		}
+	}
+	return self.Best()
+}
+
+// Request to the node failed, don't use it till the next successful health check
+func (self *SequencerPool) OnError(endpoint *SequencerEndpoint) {
+	endpoint.state.Errors.Inc()
+	endpoint.state.IsHealthy.Store(false)
+
+	self.pickBest()
+}
@@ -0,0 +1,444 @@
+package relay
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/jackc/pgtype"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+	"go.uber.org/atomic"
+	"gorm.io/gorm"
+)
+
+// Used instead of Store in the shadow mode.
+// Compares payloads with rows saved by the production relayer, once it saves the block.
+// Differences are logged and counted in the monitor. Nothing is ever written to the database.
+type Shadow struct {
+	*task.Task
+
+	db      *gorm.DB
+	monitor monitoring.Monitor
+
+	// Height saved by the production relayer, last time it was checked
+	productionHeight uint64
+
+	input chan *Payload
+}
+
+// Single mismatch between the shadow and production rows
+type shadowDifference struct {
+	counter       *atomic.Uint64
+	message       string
+	interactionId string
+	production    any
+	shadow        any
+}
+
+func NewShadow(config *config.Config) (self *Shadow) {
+	self = new(Shadow)
+
+	self.Task = task.NewTask(config, "shadow").
+		WithSubtaskFunc(self.run)
+
+	return
+}
+
+func (self *Shadow) WithMonitor(monitor monitoring.Monitor) *Shadow {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Shadow) WithDB(db *gorm.DB) *Shadow {
+	self.db = db
+	return self
+}
+
+func (self *Shadow) WithInputChannel(v chan *Payload) *Shadow {
+	self.input = v
+	return self
+}
+
+// Blocks until the production relayer saves the block at this height
+func (self *Shadow) waitForProduction(height uint64) (err error) {
+	for self.productionHeight < height {
+		var state model.State
+		err = self.db.WithContext(self.Ctx).
+			Table(model.TableState).
+			Where("name = ?", model.SyncedComponentRelayer).
+			First(&state).
+			Error
+		if err != nil {
+			return
+		}
+
+		self.productionHeight = state.FinishedBlockHeight
+		if self.productionHeight >= height {
+			return
+		}
+
+		select {
+		case <-self.Ctx.Done():
+			return errors.New("task closing")
+		case <-time.After(self.Config.Relayer.ShadowPollInterval):
+		}
+	}
+	return
+}
+
+func (self *Shadow) getInteractions(query *gorm.DB) (out map[string]*model.Interaction, ordered []*model.Interaction, err error) {
+	err = query.WithContext(self.Ctx).
+		Table(model.TableInteraction).
+		Select("id", "interaction_id", "contract_id", "sort_key", "last_sort_key", "sync_timestamp").
+		Order("sort_key ASC").
+		Find(&ordered).
+		Error
+	if err != nil {
+		return
+	}
+
+	out = make(map[string]*model.Interaction, len(ordered))
+	for _, interaction := range ordered {
+		out[interaction.InteractionId.Base64()] = interaction
+	}
+	return
+}
+
+func lastSortKeyString(v pgtype.Text) string {
+	if v.Status != pgtype.Present {
+		return ""
+	}
+	return v.String
+}
+
+// Sort keys of a single interaction
+func (self *Shadow) compareInteraction(shadow, production *model.Interaction) (out []*shadowDifference) {
+	state := &self.monitor.GetReport().Relayer.State
+	id := shadow.InteractionId.Base64()
+
+	if production == nil {
+		return []*shadowDifference{{
+			counter:       &state.ShadowMissingInteractions,
+			message:       "Interaction not saved by the production relayer",
+			interactionId: id,
+			shadow:        shadow.SortKey,
+		}}
+	}
+
+	if shadow.SortKey != production.SortKey {
+		out = append(out, &shadowDifference{
+			counter:       &state.ShadowSortKeyDifferences,
+			message:       "Sort key differs",
+			interactionId: id,
+			production:    production.SortKey,
+			shadow:        shadow.SortKey,
+		})
+	}
+
+	if lastSortKeyString(shadow.LastSortKey) != lastSortKeyString(production.LastSortKey) {
+		out = append(out, &shadowDifference{
+			counter:       &state.ShadowLastSortKeyDifferences,
+			message:       "Last sort key differs",
+			interactionId: id,
+			production:    lastSortKeyString(production.LastSortKey),
+			shadow:        lastSortKeyString(shadow.LastSortKey),
+		})
+	}
+
+	return
+}
+
+// L2 interactions and their bundle items
+func (self *Shadow) compareL2(payload *Payload) (out []*shadowDifference, err error) {
+	if len(payload.Interactions) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(payload.Interactions))
+	for _, interaction := range payload.Interactions {
+		ids = append(ids, interaction.InteractionId.Base64())
+	}
+
+	production, _, err := self.getInteractions(self.db.Where("interaction_id IN ?", ids))
+	if err != nil {
+		return
+	}
+
+	// Bundle items of interactions that exist in production
+	productionIds := make([]int, 0, len(production))
+	for _, interaction := range production {
+		productionIds = append(productionIds, interaction.ID)
+	}
+
+	var bundled []int
+	if len(productionIds) > 0 {
+		err = self.db.WithContext(self.Ctx).
+			Table(model.TableBundleItem).
+			Where("interaction_id IN ?", productionIds).
+			Pluck("interaction_id", &bundled).
+			Error
+		if err != nil {
+			return
+		}
+	}
+
+	out = self.diffL2(payload, production, bundled)
+	return
+}
+
+// Differences between L2 interactions of the payload and the production rows, bundled are ids of production interactions with bundle items
+func (self *Shadow) diffL2(payload *Payload, production map[string]*model.Interaction, bundled []int) (out []*shadowDifference) {
+	for _, interaction := range payload.Interactions {
+		id := interaction.InteractionId.Base64()
+		out = append(out, self.compareInteraction(interaction, production[id])...)
+
+		if production[id] != nil && !slices.Contains(bundled, production[id].ID) {
+			out = append(out, &shadowDifference{
+				counter:       &self.monitor.GetReport().Relayer.State.ShadowMissingBundleItems,
+				message:       "Bundle item not saved by the production relayer",
+				interactionId: id,
+			})
+		}
+	}
+
+	return
+}
+
+// L1 interactions from one Arweave block, including their order and meta info data items
+func (self *Shadow) compareL1(arweaveBlock *ArweaveBlock) (out []*shadowDifference, err error) {
+	production, ordered, err := self.getInteractions(self.db.
+		Where("block_height = ?", arweaveBlock.Block.Height).
+		Where("source = ?", "arweave"))
+	if err != nil {
+		return
+	}
+
+	out = self.diffL1(arweaveBlock, production, ordered)
+
+	metaInfo, err := self.compareMetaInfo(arweaveBlock, production)
+	if err != nil {
+		return
+	}
+
+	out = append(out, metaInfo...)
+
+	return
+}
+
+// Differences between L1 interactions of the block and the production rows, ordered by sort key
+func (self *Shadow) diffL1(arweaveBlock *ArweaveBlock, production map[string]*model.Interaction, ordered []*model.Interaction) (out []*shadowDifference) {
+	for _, interaction := range arweaveBlock.Interactions {
+		out = append(out, self.compareInteraction(interaction, production[interaction.InteractionId.Base64()])...)
+	}
+
+	// Order of all L1 interactions in the block, this also catches interactions that exist only in production
+	shadowOrdered := slices.Clone(arweaveBlock.Interactions)
+	slices.SortFunc(shadowOrdered, func(a, b *model.Interaction) int {
+		return cmp.Compare(a.SortKey, b.SortKey)
+	})
+
+	shadowIds := make([]string, 0, len(shadowOrdered))
+	for _, interaction := range shadowOrdered {
+		shadowIds = append(shadowIds, interaction.InteractionId.Base64())
+	}
+
+	productionIds := make([]string, 0, len(ordered))
+	for _, interaction := range ordered {
+		productionIds = append(productionIds, interaction.InteractionId.Base64())
+	}
+
+	if !slices.Equal(shadowIds, productionIds) {
+		out = append(out, &shadowDifference{
+			counter:    &self.monitor.GetReport().Relayer.State.ShadowL1OrderDifferences,
+			message:    "Order of L1 interactions differs",
+			production: strings.Join(productionIds, ","),
+			shadow:     strings.Join(shadowIds, ","),
+		})
+	}
+
+	return
+}
+
+// Data items are signed with a random salt, so their ids differ between runs.
+// Production data item is found by its anchor (hash of the sort key) among items saved together with the interaction,
+// then everything except the signature is compared.
+func (self *Shadow) compareMetaInfo(arweaveBlock *ArweaveBlock, production map[string]*model.Interaction) (out []*shadowDifference, err error) {
+	if len(arweaveBlock.MetaInfoDataItems) == 0 {
+		return
+	}
+
+	var (
+		contractIds []string
+		minSyncTime int64
+		maxSyncTime int64
+		found       bool
+	)
+	for _, interaction := range arweaveBlock.Interactions {
+		p := production[interaction.InteractionId.Base64()]
+		if p == nil || p.SyncTimestamp.Status != pgtype.Present {
+			continue
+		}
+
+		contractIds = append(contractIds, p.ContractId)
+		if !found || p.SyncTimestamp.Int < minSyncTime {
+			minSyncTime = p.SyncTimestamp.Int
+		}
+		if !found || p.SyncTimestamp.Int > maxSyncTime {
+			maxSyncTime = p.SyncTimestamp.Int
+		}
+		found = true
+	}
+
+	if !found {
+		// Nothing to compare with, missing interactions are already reported
+		return
+	}
+
+	var dataItems []*model.DataItem
+	err = self.db.WithContext(self.Ctx).
+		Table(model.TableDataItem).
+		Select("data_item_id", "data_item").
+		Where("contract_id IN ?", contractIds).
+		Where("created_at BETWEEN ? AND ?",
+			time.UnixMilli(minSyncTime).Add(-self.Config.Relayer.ShadowMetaInfoWindow),
+			time.UnixMilli(maxSyncTime).Add(self.Config.Relayer.ShadowMetaInfoWindow)).
+		Find(&dataItems).
+		Error
+	if err != nil {
+		return
+	}
+
+	// Index production items by anchor
+	byAnchor := make(map[string]*bundlr.BundleItem, len(dataItems))
+	for _, dataItem := range dataItems {
+		item := new(bundlr.BundleItem)
+		if item.Unmarshal(dataItem.DataItem.Bytes) != nil {
+			continue
+		}
+		byAnchor[string(item.Anchor)] = item
+	}
+
+	for i, dataItem := range arweaveBlock.MetaInfoDataItems {
+		interaction := arweaveBlock.Interactions[i]
+		if production[interaction.InteractionId.Base64()] == nil {
+			continue
+		}
+
+		shadow := new(bundlr.BundleItem)
+		err = shadow.Unmarshal(dataItem.DataItem.Bytes)
+		if err != nil {
+			return
+		}
+
+		anchor := sha256.Sum256([]byte(interaction.SortKey))
+		item, ok := byAnchor[string(anchor[:])]
+		if !ok {
+			out = append(out, &shadowDifference{
+				counter:       &self.monitor.GetReport().Relayer.State.ShadowMetaInfoDifferences,
+				message:       "Meta info data item not saved by the production relayer",
+				interactionId: interaction.InteractionId.Base64(),
+				shadow:        dataItem.DataItemID,
+			})
+			continue
+		}
+
+		if !slices.Equal(shadow.Tags, item.Tags) || !bytes.Equal(shadow.Data, item.Data) || !bytes.Equal(shadow.Target, item.Target) {
+			out = append(out, &shadowDifference{
+				counter:       &self.monitor.GetReport().Relayer.State.ShadowMetaInfoDifferences,
+				message:       "Meta info data item differs",
+				interactionId: interaction.InteractionId.Base64(),
+				production:    item.Tags,
+				shadow:        shadow.Tags,
+			})
+		}
+	}
+
+	return
+}
+
+func (self *Shadow) compare(payload *Payload) (out []*shadowDifference, err error) {
+	out, err = self.compareL2(payload)
+	if err != nil {
+		return
+	}
+
+	for _, arweaveBlock := range payload.ArweaveBlocks {
+		var differences []*shadowDifference
+		differences, err = self.compareL1(arweaveBlock)
+		if err != nil {
+			return
+		}
+		out = append(out, differences...)
+	}
+
+	return
+}
+
+func (self *Shadow) process(payload *Payload) (err error) {
+	var differences []*shadowDifference
+
+	err = task.NewRetry().
+		WithContext(self.Ctx).
+		WithMaxElapsedTime(0).
+		WithMaxInterval(self.Config.Relayer.StoreMaxBackoffInterval).
+		WithOnError(func(err error, isDurationAcceptable bool) error {
+			if self.IsStopping.Load() {
+				return backoff.Permanent(err)
+			}
+
+			self.Log.WithError(err).WithField("sequencer_height", payload.SequencerBlockHeight).Warn("Failed to compare block, retrying")
+			self.monitor.GetReport().Relayer.Errors.DbError.Inc()
+			return err
+		}).
+		Run(func() (err error) {
+			err = self.waitForProduction(uint64(payload.SequencerBlockHeight))
+			if err != nil {
+				return
+			}
+
+			differences, err = self.compare(payload)
+			return
+		})
+	if err != nil {
+		return
+	}
+
+	for _, difference := range differences {
+		difference.counter.Inc()
+		self.Log.WithField("sequencer_height", payload.SequencerBlockHeight).
+			WithField("interaction_id", difference.interactionId).
+			WithField("production", difference.production).
+			WithField("shadow", difference.shadow).
+			Warn(difference.message)
+	}
+
+	self.monitor.GetReport().Relayer.State.ShadowBlocksCompared.Inc()
+	self.monitor.GetReport().Relayer.State.ShadowComparedHeight.Store(payload.SequencerBlockHeight)
+
+	return
+}
+
+func (self *Shadow) run() (err error) {
+	for payload := range self.input {
+		err = self.process(payload)
+		if err != nil {
+			if self.IsStopping.Load() || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			self.Log.WithError(err).WithField("sequencer_height", payload.SequencerBlockHeight).Error("Failed to compare block")
+			return
+		}
+	}
+
+	return nil
+}
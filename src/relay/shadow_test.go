@@ -0,0 +1,113 @@
+package relay
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/suite"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	monitor_relayer "github.com/warp-contracts/syncer/src/utils/monitoring/relayer"
+)
+
+type ShadowTestSuite struct {
+	suite.Suite
+
+	shadow *Shadow
+}
+
+func TestShadowTestSuite(t *testing.T) {
+	suite.Run(t, new(ShadowTestSuite))
+}
+
+func (s *ShadowTestSuite) SetupTest() {
+	config := config.Default()
+	s.shadow = NewShadow(config).
+		WithMonitor(monitor_relayer.NewMonitor(config))
+}
+
+func testInteraction(id int, sortKey, lastSortKey string) *model.Interaction {
+	return &model.Interaction{
+		ID:            id,
+		InteractionId: arweave.Base64String(fmt.Sprintf("interaction-%d", id)),
+		SortKey:       sortKey,
+		LastSortKey:   pgtype.Text{String: lastSortKey, Status: pgtype.Present},
+	}
+}
+
+// Production rows as returned by getInteractions
+func production(interactions ...*model.Interaction) (out map[string]*model.Interaction, ordered []*model.Interaction) {
+	out = make(map[string]*model.Interaction, len(interactions))
+	for _, interaction := range interactions {
+		copied := *interaction
+		out[interaction.InteractionId.Base64()] = &copied
+		ordered = append(ordered, &copied)
+	}
+	return
+}
+
+func messages(differences []*shadowDifference) (out []string) {
+	for _, difference := range differences {
+		out = append(out, difference.message)
+	}
+	return
+}
+
+func (s *ShadowTestSuite) TestL2Same() {
+	a, b := testInteraction(1, "a", ""), testInteraction(2, "b", "a")
+	rows, _ := production(a, b)
+
+	differences := s.shadow.diffL2(&Payload{Interactions: []*model.Interaction{a, b}}, rows, []int{1, 2})
+	s.Require().Empty(differences)
+}
+
+func (s *ShadowTestSuite) TestL2Mismatch() {
+	a, b, c := testInteraction(1, "a", ""), testInteraction(2, "b", "a"), testInteraction(3, "c", "b")
+	rows, _ := production(a, b)
+	rows[a.InteractionId.Base64()].SortKey = "x"
+	rows[b.InteractionId.Base64()].LastSortKey = pgtype.Text{Status: pgtype.Null}
+
+	// Bundle item of b is missing, c isn't saved at all
+	differences := s.shadow.diffL2(&Payload{Interactions: []*model.Interaction{a, b, c}}, rows, []int{1})
+	s.Require().Equal([]string{
+		"Sort key differs",
+		"Last sort key differs",
+		"Bundle item not saved by the production relayer",
+		"Interaction not saved by the production relayer",
+	}, messages(differences))
+
+	state := &s.shadow.monitor.GetReport().Relayer.State
+	s.Require().Same(&state.ShadowSortKeyDifferences, differences[0].counter)
+	s.Require().Same(&state.ShadowMissingBundleItems, differences[2].counter)
+	s.Require().Same(&state.ShadowMissingInteractions, differences[3].counter)
+}
+
+func (s *ShadowTestSuite) TestL1Same() {
+	a, b := testInteraction(1, "a", ""), testInteraction(2, "b", "a")
+	rows, ordered := production(a, b)
+
+	// Order in the block doesn't matter, sort keys do
+	differences := s.shadow.diffL1(&ArweaveBlock{Interactions: []*model.Interaction{b, a}}, rows, ordered)
+	s.Require().Empty(differences)
+}
+
+func (s *ShadowTestSuite) TestL1OnlyInProduction() {
+	a, b := testInteraction(1, "a", ""), testInteraction(2, "b", "a")
+	rows, ordered := production(a, b)
+
+	differences := s.shadow.diffL1(&ArweaveBlock{Interactions: []*model.Interaction{a}}, rows, ordered)
+	s.Require().Equal([]string{"Order of L1 interactions differs"}, messages(differences))
+	s.Require().Equal(a.InteractionId.Base64()+","+b.InteractionId.Base64(), differences[0].production)
+	s.Require().Equal(a.InteractionId.Base64(), differences[0].shadow)
+}
+
+func (s *ShadowTestSuite) TestL1Order() {
+	a, b := testInteraction(1, "a", ""), testInteraction(2, "b", "a")
+	rows, _ := production(a, b)
+
+	// Production saved the same sort keys, but returned them in another order
+	differences := s.shadow.diffL1(&ArweaveBlock{Interactions: []*model.Interaction{a, b}}, rows, []*model.Interaction{rows[b.InteractionId.Base64()], rows[a.InteractionId.Base64()]})
+	s.Require().Equal([]string{"Order of L1 interactions differs"}, messages(differences))
+}
@@ -6,7 +6,6 @@ import (
 	"sync"
 
 	"github.com/cenkalti/backoff"
-	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cometbft/cometbft/types"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -28,8 +27,12 @@ type Source struct {
 	Output           chan *types.Block
 	db               *gorm.DB
 	monitor          monitoring.Monitor
-	client           *rpchttp.HTTP
+	pool             *SequencerPool
 	lastSyncedHeight uint64
+
+	// Only blocks from this range are produced, if set. Used for archiving
+	startHeight uint64
+	stopHeight  uint64
 }
 
 func NewSource(config *config.Config) (self *Source) {
@@ -62,8 +65,14 @@ func (self *Source) WithInputChannel(input <-chan *types.Block) *Source {
 	return self
 }
 
-func (self *Source) WithClient(client *rpchttp.HTTP) *Source {
-	self.client = client
+func (self *Source) WithSequencerPool(pool *SequencerPool) *Source {
+	self.pool = pool
+	return self
+}
+
+func (self *Source) WithHeightRange(start, stop uint64) *Source {
+	self.startHeight = start
+	self.stopHeight = stop
 	return self
 }
 
@@ -86,13 +95,19 @@ func (self *Source) initLastSyncedHeight() (err error) {
 		return
 	}
 
+	// Shadow relayer never writes, it follows the production one
+	if self.Config.Relayer.ShadowEnabled {
+		self.Log.Error("Sync state of the production relayer not found, can't run in the shadow mode")
+		return
+	}
+
 	// No record found, create one
 	if !self.Config.IsDevelopment {
 		// In production
 		self.lastSyncedHeight = 0
 	} else {
 		// In development start syncing from the present block
-		status, err := self.client.Status(self.Ctx)
+		status, err := self.pool.Best().Client.Status(self.Ctx)
 		if err != nil {
 			self.Log.Error("Failed to get status of the blockchain")
 			return err
@@ -180,8 +195,13 @@ func (self *Source) download(len int) (err error) {
 					return err
 				}).
 				Run(func() error {
+					// Historical blocks are downloaded from all healthy nodes
+					endpoint := self.pool.Next()
 					var errBlock error
-					block, errBlock = self.client.Block(self.Ctx, &height)
+					block, errBlock = endpoint.Client.Block(self.Ctx, &height)
+					if errBlock != nil && self.Ctx.Err() == nil {
+						self.pool.OnError(endpoint)
+					}
 					return errBlock
 				})
 			if errWorker != nil {
@@ -273,13 +293,51 @@ func (self *Source) catchUp(height int64) (err error) {
 	return
 }
 
+// Downloads blocks from the specified range, without the database and streamer
+func (self *Source) runRange() (err error) {
+	// Restarted subtask continues where it stopped
+	if self.startHeight > 0 && self.lastSyncedHeight < self.startHeight-1 {
+		self.lastSyncedHeight = self.startHeight - 1
+	}
+
+	err = self.catchUp(int64(self.stopHeight))
+	if err != nil {
+		if self.IsStopping.Load() {
+			return nil
+		}
+		self.Log.WithError(err).
+			WithField("last_synced_height", self.lastSyncedHeight).
+			Error("Failed to download blocks from the range")
+		return
+	}
+
+	self.Log.WithField("start", self.startHeight).
+		WithField("stop", self.stopHeight).
+		Info("Finished downloading blocks from the range")
+
+	// Wait till the context is done
+	<-self.Ctx.Done()
+
+	return
+}
+
 func (self *Source) run() (err error) {
+	if self.stopHeight > 0 {
+		return self.runRange()
+	}
+
 	err = self.initLastSyncedHeight()
 	if err != nil {
 		return
 	}
 
 	for block := range self.input {
+		if uint64(block.Height) <= self.lastSyncedHeight {
+			// Streamer switched to a node that's slightly behind
+			self.Log.WithField("height", block.Height).Debug("Block already synced, skipping")
+			continue
+		}
+
 		if uint64(block.Height) > self.lastSyncedHeight+1 {
 			err = self.catchUp(block.Height - 1)
 			if err != nil {
@@ -2,16 +2,23 @@ package relay
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/cometbft/cometbft/libs/bytes"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -22,14 +29,16 @@ import (
 type Store struct {
 	*task.Processor[*Payload, *Payload]
 
-	DB      *gorm.DB
-	monitor monitoring.Monitor
+	DB        *gorm.DB
+	monitor   monitoring.Monitor
+	freshness *freshness.Stage
 
 	savedBlockHeight  uint64
 	finishedTimestamp uint64
 	finishedHeight    uint64
 	finishedBlockHash bytes.HexBytes
 
+	// Existing L1 interactions are updated. L2 interactions are never replaced, their ids are needed for bundle items
 	isReplacing bool
 }
 
@@ -40,11 +49,22 @@ func NewStore(config *config.Config) (self *Store) {
 		WithBatchSize(config.Relayer.StoreBatchSize).
 		WithOnFlush(config.Relayer.StoreMaxTimeInQueue, self.flush).
 		WithOnProcess(self.process).
-		WithBackoff(0, config.Relayer.StoreMaxBackoffInterval)
+		WithBackoff(config.Relayer.StoreMaxBackoffElapsedTime, config.Relayer.StoreMaxBackoffInterval)
+
+	// Latency from the sequencer block till saving L2 interactions
+	self.freshness = freshness.NewStage(config, freshness.PipelineL2, freshness.StageRelay)
+
+	// Batching is adjusted upon configuration file change
+	self.Processor.WithOnConfigChange(self.reload)
 
 	return
 }
 
+func (self *Store) reload(config *config.Config) {
+	self.SetBatchSize(config.Relayer.StoreBatchSize)
+	self.SetFlushInterval(config.Relayer.StoreMaxTimeInQueue)
+}
+
 func (self *Store) WithMonitor(v monitoring.Monitor) *Store {
 	self.monitor = v
 	return self
@@ -60,6 +80,14 @@ func (self *Store) WithInputChannel(v chan *Payload) *Store {
 	return self
 }
 
+// Failed payloads are saved without raw messages and Arweave blocks, see ReplayPayload
+func (self *Store) WithErrorSink(v task.ErrorSink) *Store {
+	if v != nil {
+		self.Processor = self.Processor.WithErrorSink(&payloadErrorSink{ErrorSink: v})
+	}
+	return self
+}
+
 func (self *Store) WithDB(v *gorm.DB) *Store {
 	self.DB = v
 	return self
@@ -88,19 +116,33 @@ func (self *Store) flush(payloads []*Payload) (out []*Payload, err error) {
 		return
 	}
 
+	// Flush handles many payloads, their traces are linked
+	links := make([]trace.Link, 0, len(payloads))
+	for _, payload := range payloads {
+		links = append(links, tracing.Links(&payload.Trace)...)
+	}
+	ctx, span := tracing.Start(self.Ctx, "store.flush",
+		trace.WithLinks(links...),
+		trace.WithAttributes(attribute.Int("payloads", len(payloads))))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	// Get data from payloads
 	lastArweaveBlock, arweaveInteractions, interactions, bundleItems, dataItems, err := self.getData(payloads)
 	if err != nil {
 		return
 	}
 
-	err = self.DB.WithContext(self.Ctx).
+	err = self.DB.WithContext(ctx).
 		// Debug().
 		Transaction(func(tx *gorm.DB) error {
+			// Replace finished block info, if it's newer. Archive import replays older blocks
 			err = tx.WithContext(self.Ctx).
 				Model(&model.State{
 					Name: model.SyncedComponentRelayer,
 				}).
+				Where("finished_block_height < ?", self.finishedHeight).
 				// State struct accepts block hash as bytes, but here we have only a string
 				Updates(map[string]interface{}{
 					"finished_block_timestamp": self.finishedTimestamp,
@@ -154,15 +196,8 @@ func (self *Store) flush(payloads []*Payload) (out []*Payload, err error) {
 
 			if len(interactions) != 0 {
 				// Save L2 interactions if there are any
-				err = tx.WithContext(self.Ctx).
-					Clauses(clause.OnConflict{
-						DoNothing: !self.isReplacing,
-						Columns:   []clause.Column{{Name: "interaction_id"}},
-						UpdateAll: self.isReplacing,
-					}).
-					CreateInBatches(&interactions, self.Config.Relayer.StoreBatchSize).
-					Error
-
+				var ids map[string]int
+				ids, err = insertInteractions(tx, interactions, self.Config.Relayer.StoreBatchSize)
 				if err != nil {
 					self.Log.WithError(err).Error("Failed to insert Interactions")
 					return err
@@ -170,9 +205,11 @@ func (self *Store) flush(payloads []*Payload) (out []*Payload, err error) {
 
 				// Connect bundle items with interactions
 				// L1 interactions don't have corresponding bundle items
-				// FIXME: Handle bundle items with arweave interactions order
-				for idx := range interactions {
-					bundleItems[idx].InteractionID = interactions[idx].ID
+				var orderedBundleItems []*model.BundleItem
+				orderedBundleItems, err = connectBundleItems(interactions, bundleItems, ids)
+				if err != nil {
+					self.Log.WithError(err).Error("Failed to connect bundle items with interactions")
+					return err
 				}
 
 				// Save bundle items if there are any
@@ -183,7 +220,7 @@ func (self *Store) flush(payloads []*Payload) (out []*Payload, err error) {
 						Columns:   []clause.Column{{Name: "interaction_id"}},
 						UpdateAll: false,
 					}).
-					CreateInBatches(bundleItems, self.Config.Relayer.StoreBatchSize).
+					CreateInBatches(orderedBundleItems, self.Config.Relayer.StoreBatchSize).
 					Error
 				if err != nil {
 					self.Log.WithError(err).Error("Failed to insert bundle items")
@@ -211,6 +248,15 @@ func (self *Store) flush(payloads []*Payload) (out []*Payload, err error) {
 	self.monitor.GetReport().Relayer.State.L1InteractionsSaved.Add(uint64(len(arweaveInteractions)))
 	self.monitor.GetReport().Relayer.State.L2InteractionsSaved.Add(uint64(len(interactions)))
 
+	// Duplicates from later payloads didn't get ids, the first occurrence is enough
+	for _, payload := range payloads {
+		for _, interaction := range payload.Interactions {
+			if interaction.ID > 0 {
+				self.freshness.Observe(strconv.Itoa(interaction.ID), time.UnixMilli(payload.SequencerBlockTimestamp))
+			}
+		}
+	}
+
 	// Bundle items saved
 	self.monitor.GetReport().Relayer.State.BundleItemsSaved.Store(uint64(len(bundleItems)))
 
@@ -230,7 +276,7 @@ func (self *Store) getData(payloads []*Payload) (
 	lastArweaveBlock *ArweaveBlock,
 	arweaveInteractions []*model.Interaction,
 	interactions []*model.Interaction,
-	bundleItems []*model.BundleItem,
+	bundleItems map[string]*model.BundleItem,
 	dataItems []*model.DataItem,
 	err error) {
 
@@ -243,8 +289,16 @@ func (self *Store) getData(payloads []*Payload) (
 			}
 			lastArweaveBlock = payload.ArweaveBlocks[len(payload.ArweaveBlocks)-1]
 		}
-		interactions = append(interactions, payload.Interactions...)
-		bundleItems = append(bundleItems, payload.BundleItems...)
+	}
+
+	// Interactions repeated in the batch would make the insert fail
+	interactions, bundleItems, numDuplicates, err := uniqueInteractions(payloads)
+	if err != nil {
+		self.Log.WithError(err).Error("Invalid interactions in the batch")
+		return
+	}
+	if numDuplicates > 0 {
+		self.Log.WithField("num", numDuplicates).Warn("Duplicate interactions in the batch, skipping")
 	}
 
 	// Set sync timestamp
@@ -263,12 +317,113 @@ func (self *Store) getData(payloads []*Payload) (
 			return
 		}
 	}
+	return
+}
 
+// L2 interactions from all payloads, each one only once. Every interaction needs a bundle item
+func uniqueInteractions(payloads []*Payload) (interactions []*model.Interaction, bundleItems map[string]*model.BundleItem, numDuplicates int, err error) {
+	bundleItems = make(map[string]*model.BundleItem)
+	for _, payload := range payloads {
+		for _, interaction := range payload.Interactions {
+			id := interaction.InteractionId.Base64()
+			if _, ok := bundleItems[id]; ok {
+				numDuplicates++
+				continue
+			}
+
+			bundleItem, ok := payload.BundleItems[id]
+			if !ok || bundleItem == nil {
+				err = fmt.Errorf("no bundle item for interaction %s", id)
+				return
+			}
+
+			interactions = append(interactions, interaction)
+			bundleItems[id] = bundleItem
+		}
+	}
+	return
+}
+
+// Inserts L2 interactions and returns their ids in the database, by interaction id.
+// Existing rows are left untouched, ids of all interactions are read after the insert in the same transaction.
+// Updating conflicting rows just to get them from RETURNING would rewrite them and emit changes to replication.
+// Copies are inserted, gorm sets the returned values in place and payloads may be flushed again after a failure.
+func insertInteractions(tx *gorm.DB, interactions []*model.Interaction, batchSize int) (ids map[string]int, err error) {
+	rows := make([]*model.Interaction, 0, len(interactions))
+	interactionIds := make([]string, 0, len(interactions))
+	for _, interaction := range interactions {
+		row := *interaction
+		row.ID = 0
+		rows = append(rows, &row)
+		interactionIds = append(interactionIds, interaction.InteractionId.Base64())
+	}
+
+	err = tx.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "interaction_id"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(&rows, batchSize).
+		Error
+	if err != nil {
+		return
+	}
+
+	var saved []*model.Interaction
+	err = tx.
+		Table(model.TableInteraction).
+		Select("id", "interaction_id").
+		Where("interaction_id IN ?", interactionIds).
+		Find(&saved).
+		Error
+	if err != nil {
+		return
+	}
+
+	ids = make(map[string]int, len(saved))
+	for _, row := range saved {
+		ids[row.InteractionId.Base64()] = row.ID
+	}
+
+	return
+}
+
+// Sets ids of inserted interactions in their bundle items. Returns bundle items in the order of interactions.
+// Fails if any interaction didn't get an id, or two interactions got the same one.
+func connectBundleItems(interactions []*model.Interaction, bundleItems map[string]*model.BundleItem, ids map[string]int) (out []*model.BundleItem, err error) {
 	if len(interactions) != len(bundleItems) {
-		err = errors.New("bundle items and interactions count mismatch")
-		self.Log.WithError(err).Error("Bundle items and interactions count mismatch")
+		err = fmt.Errorf("bundle items and interactions count mismatch: %d != %d", len(bundleItems), len(interactions))
 		return
 	}
+
+	out = make([]*model.BundleItem, 0, len(interactions))
+	used := make(map[int]string, len(interactions))
+	for _, interaction := range interactions {
+		interactionId := interaction.InteractionId.Base64()
+
+		bundleItem, ok := bundleItems[interactionId]
+		if !ok {
+			err = fmt.Errorf("no bundle item for interaction %s", interactionId)
+			return
+		}
+
+		id, ok := ids[interactionId]
+		if !ok || id <= 0 {
+			err = fmt.Errorf("no id returned for interaction %s", interactionId)
+			return
+		}
+
+		if other, ok := used[id]; ok {
+			err = fmt.Errorf("interactions %s and %s got the same id %d", other, interactionId, id)
+			return
+		}
+		used[id] = interactionId
+
+		interaction.ID = id
+		bundleItem.InteractionID = id
+		out = append(out, bundleItem)
+	}
+
 	return
 }
 
@@ -305,3 +460,104 @@ func (self *Store) updateFinishedArweaveBlock(tx *gorm.DB, arweaveBlock *Arweave
 
 	return
 }
+
+// Part of the payload saved as a dead letter, enough to save its interactions again
+type deadLetterPayload struct {
+	SequencerBlockHeight int64
+	Interactions         []*model.Interaction
+	BundleItems          map[string]*model.BundleItem
+	ArweaveInteractions  []*model.Interaction
+	DataItems            []*model.DataItem
+}
+
+type payloadErrorSink struct {
+	task.ErrorSink
+}
+
+func (self *payloadErrorSink) OnError(stage string, item any, err error) {
+	payload, ok := item.(*Payload)
+	if !ok {
+		self.ErrorSink.OnError(stage, item, err)
+		return
+	}
+
+	deadLetter := &deadLetterPayload{
+		SequencerBlockHeight: payload.SequencerBlockHeight,
+		Interactions:         payload.Interactions,
+		BundleItems:          payload.BundleItems,
+	}
+	for _, arweaveBlock := range payload.ArweaveBlocks {
+		deadLetter.ArweaveInteractions = append(deadLetter.ArweaveInteractions, arweaveBlock.Interactions...)
+		deadLetter.DataItems = append(deadLetter.DataItems, arweaveBlock.MetaInfoDataItems...)
+	}
+	self.ErrorSink.OnError(stage, deadLetter, err)
+}
+
+// Saves interactions of a payload that the store failed to save, used to replay dead letters.
+// Existing rows are left untouched and the sync state isn't changed.
+func ReplayPayload(ctx context.Context, db *gorm.DB, buf []byte) (err error) {
+	var payload deadLetterPayload
+	err = json.Unmarshal(buf, &payload)
+	if err != nil {
+		return
+	}
+
+	interactions, bundleItems, _, err := uniqueInteractions([]*Payload{{
+		Interactions: payload.Interactions,
+		BundleItems:  payload.BundleItems,
+	}})
+	if err != nil {
+		return
+	}
+
+	return db.WithContext(ctx).
+		Transaction(func(tx *gorm.DB) error {
+			if len(payload.ArweaveInteractions) != 0 {
+				err := tx.Table(model.TableInteraction).
+					Clauses(clause.OnConflict{
+						DoNothing: true,
+						Columns:   []clause.Column{{Name: "interaction_id"}},
+					}).
+					Create(&payload.ArweaveInteractions).
+					Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(payload.DataItems) != 0 {
+				err := tx.Table(model.TableDataItem).
+					Clauses(clause.OnConflict{
+						DoNothing: true,
+						Columns:   []clause.Column{{Name: "data_item_id"}},
+					}).
+					Create(&payload.DataItems).
+					Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(interactions) == 0 {
+				return nil
+			}
+
+			ids, err := insertInteractions(tx, interactions, len(interactions))
+			if err != nil {
+				return err
+			}
+
+			orderedBundleItems, err := connectBundleItems(interactions, bundleItems, ids)
+			if err != nil {
+				return err
+			}
+
+			return tx.Table(model.TableBundleItem).
+				Clauses(clause.OnConflict{
+					DoNothing: true,
+					Columns:   []clause.Column{{Name: "interaction_id"}},
+				}).
+				Create(&orderedBundleItems).
+				Error
+		})
+}
@@ -0,0 +1,231 @@
+package relay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	sequencertypes "github.com/warp-contracts/sequencer/x/sequencer/types"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	monitor_relayer "github.com/warp-contracts/syncer/src/utils/monitoring/relayer"
+	"github.com/warp-contracts/syncer/src/utils/smartweave"
+)
+
+// Sequencer blocks recorded with interactions repeated in the same block, in the same batch and across batches.
+// Recorded interaction ids are replaced with ids of data items signed in the test, the same recorded id gets the same data item.
+const recordedBlocksPath = "testdata/sequencer_blocks_with_duplicates.json"
+
+const (
+	testPrivateKey         = "0xf4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893"
+	testArweaveBlockHeight = 1340022
+	testArweaveBlockHash   = "VZZ5CiAS7KI8V4MPFaTjZXq8VkjvRA9Ph1IhRuW7xTkk4yBhMTWB5SW6KbuqnRwK"
+)
+
+type recordedBlock struct {
+	SequencerHeight int64 `json:"sequencer_height"`
+	Interactions    []struct {
+		InteractionId string `json:"interaction_id"`
+		ContractId    string `json:"contract_id"`
+		SortKey       string `json:"sort_key"`
+	} `json:"interactions"`
+}
+
+// Behaves like the interactions table with the unique interaction_id: existing rows keep their ids.
+// Rows are returned in reverse order, insert can't depend on it.
+type fakeInteractionsTable struct {
+	ids    map[string]int
+	nextId int
+}
+
+func (self *fakeInteractionsTable) insert(interactions []*model.Interaction) (out map[string]int) {
+	out = make(map[string]int, len(interactions))
+	for i := len(interactions) - 1; i >= 0; i-- {
+		id := interactions[i].InteractionId.Base64()
+		if _, ok := self.ids[id]; !ok {
+			self.nextId++
+			self.ids[id] = self.nextId
+		}
+		out[id] = self.ids[id]
+	}
+	return
+}
+
+type StoreTestSuite struct {
+	suite.Suite
+
+	parser    *MsgDataItemParser
+	signer    bundlr.Signer
+	dataItems map[string]*bundlr.BundleItem
+	table     *fakeInteractionsTable
+	blocks    []*recordedBlock
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+func (s *StoreTestSuite) SetupTest() {
+	var err error
+	config := config.Default()
+	s.parser = NewMsgDataItemParser(config).WithMonitor(monitor_relayer.NewMonitor(config))
+	s.signer, err = bundlr.NewEthereumSigner(testPrivateKey)
+	s.Require().NoError(err)
+	s.dataItems = make(map[string]*bundlr.BundleItem)
+	s.table = &fakeInteractionsTable{ids: make(map[string]int)}
+
+	buf, err := os.ReadFile(recordedBlocksPath)
+	s.Require().NoError(err)
+	s.Require().NoError(json.Unmarshal(buf, &s.blocks))
+}
+
+// Signed data item standing for the recorded interaction
+func (s *StoreTestSuite) dataItem(recordedId, contractId string) *bundlr.BundleItem {
+	if dataItem, ok := s.dataItems[recordedId]; ok {
+		return dataItem
+	}
+
+	dataItem := &bundlr.BundleItem{
+		SignatureType: bundlr.SignatureTypeEthereum,
+		Tags: bundlr.Tags{
+			{Name: smartweave.TagAppName, Value: smartweave.TagAppNameValue},
+			{Name: smartweave.TagContractTxId, Value: contractId},
+			{Name: smartweave.TagInput, Value: fmt.Sprintf(`{"function":"transfer","recorded":"%s"}`, recordedId)},
+		},
+		Data: arweave.Base64String("1234"),
+	}
+	s.Require().NoError(dataItem.Sign(s.signer))
+
+	s.dataItems[recordedId] = dataItem
+	return dataItem
+}
+
+// Block with MsgDataItem messages, as it's passed to MsgDataItemParser
+func (s *StoreTestSuite) block(block *recordedBlock) *Payload {
+	payload := &Payload{
+		SequencerBlockHeight:    block.SequencerHeight,
+		SequencerBlockTimestamp: 1700000000000 + block.SequencerHeight,
+		LastArweaveBlock: &sequencertypes.ArweaveBlockInfo{
+			Height:    testArweaveBlockHeight,
+			Timestamp: 1700000000,
+			Hash:      testArweaveBlockHash,
+		},
+	}
+
+	for _, recorded := range block.Interactions {
+		payload.Messages = append(payload.Messages, &sequencertypes.MsgDataItem{
+			DataItem: *s.dataItem(recorded.InteractionId, recorded.ContractId),
+			SortKey:  recorded.SortKey,
+			Random:   []byte{1, 2, 3, 4},
+		})
+	}
+	return payload
+}
+
+// Payload as it leaves MsgDataItemParser
+func (s *StoreTestSuite) payload(block *recordedBlock) *Payload {
+	payload := s.block(block)
+	s.Require().NoError(s.parser.parse(payload))
+	return payload
+}
+
+// Same steps as Store.flush, but with the fake table. Bundle items are checked to hold the data item of their interaction.
+func (s *StoreTestSuite) flush(payloads ...*Payload) []*model.BundleItem {
+	interactions, bundleItems, _, err := uniqueInteractions(payloads)
+	s.Require().NoError(err)
+
+	out, err := connectBundleItems(interactions, bundleItems, s.table.insert(interactions))
+	s.Require().NoError(err)
+
+	for _, bundleItem := range out {
+		var dataItem bundlr.BundleItem
+		s.Require().NoError(dataItem.Unmarshal(bundleItem.DataItem.Bytes))
+
+		interactionId := dataItem.Id.Base64()
+		s.Require().Equal(s.table.ids[interactionId], bundleItem.InteractionID, interactionId)
+	}
+	return out
+}
+
+func (s *StoreTestSuite) TestParse() {
+	payload := s.payload(s.blocks[0])
+	s.Require().Len(payload.Interactions, 3)
+	s.Require().Len(payload.BundleItems, 3)
+
+	for i, interaction := range payload.Interactions {
+		recorded := s.blocks[0].Interactions[i]
+		s.Require().Equal(s.dataItems[recorded.InteractionId].Id, interaction.InteractionId)
+		s.Require().Equal(recorded.ContractId, interaction.ContractId)
+		s.Require().Equal(recorded.SortKey, interaction.SortKey)
+		s.Require().Equal("transfer", interaction.Function)
+		s.Require().Contains(payload.BundleItems, interaction.InteractionId.Base64())
+	}
+}
+
+func (s *StoreTestSuite) TestRemoveDuplicatesInBlock() {
+	payload := s.payload(s.blocks[2])
+	s.Require().Len(payload.Interactions, 2)
+	s.Require().Len(payload.BundleItems, 2)
+
+	// First occurrence wins
+	s.Require().Equal(s.blocks[2].Interactions[0].SortKey, payload.Interactions[0].SortKey)
+}
+
+func (s *StoreTestSuite) TestInvalidSignature() {
+	payload := s.block(s.blocks[0])
+
+	// Data changed after signing, the message is dropped and its sort key is missing in the block
+	msg := payload.Messages[1].(*sequencertypes.MsgDataItem)
+	msg.DataItem.Data = arweave.Base64String("4321")
+	s.Require().Error(s.parser.parse(payload))
+}
+
+func (s *StoreTestSuite) TestReplayWithDuplicates() {
+	// Same interaction in two blocks of one batch
+	s.Require().Len(s.flush(s.payload(s.blocks[0]), s.payload(s.blocks[1])), 5)
+
+	// Duplicate in the same block
+	s.Require().Len(s.flush(s.payload(s.blocks[2])), 2)
+
+	// Block replayed after its interactions were already saved
+	s.Require().Len(s.flush(s.payload(s.blocks[3])), 3)
+
+	s.Require().Len(s.table.ids, 7)
+}
+
+func (s *StoreTestSuite) TestMissingBundleItem() {
+	payload := s.payload(s.blocks[0])
+	delete(payload.BundleItems, payload.Interactions[1].InteractionId.Base64())
+
+	_, _, _, err := uniqueInteractions([]*Payload{payload})
+	s.Require().Error(err)
+}
+
+func (s *StoreTestSuite) TestMissingId() {
+	payload := s.payload(s.blocks[0])
+	interactions, bundleItems, _, err := uniqueInteractions([]*Payload{payload})
+	s.Require().NoError(err)
+
+	// Interaction wasn't saved
+	ids := s.table.insert(interactions)
+	delete(ids, interactions[0].InteractionId.Base64())
+
+	_, err = connectBundleItems(interactions, bundleItems, ids)
+	s.Require().Error(err)
+}
+
+func (s *StoreTestSuite) TestSameId() {
+	payload := s.payload(s.blocks[0])
+	interactions, bundleItems, _, err := uniqueInteractions([]*Payload{payload})
+	s.Require().NoError(err)
+
+	ids := s.table.insert(interactions)
+	ids[interactions[0].InteractionId.Base64()] = ids[interactions[1].InteractionId.Base64()]
+
+	_, err = connectBundleItems(interactions, bundleItems, ids)
+	s.Require().Error(err)
+}
@@ -19,15 +19,20 @@ type Streamer struct {
 
 	monitor monitoring.Monitor
 	Output  chan *types.Block
-	client  *rpchttp.HTTP
+	pool    *SequencerPool
+
+	// Websocket connection to the best sequencer node
+	endpoint *SequencerEndpoint
+	client   *rpchttp.HTTP
 
 	// Control channels
 	pauseChan  chan struct{}
 	resumeChan chan struct{}
+	isRunning  bool
 }
 
-// Maintains a persistent websocket connection to the sequencer
-// Gets new blocks through the websocket
+// Maintains a persistent websocket connection to the best sequencer node
+// Gets new blocks through the websocket, switches to another node when the best one changes
 func NewStreamer(config *config.Config) (self *Streamer) {
 	self = new(Streamer)
 
@@ -38,22 +43,11 @@ func NewStreamer(config *config.Config) (self *Streamer) {
 	self.Task = task.NewTask(config, "new-block-streamer")
 
 	self.Task = self.Task.
-		WithOnBeforeStart(func() (err error) {
-			err = self.client.Start()
-			if err != nil {
-				self.Log.WithError(err).Error("Failed to start websocket connection")
-			}
-			return
-		}).
 		WithOnStop(func() {
 			self.Pause()
-
-			err := self.client.Stop()
-			if err != nil {
-				self.Log.WithError(err).Error("Failed to stop websocket connection")
-			}
 		}).
 		WithOnAfterStop(func() {
+			self.disconnect()
 			close(self.Output)
 		}).
 		WithSubtaskFunc(self.run)
@@ -66,8 +60,8 @@ func (self *Streamer) WithMonitor(v monitoring.Monitor) *Streamer {
 	return self
 }
 
-func (self *Streamer) WithClient(client *rpchttp.HTTP) *Streamer {
-	self.client = client
+func (self *Streamer) WithSequencerPool(pool *SequencerPool) *Streamer {
+	self.pool = pool
 	return self
 }
 
@@ -79,16 +73,63 @@ func (self *Streamer) Resume() {
 	self.resumeChan <- struct{}{}
 }
 
+func (self *Streamer) connect() (err error) {
+	self.endpoint = self.pool.Best()
+	self.client, err = rpchttp.New(self.endpoint.Url, "/websocket")
+	if err != nil {
+		return
+	}
+
+	err = self.client.Start()
+	if err != nil {
+		self.Log.WithError(err).WithField("url", self.endpoint.Url).Error("Failed to start websocket connection")
+		self.client = nil
+		return
+	}
+
+	self.Log.WithField("url", self.endpoint.Url).Info("Connected to sequencer node")
+	return
+}
+
+func (self *Streamer) disconnect() {
+	if self.client == nil {
+		return
+	}
+
+	err := self.client.Stop()
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to stop websocket connection")
+	}
+	self.client = nil
+}
+
 func (self *Streamer) onResume() (out <-chan ctypes.ResultEvent, err error) {
+	if self.client == nil {
+		err = self.connect()
+		if err != nil {
+			self.pool.OnError(self.endpoint)
+			return
+		}
+	}
+
 	// Subscribe with the query
 	// Query will be automatically used upon re-subscribing
 	ctx, cancel := context.WithTimeout(self.Ctx, 10*time.Second)
 	defer cancel()
 
-	return self.client.Subscribe(ctx, "relayer-new-block-streamer", "tm.event='NewBlock'", 1 /* queue size */)
+	out, err = self.client.Subscribe(ctx, "relayer-new-block-streamer", "tm.event='NewBlock'", 1 /* queue size */)
+	if err != nil {
+		self.pool.OnError(self.endpoint)
+		self.disconnect()
+	}
+	return
 }
 
 func (self *Streamer) onPause() (err error) {
+	if self.client == nil {
+		return
+	}
+
 	// Subscribe with the query
 	// Query will be automatically used upon re-subscribing
 	ctx, cancel := context.WithTimeout(self.Ctx, 10*time.Second)
@@ -97,10 +138,35 @@ func (self *Streamer) onPause() (err error) {
 	return self.client.UnsubscribeAll(ctx, "relayer-new-block-streamer")
 }
 
+// Subscribes to the current best node. Missed blocks are filled in by the Source
+func (self *Streamer) onSwitch() (out <-chan ctypes.ResultEvent, err error) {
+	err = self.onPause()
+	if err != nil {
+		self.Log.WithError(err).Warn("Failed to unsubscribe from the previous sequencer node")
+	}
+	self.disconnect()
+
+	return self.onResume()
+}
+
 func (self *Streamer) run() (err error) {
 	// Streamer starts in PAUSED state
 	var input <-chan ctypes.ResultEvent
 
+	if self.isRunning {
+		// Subtask got restarted after a failure, give nodes some time
+		select {
+		case <-self.Ctx.Done():
+			return
+		case <-time.After(self.Config.Relayer.SequencerHealthCheckInterval):
+		}
+
+		input, err = self.onSwitch()
+		if err != nil {
+			return
+		}
+	}
+
 	for {
 		if input == nil {
 			// State: PAUSED
@@ -109,6 +175,7 @@ func (self *Streamer) run() (err error) {
 			case <-self.Ctx.Done():
 				return
 			case <-self.resumeChan:
+				self.isRunning = true
 				input, err = self.onResume()
 				if err != nil {
 					return
@@ -121,17 +188,28 @@ func (self *Streamer) run() (err error) {
 			case <-self.Ctx.Done():
 				return
 			case <-self.pauseChan:
+				self.isRunning = false
 				err = self.onPause()
 				input = nil
 				if err != nil {
 					return
 				}
 				// Input channel is still not nil, to receive pending data
+			case <-self.pool.BestChanged:
+				input, err = self.onSwitch()
+				if err != nil {
+					return
+				}
 			case data, ok := <-input:
 				if !ok {
-					// Most probably unsubscribed from the websocket
-					self.Log.Debug("Input channel closed")
-					input = nil
+					// Most probably the node went down, try another one
+					self.Log.WithField("url", self.endpoint.Url).Warn("Input channel closed")
+					self.pool.OnError(self.endpoint)
+					input, err = self.onSwitch()
+					if err != nil {
+						return
+					}
+					continue
 				}
 
 				// Neglect other events
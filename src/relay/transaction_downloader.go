@@ -11,6 +11,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/smartweave"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/cenkalti/backoff/v4"
 )
@@ -57,7 +58,7 @@ func (self *TransactionDownloader) WithInputChannel(v chan *Payload) *Transactio
 }
 
 // Downloads one transaction, handles automatic retries and validation
-func (self *TransactionDownloader) downloadOne(txId string) (out *arweave.Transaction, err error) {
+func (self *TransactionDownloader) downloadOne(ctx context.Context, txId string) (out *arweave.Transaction, err error) {
 	err = task.NewRetry().
 		WithContext(self.Ctx).
 		WithMaxElapsedTime(0 /* never give up */).
@@ -92,14 +93,14 @@ func (self *TransactionDownloader) downloadOne(txId string) (out *arweave.Transa
 			return err
 		}).
 		Run(func() error {
-			out, err = self.client.GetTransactionById(self.Ctx, txId)
+			out, err = self.client.GetTransactionById(ctx, txId)
 			if err != nil {
 				self.Log.WithField("txId", txId).Error("Failed to download transaction")
 				return err
 			}
 
 			if smartweave.IsInteractionWithData(out) {
-				buf, err := self.client.GetTransactionDataById(self.Ctx, out)
+				buf, err := self.client.GetTransactionDataById(ctx, out)
 				if err != nil {
 					self.Log.WithField("txId", txId).Error("Failed to download transaction data")
 					self.monitor.GetReport().TransactionDownloader.Errors.DataDownload.Inc()
@@ -142,7 +143,7 @@ func (self *TransactionDownloader) downloadOne(txId string) (out *arweave.Transa
 	return
 }
 
-func (self *TransactionDownloader) downloadTransactions(block *ArweaveBlock) (out []*arweave.Transaction, err error) {
+func (self *TransactionDownloader) downloadTransactions(ctx context.Context, block *ArweaveBlock) (out []*arweave.Transaction, err error) {
 	if len(block.Message.Transactions) == 0 {
 		// Skip, we'll only update info about the arweave block
 		return
@@ -162,7 +163,7 @@ func (self *TransactionDownloader) downloadTransactions(block *ArweaveBlock) (ou
 		i := i
 
 		self.SubmitToWorker(func() {
-			tx, errOne := self.downloadOne(txInfo.Transaction.Id)
+			tx, errOne := self.downloadOne(ctx, txInfo.Transaction.Id)
 			mtx.Lock()
 			if errOne != nil {
 				err = errOne
@@ -183,10 +184,13 @@ func (self *TransactionDownloader) downloadTransactions(block *ArweaveBlock) (ou
 // Fills in transactions for arweave blocks in payload
 func (self *TransactionDownloader) run() (err error) {
 	for payload := range self.input {
+		ctx, span := payload.startSpan(self.Ctx, self.Name)
+
 		// Download transactions one by one using TransactionDownloader
 		for i, arweaveBlock := range payload.ArweaveBlocks {
-			transactions, err := self.downloadTransactions(arweaveBlock)
+			transactions, err := self.downloadTransactions(ctx, arweaveBlock)
 			if err != nil {
+				tracing.End(span, err)
 				if self.IsStopping.Load() {
 					// Neglect those transactions, we're stopping anyway
 					return nil
@@ -205,6 +209,8 @@ func (self *TransactionDownloader) run() (err error) {
 				Info("Downloaded transactions from one arweave block")
 		}
 
+		span.End()
+
 		// Arweave blocks filled
 		select {
 		case <-self.Ctx.Done():
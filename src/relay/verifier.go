@@ -0,0 +1,485 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	cmtmath "github.com/cometbft/cometbft/libs/math"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	lighthttp "github.com/cometbft/cometbft/light/provider/http"
+	"github.com/cometbft/cometbft/types"
+
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Verifies Sequencer's blocks with the CometBFT light client logic before they get decoded.
+// Trust starts from the higher of the checkpoint (height + hash) set in the config and the last trusted header saved in the database.
+// Each block's header, commit signatures and validator set are verified against the last trusted header, within the trusting period.
+// Blocks below the trusted header are verified backwards, through the chain of hashes.
+// Only a window of hashes above the last such block is kept, along with every window-th header to verify the next window from.
+// Block that fails verification halts the pipeline and sets a permanent error in the monitor.
+//
+// Commit of a block is only in the next block, so each block costs an additional LightBlock request to the sequencer.
+type Verifier struct {
+	*task.Task
+
+	db      *gorm.DB
+	monitor monitoring.Monitor
+	pool    *SequencerPool
+	chainId string
+
+	trustLevel cmtmath.Fraction
+	trusted    *types.LightBlock
+
+	// Source of light blocks, the next node from the pool by default
+	lightBlock func(ctx context.Context, height int64) (*types.LightBlock, error)
+
+	// Hashes of headers below the trusted one, verified backwards
+	belowWindow      int64
+	verifiedBelow    map[int64][]byte
+	checkpointsBelow map[int64]*types.Header
+
+	// Last trusted header, periodically saved
+	mtx     sync.Mutex
+	toSave  *types.LightBlock
+	isSaved bool
+
+	input  <-chan *types.Block
+	Output chan *types.Block
+}
+
+func NewVerifier(config *config.Config) (self *Verifier) {
+	self = new(Verifier)
+
+	self.Output = make(chan *types.Block)
+
+	self.lightBlock = self.lightBlockFromPool
+	self.belowWindow = 1000
+	self.verifiedBelow = make(map[int64][]byte)
+	self.checkpointsBelow = make(map[int64]*types.Header)
+
+	self.Task = task.NewTask(config, "verifier").
+		WithOnBeforeStart(self.init).
+		WithSubtaskFunc(self.run).
+		WithPeriodicSubtaskFunc(config.Relayer.LightClientSaveInterval, self.save).
+		WithOnAfterStop(func() {
+			close(self.Output)
+
+			// Context is already cancelled
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := self.saveTrusted(ctx)
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to save trusted header before stopping")
+			}
+		})
+
+	return
+}
+
+// Trusted header is loaded from and saved to the database, if set. Shadow relayer only loads it
+func (self *Verifier) WithDB(db *gorm.DB) *Verifier {
+	self.db = db
+	return self
+}
+
+func (self *Verifier) WithMonitor(monitor monitoring.Monitor) *Verifier {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Verifier) WithSequencerPool(pool *SequencerPool) *Verifier {
+	self.pool = pool
+	return self
+}
+
+func (self *Verifier) WithInputChannel(v <-chan *types.Block) *Verifier {
+	self.input = v
+	return self
+}
+
+func (self *Verifier) init() (err error) {
+	self.trustLevel, err = cmtmath.ParseFraction(self.Config.Relayer.LightClientTrustLevel)
+	if err != nil {
+		self.Log.WithError(err).Error("Invalid trust level")
+		return
+	}
+	err = light.ValidateTrustLevel(self.trustLevel)
+	if err != nil {
+		self.Log.WithError(err).Error("Invalid trust level")
+		return
+	}
+
+	if self.Config.Relayer.LightClientTrustedHeight <= 0 || len(self.Config.Relayer.LightClientTrustedHash) == 0 {
+		err = errors.New("light client needs a trusted checkpoint")
+		self.Log.WithError(err).Error("Trusted height and hash need to be set")
+		return
+	}
+
+	self.chainId = self.Config.Relayer.LightClientChainId
+	if len(self.chainId) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		status, err := self.pool.Best().Client.Status(ctx)
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to get chain id from the sequencer")
+			return err
+		}
+		self.chainId = status.NodeInfo.Network
+	}
+
+	return
+}
+
+// Checkpoint and the saved header are trusted without verification, they only need to match their hashes.
+// Verification starts from the higher one
+func (self *Verifier) initTrusted() (err error) {
+	height := self.Config.Relayer.LightClientTrustedHeight
+	expected, err := hex.DecodeString(self.Config.Relayer.LightClientTrustedHash)
+	if err != nil {
+		return
+	}
+
+	saved, err := self.loadTrusted()
+	if err != nil {
+		return
+	}
+	if saved != nil && int64(saved.FinishedBlockHeight) > height {
+		height = int64(saved.FinishedBlockHeight)
+		expected = saved.FinishedBlockHash.Bytes()
+	}
+
+	lightBlock, err := self.fetch(height)
+	if err != nil {
+		return
+	}
+
+	if !bytes.Equal(lightBlock.Hash(), expected) {
+		return fmt.Errorf("trusted header hash mismatch at %d, expected %X got %X", height, expected, lightBlock.Hash())
+	}
+
+	self.setTrusted(lightBlock)
+
+	self.Log.WithField("height", lightBlock.Height).Info("Initialized trusted header")
+	return
+}
+
+// Last header trusted before the restart, nil if there's none
+func (self *Verifier) loadTrusted() (state *model.State, err error) {
+	if self.db == nil {
+		return
+	}
+
+	state = new(model.State)
+	err = self.db.WithContext(self.Ctx).
+		Where("name = ?", model.SyncedComponentRelayerLightClient).
+		Limit(1).
+		Find(state).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to get saved trusted header")
+		return nil, err
+	}
+	if state.Name == "" {
+		return nil, nil
+	}
+	return
+}
+
+func (self *Verifier) save() (err error) {
+	err = self.saveTrusted(self.Ctx)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to save trusted header")
+	}
+	return nil
+}
+
+// Upserts the last trusted header, so verification doesn't need to start from the checkpoint after a restart
+func (self *Verifier) saveTrusted(ctx context.Context) (err error) {
+	if self.db == nil || self.Config.Relayer.ShadowEnabled {
+		return
+	}
+
+	self.mtx.Lock()
+	lightBlock, isSaved := self.toSave, self.isSaved
+	self.mtx.Unlock()
+	if lightBlock == nil || isSaved {
+		return
+	}
+
+	err = self.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"finished_block_timestamp", "finished_block_height", "finished_block_hash"}),
+		}).
+		Create(&model.State{
+			Name:                   model.SyncedComponentRelayerLightClient,
+			FinishedBlockTimestamp: uint64(lightBlock.Time.Unix()),
+			FinishedBlockHeight:    uint64(lightBlock.Height),
+			FinishedBlockHash:      arweave.Base64String(lightBlock.Hash()),
+		}).
+		Error
+	if err != nil {
+		return
+	}
+
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	if self.toSave == lightBlock {
+		self.isSaved = true
+	}
+	return
+}
+
+func (self *Verifier) setTrusted(lightBlock *types.LightBlock) {
+	self.trusted = lightBlock
+
+	self.mtx.Lock()
+	self.toSave = lightBlock
+	self.isSaved = false
+	self.mtx.Unlock()
+
+	self.monitor.GetReport().Relayer.State.LightClientTrustedHeight.Store(lightBlock.Height)
+}
+
+// Signed header and validator set at the given height, retried upon network errors
+func (self *Verifier) fetch(height int64) (out *types.LightBlock, err error) {
+	err = task.NewRetry().
+		WithContext(self.Ctx).
+		WithMaxElapsedTime(self.Config.Relayer.SourceBackoffMaxElapsedTime).
+		WithMaxInterval(self.Config.Relayer.SourceBackoffMaxInterval).
+		WithAcceptableDuration(self.Config.Relayer.SourceBackoffMaxInterval * 10).
+		WithOnError(func(err error, isDurationAcceptable bool) error {
+			if errors.Is(err, context.Canceled) && self.IsStopping.Load() {
+				return backoff.Permanent(err)
+			}
+
+			var errBadLightBlock provider.ErrBadLightBlock
+			if errors.As(err, &errBadLightBlock) {
+				// Node returned invalid data, no point in retrying
+				return backoff.Permanent(err)
+			}
+
+			self.Log.WithError(err).WithField("height", height).Warn("Failed to download light block, retrying after timeout")
+			self.monitor.GetReport().Relayer.Errors.SequencerBlockDownloadError.Inc()
+
+			return err
+		}).
+		Run(func() (err error) {
+			out, err = self.lightBlock(self.Ctx, height)
+			return
+		})
+	return
+}
+
+func (self *Verifier) lightBlockFromPool(ctx context.Context, height int64) (out *types.LightBlock, err error) {
+	// Verification doesn't depend on which node returned the data
+	endpoint := self.pool.Next()
+	out, err = lighthttp.NewWithClient(self.chainId, endpoint.Client).LightBlock(ctx, height)
+	if err != nil && ctx.Err() == nil {
+		self.pool.OnError(endpoint)
+	}
+	return
+}
+
+// Verifies the light block against the trusted one. Falls back to bisection if validator set changed too much in between.
+func (self *Verifier) verifyLightBlock(untrusted *types.LightBlock) (err error) {
+	// Trusted header older than the trusting period can't be used, validators that signed it may have already unbonded
+	now := time.Now()
+
+	if untrusted.Height == self.trusted.Height+1 {
+		err = light.VerifyAdjacent(self.trusted.SignedHeader, untrusted.SignedHeader, untrusted.ValidatorSet,
+			self.Config.Relayer.LightClientTrustingPeriod, now, self.Config.Relayer.LightClientMaxClockDrift)
+		if err != nil {
+			return
+		}
+		self.setTrusted(untrusted)
+		return
+	}
+
+	err = light.VerifyNonAdjacent(self.trusted.SignedHeader, self.trusted.ValidatorSet, untrusted.SignedHeader, untrusted.ValidatorSet,
+		self.Config.Relayer.LightClientTrustingPeriod, now, self.Config.Relayer.LightClientMaxClockDrift, self.trustLevel)
+	if err == nil {
+		self.setTrusted(untrusted)
+		return
+	}
+
+	var errExpired light.ErrOldHeaderExpired
+	if errors.As(err, &errExpired) {
+		return fmt.Errorf("trusted header at %d expired, a newer checkpoint needs to be set: %w", self.trusted.Height, err)
+	}
+
+	var errValSet light.ErrNewValSetCantBeTrusted
+	if !errors.As(err, &errValSet) {
+		return
+	}
+
+	// Verify the block in the middle first
+	pivot, err := self.fetch((self.trusted.Height + untrusted.Height) / 2)
+	if err != nil {
+		return
+	}
+
+	err = self.verifyLightBlock(pivot)
+	if err != nil {
+		return
+	}
+
+	return self.verifyLightBlock(untrusted)
+}
+
+func (self *Verifier) verify(block *types.Block) (err error) {
+	if self.trusted == nil {
+		err = self.initTrusted()
+		if err != nil {
+			return
+		}
+	}
+
+	// Block's content needs to match its header
+	err = block.ValidateBasic()
+	if err != nil {
+		return
+	}
+
+	if block.Height < self.trusted.Height {
+		// E.g. relayer is behind the checkpoint or the saved header
+		return self.verifyBelow(block)
+	}
+
+	if block.Height == self.trusted.Height {
+		// Only possible with the initial trusted header
+		if !bytes.Equal(block.Hash(), self.trusted.Hash()) {
+			return fmt.Errorf("block hash %X doesn't match the trusted hash %X", block.Hash(), self.trusted.Hash())
+		}
+		return
+	}
+
+	lightBlock, err := self.fetch(block.Height)
+	if err != nil {
+		return
+	}
+
+	err = self.verifyLightBlock(lightBlock)
+	if err != nil {
+		return
+	}
+
+	// Header is trusted now, block needs to match it
+	if !bytes.Equal(block.Hash(), lightBlock.Hash()) {
+		return fmt.Errorf("block hash %X doesn't match the verified header %X", block.Hash(), lightBlock.Hash())
+	}
+
+	return
+}
+
+// Block below the trusted header needs to be in the chain of hashes that leads to it
+func (self *Verifier) verifyBelow(block *types.Block) (err error) {
+	// Blocks come in order, lower hashes won't be needed
+	for height := range self.verifiedBelow {
+		if height < block.Height {
+			delete(self.verifiedBelow, height)
+		}
+	}
+	for height := range self.checkpointsBelow {
+		if height < block.Height {
+			delete(self.checkpointsBelow, height)
+		}
+	}
+
+	expected, ok := self.verifiedBelow[block.Height]
+	if !ok {
+		expected, err = self.verifyBackwards(block.Height)
+		if err != nil {
+			return
+		}
+	}
+	delete(self.verifiedBelow, block.Height)
+
+	if !bytes.Equal(block.Hash(), expected) {
+		return fmt.Errorf("block hash %X doesn't match the header verified backwards %X", block.Hash(), expected)
+	}
+	return
+}
+
+// Verifies headers from the closest verified one above the height down to it, returns hash of the header at the height.
+// Keeps hashes of the window above the height and every window-th header for later blocks
+func (self *Verifier) verifyBackwards(height int64) (hash []byte, err error) {
+	trusted := self.trusted.Header
+	for checkpointHeight, header := range self.checkpointsBelow {
+		if checkpointHeight > height && checkpointHeight < trusted.Height {
+			trusted = header
+		}
+	}
+
+	if trusted.Height-height > self.belowWindow {
+		self.Log.WithField("from", trusted.Height).WithField("to", height).Info("Verifying headers backwards")
+	}
+
+	for trusted.Height > height {
+		var lightBlock *types.LightBlock
+		lightBlock, err = self.fetch(trusted.Height - 1)
+		if err != nil {
+			return
+		}
+
+		err = light.VerifyBackwards(lightBlock.Header, trusted)
+		if err != nil {
+			return
+		}
+
+		if lightBlock.Height < height+self.belowWindow {
+			self.verifiedBelow[lightBlock.Height] = lightBlock.Hash()
+		}
+		if lightBlock.Height%self.belowWindow == 0 {
+			self.checkpointsBelow[lightBlock.Height] = lightBlock.Header
+		}
+		trusted = lightBlock.Header
+	}
+
+	return trusted.Hash(), nil
+}
+
+func (self *Verifier) run() (err error) {
+	for block := range self.input {
+		err = self.verify(block)
+		if err != nil {
+			if self.IsStopping.Load() {
+				return nil
+			}
+
+			self.monitor.GetReport().Relayer.Errors.SequencerBlockVerificationError.Inc()
+			self.Log.WithError(err).WithField("sequencer_height", block.Height).Error("Failed to verify block, halting")
+			self.monitor.SetPermanentError(err)
+
+			// Nothing passes through till the restart
+			<-self.Ctx.Done()
+			return nil
+		}
+
+		self.monitor.GetReport().Relayer.State.SequencerBlocksVerified.Inc()
+
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case self.Output <- block:
+		}
+	}
+
+	return nil
+}
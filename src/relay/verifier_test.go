@@ -0,0 +1,167 @@
+package relay
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/light/provider"
+	"github.com/cometbft/cometbft/light/provider/mock"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/suite"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	monitor_relayer "github.com/warp-contracts/syncer/src/utils/monitoring/relayer"
+)
+
+const (
+	testChainId     = "test-chain"
+	testChainLength = 30
+)
+
+type VerifierTestSuite struct {
+	suite.Suite
+
+	blocks  map[int64]*types.Block
+	headers map[int64]*types.SignedHeader
+	vals    map[int64]*types.ValidatorSet
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
+
+// Chain of blocks signed by the same validators, one block per second ending a minute ago
+func (s *VerifierTestSuite) SetupTest() {
+	vals, privVals := types.RandValidatorSet(4, 10)
+	start := time.Now().Add(-time.Minute - testChainLength*time.Second)
+
+	s.blocks = make(map[int64]*types.Block)
+	s.headers = make(map[int64]*types.SignedHeader)
+	s.vals = make(map[int64]*types.ValidatorSet)
+
+	lastCommit := &types.Commit{}
+	var lastBlockId types.BlockID
+	for height := int64(1); height <= testChainLength; height++ {
+		block := types.MakeBlock(height, nil, lastCommit, nil)
+		block.ChainID = testChainId
+		block.Time = start.Add(time.Duration(height) * time.Second)
+		block.LastBlockID = lastBlockId
+		block.ValidatorsHash = vals.Hash()
+		block.NextValidatorsHash = vals.Hash()
+		block.ProposerAddress = vals.GetProposer().Address
+
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		s.Require().NoError(err)
+		blockId := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		voteSet := types.NewVoteSet(testChainId, height, 0, cmtproto.PrecommitType, vals)
+		extCommit, err := types.MakeExtCommit(blockId, height, 0, voteSet, privVals, block.Time.Add(time.Second/2), false)
+		s.Require().NoError(err)
+		commit := extCommit.ToCommit()
+
+		s.blocks[height] = block
+		s.headers[height] = &types.SignedHeader{Header: &block.Header, Commit: commit}
+		s.vals[height] = vals
+
+		lastCommit = commit
+		lastBlockId = blockId
+	}
+}
+
+// Verifier trusting the block at the height, light blocks come from the chain
+func (s *VerifierTestSuite) verifier(trustedHeight int64, source provider.Provider) *Verifier {
+	config := config.Default()
+	config.Relayer.LightClientChainId = testChainId
+	config.Relayer.LightClientTrustedHeight = trustedHeight
+	config.Relayer.LightClientTrustedHash = hex.EncodeToString(s.blocks[trustedHeight].Hash())
+	config.Relayer.SourceBackoffMaxElapsedTime = time.Second
+
+	verifier := NewVerifier(config).
+		WithMonitor(monitor_relayer.NewMonitor(config))
+	s.Require().NoError(verifier.init())
+
+	if source == nil {
+		source = mock.New(testChainId, s.headers, s.vals)
+	}
+	verifier.lightBlock = source.LightBlock
+	return verifier
+}
+
+func (s *VerifierTestSuite) TestAdjacent() {
+	verifier := s.verifier(5, nil)
+	for height := int64(5); height <= 10; height++ {
+		s.Require().NoError(verifier.verify(s.blocks[height]))
+	}
+	s.Require().Equal(int64(10), verifier.trusted.Height)
+}
+
+func (s *VerifierTestSuite) TestGap() {
+	verifier := s.verifier(5, nil)
+
+	// Blocks in between were skipped, the header is verified from the trusted one
+	s.Require().NoError(verifier.verify(s.blocks[20]))
+	s.Require().Equal(int64(20), verifier.trusted.Height)
+	s.Require().NoError(verifier.verify(s.blocks[21]))
+}
+
+func (s *VerifierTestSuite) TestBlockDoesntMatchHeader() {
+	verifier := s.verifier(5, nil)
+
+	// Valid block, but not the one the validators signed
+	block := s.blocks[6]
+	other := types.MakeBlock(6, []types.Tx{[]byte("tx")}, block.LastCommit, nil)
+	other.Header = block.Header
+	other.DataHash = other.Data.Hash()
+
+	s.Require().Error(verifier.verify(other))
+}
+
+func (s *VerifierTestSuite) TestBadLightBlock() {
+	// Light block at 6 has a commit for another header
+	headers := make(map[int64]*types.SignedHeader, len(s.headers))
+	for height, header := range s.headers {
+		headers[height] = header
+	}
+	headers[6] = &types.SignedHeader{Header: s.headers[6].Header, Commit: s.headers[7].Commit}
+
+	verifier := s.verifier(5, mock.New(testChainId, headers, s.vals))
+	s.Require().NoError(verifier.verify(s.blocks[5]))
+
+	// Not retried, the node returned invalid data
+	start := time.Now()
+	err := verifier.verify(s.blocks[6])
+	s.Require().ErrorAs(err, &provider.ErrBadLightBlock{})
+	s.Require().Less(time.Since(start), verifier.Config.Relayer.SourceBackoffMaxElapsedTime)
+}
+
+func (s *VerifierTestSuite) TestBelowTrusted() {
+	verifier := s.verifier(25, nil)
+	verifier.belowWindow = 4
+
+	for height := int64(2); height <= 25; height++ {
+		s.Require().NoError(verifier.verify(s.blocks[height]), height)
+		s.Require().LessOrEqual(len(verifier.verifiedBelow), int(verifier.belowWindow))
+		s.Require().LessOrEqual(len(verifier.checkpointsBelow), 25/int(verifier.belowWindow))
+	}
+}
+
+func (s *VerifierTestSuite) TestBelowTrustedWithGap() {
+	verifier := s.verifier(25, nil)
+	verifier.belowWindow = 4
+
+	s.Require().NoError(verifier.verify(s.blocks[3]))
+
+	// Blocks in between were skipped, their hashes aren't kept
+	s.Require().NoError(verifier.verify(s.blocks[17]))
+	for height := range verifier.verifiedBelow {
+		s.Require().Greater(height, int64(17))
+	}
+
+	// Block that doesn't match the chain of hashes
+	block := s.blocks[18]
+	other := types.MakeBlock(18, []types.Tx{[]byte("tx")}, block.LastCommit, nil)
+	other.Header = block.Header
+	other.DataHash = other.Data.Hash()
+	s.Require().Error(verifier.verify(other))
+}
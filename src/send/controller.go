@@ -1,13 +1,14 @@
 package send
 
 import (
-	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/balance_monitor"
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/listener"
-	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_sender "github.com/warp-contracts/syncer/src/utils/monitoring/sender"
+	"github.com/warp-contracts/syncer/src/utils/price_cache"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 	"github.com/warp-contracts/syncer/src/utils/turbo"
 )
@@ -21,27 +22,27 @@ type Controller struct {
 // |               |
 // |               |
 // | +-----------+ |
-// | |  Poller   | |             +----------+         +-----------+                +-----------------+
-// | +-----------+ |     tx      |          |   pd    |           |  network_info  |                 |
-// |               +------------>|  Sender  +-------->|   Store   |<-------------- | Network Monitor |
-// | +-----------+ |             |          |         |           |                |                 |
-// | |  Notifier | |             +----------+         +-----------+                +-----------------+
+// | |  Poller   | |             +-----------+          +----------+         +-----------+                +-----------------+
+// | +-----------+ |     tx      |           |    tx    |          |   pd    |           |  network_info  |                 |
+// |               +------------>| Scheduler +--------->|  Sender  +-------->|   Store   |<-------------- | Network Monitor |
+// | +-----------+ |             |           |          |          |         |           |                |                 |
+// | |  Notifier | |             +-----------+          +----------+         +-----------+                +-----------------+
 // | +-----------+ |
 // |               |
 // +---------------+
 // Main class that orchestrates main syncer functionalities
-func NewController(config *config.Config) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources) (self *Controller, err error) {
 	self = new(Controller)
 	self.Task = task.NewTask(config, "sender-controller")
 
 	// SQL database
-	db, err := model.NewConnection(self.Ctx, config, "sender")
+	db, err := resources.GetDB(self.Ctx, config, "sender")
 	if err != nil {
 		return
 	}
 
 	// Arweave client
-	arweaveClient := arweave.NewClient(self.Ctx, config)
+	arweaveClient := resources.GetArweaveClient(self.Ctx, config)
 
 	// Clients used for bundling
 	irysClient := bundlr.NewClient(self.Ctx, &config.Bundlr)
@@ -49,8 +50,7 @@ func NewController(config *config.Config) (self *Controller, err error) {
 
 	// Monitoring
 	monitor := monitor_sender.NewMonitor()
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "send", monitor)
 
 	// Gets data items from the database
 	collector := NewCollector(config, db).
@@ -64,12 +64,22 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithRequiredConfirmationBlocks(0).
 		WithEnableOutput(false /*disable output channel to avoid blocking*/)
 
+	// Divides items into priority lanes and passes them on fairly between contracts
+	scheduler := NewScheduler(config).
+		WithInputChannel(collector.Output).
+		WithMonitor(monitor)
+
 	// Sends data items to bundle service
+	// Irys prices, refreshed in the background
+	priceCache := price_cache.NewPriceCache(config).
+		WithIrysClient(irysClient)
+
 	sender := NewSender(config, db).
-		WithInputChannel(collector.Output).
+		WithInputChannel(scheduler.Output).
 		WithMonitor(monitor).
 		WithIrysClient(irysClient).
-		WithTurboClient(turboClient)
+		WithTurboClient(turboClient).
+		WithPriceCache(priceCache)
 
 	// Save updated data items
 	store := NewStore(config).
@@ -78,6 +88,12 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithNetworkMonitor(networkMonitor).
 		WithInputChannel(sender.Output)
 
+	// Checks if there's enough funds in the bundling wallet
+	balanceMonitor := balance_monitor.NewBalanceMonitor(config).
+		WithIrysClient(irysClient).
+		WithTurboClient(turboClient).
+		WithMonitor(monitor)
+
 	// Periodically run queries. Results stored in the monitor.
 	dbPoller := monitoring.NewDbPoller(config).
 		WithDB(db).
@@ -90,7 +106,10 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		WithSubtask(sender.Task).
 		WithSubtask(monitor.Task).
 		WithSubtask(networkMonitor.Task).
-		WithSubtask(server.Task).
+		WithConditionalSubtask(!config.Bundlr.BalanceMonitorDisabled, balanceMonitor.Task).
+		WithConditionalSubtask(config.Bundlr.IrysSendProbability > 0, priceCache.Task).
+		WithConditionalSubtask(server != nil, server).
+		WithSubtask(scheduler.Task).
 		WithSubtask(collector.Task)
 	return
 }
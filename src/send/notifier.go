@@ -2,6 +2,7 @@ package send
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/model"
@@ -17,9 +18,10 @@ type Notifier struct {
 	*task.Task
 	db *gorm.DB
 
-	streamer *streamer.Streamer
-	monitor  monitoring.Monitor
-	output   chan *model.DataItem
+	streamer   *streamer.Streamer
+	replicator *streamer.Replicator
+	monitor    monitoring.Monitor
+	output     chan *model.DataItem
 }
 
 func NewNotifier(config *config.Config) (self *Notifier) {
@@ -30,6 +32,18 @@ func NewNotifier(config *config.Config) (self *Notifier) {
 		return
 	}
 
+	if config.Replication.Enabled {
+		// Live source of inserted data items, with all columns
+		self.replicator = streamer.NewReplicator(config, "sender").
+			WithTables(model.TableDataItem).
+			WithCapacity(10)
+
+		self.Task = task.NewTask(config, "notifier").
+			WithSubtask(self.replicator.Task).
+			WithSubtaskFunc(self.runReplication)
+		return
+	}
+
 	self.streamer = streamer.NewStreamer(config, "sender-notifier").
 		WithNotificationChannelName("data_items_pending").
 		WithCapacity(10)
@@ -122,3 +136,68 @@ func (self *Notifier) run() error {
 		}
 	}
 }
+
+// Takes pending data items from the replication stream, like the notification trigger does.
+// Items already taken by the poller are skipped.
+func (self *Notifier) runReplication() (err error) {
+	for {
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case change, ok := <-self.replicator.Output:
+			if !ok {
+				self.Log.Info("Replicator channel closed")
+				return nil
+			}
+
+			err = self.handleChange(change)
+			if err != nil {
+				return
+			}
+
+			self.replicator.Ack(change)
+		}
+	}
+}
+
+func (self *Notifier) handleChange(change *streamer.Change) (err error) {
+	state, _ := change.Get("state")
+	if change.Operation != streamer.OperationInsert || state != string(model.BundleStatePending) {
+		return
+	}
+
+	var dataItem model.DataItem
+	err = change.Scan(&dataItem)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to scan data item")
+		return nil
+	}
+
+	result := self.db.WithContext(self.Ctx).
+		Model(&model.DataItem{}).
+		Where("data_item_id = ?", dataItem.DataItemID).
+		Where("state = ?", model.BundleStatePending).
+		Update("state", model.BundleStateUploading)
+	if result.Error != nil {
+		// Data item will be picked up by the poller
+		self.Log.WithError(result.Error).Error("Failed to update data item state")
+		self.monitor.GetReport().Sender.Errors.AdditionalFetchError.Inc()
+		return nil
+	}
+	if result.RowsAffected == 0 {
+		// Poller already took it
+		return nil
+	}
+	dataItem.State = model.BundleStateUploading
+
+	select {
+	case <-self.Ctx.Done():
+		return errors.New("notifier stopped")
+	case self.output <- &dataItem:
+	}
+
+	// Update metrics
+	self.monitor.GetReport().Sender.State.BundlesFromNotifications.Inc()
+
+	return nil
+}
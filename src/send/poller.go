@@ -51,18 +51,34 @@ func (self *Poller) handleNew() (repeat bool, err error) {
 	ctx, cancel := context.WithTimeout(self.Ctx, self.Config.Sender.PollerTimeout)
 	defer cancel()
 
-	// Gets new data items
+	// Gets new data items. Takes a window of the oldest pending items and picks a batch that's fair between contracts:
+	// - high priority items (explicit priority or allowlisted contract) go first,
+	// - then contracts take turns, each contract contributes its oldest items.
 	var dataItems []model.DataItem
 	err = self.db.WithContext(ctx).
-		Raw(`UPDATE data_items
-			SET state = 'UPLOADING'::bundle_state, updated_at = NOW()
-			WHERE data_item_id IN (SELECT data_item_id
+		Raw(`WITH candidates AS (
+				SELECT data_item_id, contract_id, updated_at,
+					(priority > 0 OR COALESCE(contract_id IN ?, FALSE)) AS is_priority
 				FROM data_items
 				WHERE state = 'PENDING'::bundle_state
-				ORDER BY updated_at ASC
+				ORDER BY priority DESC, updated_at ASC
 				LIMIT ?
-				FOR UPDATE SKIP LOCKED)
-			RETURNING *`, self.Config.Sender.PollerMaxBatchSize).
+				FOR UPDATE SKIP LOCKED
+			), selected AS (
+				SELECT data_item_id
+				FROM (SELECT data_item_id, is_priority, updated_at, ROW_NUMBER() OVER (PARTITION BY contract_id ORDER BY updated_at ASC) AS turn
+					FROM candidates) AS ranked
+				ORDER BY is_priority DESC, turn ASC, updated_at ASC
+				LIMIT ?
+			)
+			UPDATE data_items
+			SET state = 'UPLOADING'::bundle_state, updated_at = NOW()
+			FROM selected
+			WHERE data_items.data_item_id = selected.data_item_id
+			RETURNING data_items.*`,
+			self.Config.Sender.Scheduler.PriorityContracts,
+			max(self.Config.Sender.Scheduler.PollerFairnessWindow, self.Config.Sender.PollerMaxBatchSize),
+			self.Config.Sender.PollerMaxBatchSize).
 		Scan(&dataItems).Error
 
 	if err != nil {
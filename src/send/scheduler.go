@@ -0,0 +1,74 @@
+package send
+
+import (
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+const (
+	LaneHigh = iota
+	LaneNormal
+)
+
+// Puts data items into priority lanes and passes them to the sender fairly between contracts.
+// Tags are stored inside the signed data item, so here only explicit priority and contract allowlist are used.
+type Scheduler struct {
+	*task.Scheduler[*model.DataItem]
+
+	monitor monitoring.Monitor
+
+	priorityContracts map[string]struct{}
+}
+
+func NewScheduler(config *config.Config) (self *Scheduler) {
+	self = new(Scheduler)
+
+	self.priorityContracts = make(map[string]struct{}, len(config.Sender.Scheduler.PriorityContracts))
+	for _, contractId := range config.Sender.Scheduler.PriorityContracts {
+		self.priorityContracts[contractId] = struct{}{}
+	}
+
+	self.Scheduler = task.NewScheduler[*model.DataItem](config, "scheduler").
+		WithCapacity(config.Sender.Scheduler.QueueSize).
+		WithLaneWeights(config.Sender.Scheduler.HighLaneWeight, config.Sender.Scheduler.NormalLaneWeight).
+		WithKeyRateLimit(config.Sender.Scheduler.ContractRateLimit, config.Sender.Scheduler.ContractRateBurst).
+		WithGetLane(self.getLane).
+		WithGetKey(func(item *model.DataItem) string {
+			return item.ContractId.String
+		})
+
+	self.Scheduler.Task = self.Scheduler.Task.
+		WithPeriodicSubtaskFunc(time.Second, self.updateMetrics)
+
+	return
+}
+
+func (self *Scheduler) WithInputChannel(v chan *model.DataItem) *Scheduler {
+	self.Scheduler.WithInputChannel(v)
+	return self
+}
+
+func (self *Scheduler) WithMonitor(monitor monitoring.Monitor) *Scheduler {
+	self.monitor = monitor
+	return self
+}
+
+func (self *Scheduler) getLane(item *model.DataItem) int {
+	_, isPriorityContract := self.priorityContracts[item.ContractId.String]
+	if item.Priority > 0 || (item.ContractId.Valid && isPriorityContract) {
+		self.monitor.GetReport().Sender.State.PriorityItems.Inc()
+		return LaneHigh
+	}
+	return LaneNormal
+}
+
+func (self *Scheduler) updateMetrics() error {
+	state := &self.monitor.GetReport().Sender.State
+	state.HighLaneQueueSize.Store(int64(self.GetLaneSize(LaneHigh)))
+	state.NormalLaneQueueSize.Store(int64(self.GetLaneSize(LaneNormal)))
+	return nil
+}
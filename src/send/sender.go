@@ -11,10 +11,12 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/price_cache"
 	"github.com/warp-contracts/syncer/src/utils/task"
 	"github.com/warp-contracts/syncer/src/utils/turbo"
 	turboResponses "github.com/warp-contracts/syncer/src/utils/turbo/responses"
 
+	"github.com/jackc/pgtype"
 	"gorm.io/gorm"
 )
 
@@ -27,6 +29,7 @@ type Sender struct {
 	// Bundling and signing
 	irysClient  *bundlr.Client
 	turboClient *turbo.Client
+	priceCache  *price_cache.PriceCache
 
 	// Updated data items
 	Output chan *model.DataItem
@@ -62,6 +65,11 @@ func (self *Sender) WithTurboClient(client *turbo.Client) *Sender {
 	return self
 }
 
+func (self *Sender) WithPriceCache(v *price_cache.PriceCache) *Sender {
+	self.priceCache = v
+	return self
+}
+
 func (self *Sender) WithInputChannel(in chan *model.DataItem) *Sender {
 	self.input = in
 	return self
@@ -124,6 +132,11 @@ func (self *Sender) upload(dataItem *model.DataItem, item *bundlr.BundleItem) (r
 			return
 		}
 
+		// Turbo returns the price in the response
+		self.setPrice(dataItem, func() (int64, error) {
+			return bundlr.ParseWinc(uploadResponse.Winc)
+		})
+
 	case model.BundlingServiceIrys:
 		var uploadResponse *irysResponses.Upload
 		uploadResponse, resp, err = self.irysClient.Upload(self.Ctx, item)
@@ -160,6 +173,11 @@ func (self *Sender) upload(dataItem *model.DataItem, item *bundlr.BundleItem) (r
 			return
 		}
 
+		// Irys doesn't return the price, it's checked separately and cached
+		self.setPrice(dataItem, func() (int64, error) {
+			return self.priceCache.GetIrysPrice(self.Ctx, item.Size())
+		})
+
 	default:
 		err = errors.New("Unknown bundling service")
 		self.Log.WithError(err).WithField("service", dataItem.Service).Error("Unknown bundling service")
@@ -167,6 +185,21 @@ func (self *Sender) upload(dataItem *model.DataItem, item *bundlr.BundleItem) (r
 	return
 }
 
+// Price is only informative, failure to get it doesn't fail the upload
+func (self *Sender) setPrice(dataItem *model.DataItem, getPrice func() (int64, error)) {
+	price, err := getPrice()
+	if err != nil {
+		self.Log.WithError(err).WithField("data_item_id", dataItem.DataItemID).Warn("Failed to get upload price")
+		dataItem.Price.Status = pgtype.Null
+		return
+	}
+
+	err = dataItem.Price.Set(price)
+	if err != nil {
+		dataItem.Price.Status = pgtype.Null
+	}
+}
+
 func (self *Sender) run() (err error) {
 	// Waits for new data items
 	// Finishes when when the source of items is closed
@@ -239,6 +272,10 @@ func (self *Sender) run() (err error) {
 
 			// Update state
 			item.State = model.BundleStateUploaded
+			err = item.Size.Set(len(item.DataItem.Bytes))
+			if err != nil {
+				item.Size.Status = pgtype.Null
+			}
 
 			// Don't keep the data item in memory, let gc do its job
 			// This won't remove data item from the database
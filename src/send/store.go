@@ -1,6 +1,8 @@
 package send
 
 import (
+	"time"
+
 	"github.com/jackc/pgtype"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/listener"
@@ -28,9 +30,17 @@ func NewStore(config *config.Config) (self *Store) {
 		WithOnFlush(config.Sender.StoreInterval, self.flush).
 		WithBackoff(0 /* infinite retry */, config.Sender.StoreBackoffMaxInterval)
 
+	// Batching is adjusted upon configuration file change
+	self.Hole.WithOnConfigChange(self.reload)
+
 	return
 }
 
+func (self *Store) reload(config *config.Config) {
+	self.SetBatchSize(config.Sender.StoreBatchSize)
+	self.SetFlushInterval(config.Sender.StoreInterval)
+}
+
 func (self *Store) WithDB(db *gorm.DB) *Store {
 	self.db = db
 	return self
@@ -81,18 +91,26 @@ func (self *Store) flush(dataItems []*model.DataItem) (err error) {
 	// Uses one transaction to do all the updates
 	// NOTE: It still uses many requests to the database,
 	// it should be possible to combine updates into batches, but it's not a priority for now.
-	err = self.db.WithContext(self.Ctx).
-		Clauses(clause.OnConflict{
+	err = self.db.WithContext(self.Ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "data_item_id"}},
 			DoUpdates: clause.AssignmentColumns([]string{
 				"state",
 				"service",
 				"block_height",
 				"response",
+				"size",
+				"price",
 			}),
 		}).
-		CreateInBatches(&dataItems, self.Config.Sender.StoreBatchSize).
-		Error
+			CreateInBatches(&dataItems, self.Config.Sender.StoreBatchSize).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return self.saveCosts(tx, dataItems)
+	})
 	if err != nil {
 		self.Log.WithError(err).Error("Failed to save bundle items, retrying...")
 
@@ -107,3 +125,44 @@ func (self *Store) flush(dataItems []*model.DataItem) (err error) {
 	return nil
 
 }
+
+// Updates daily costs of uploads, per service. Data items aren't bound to contracts.
+func (self *Store) saveCosts(tx *gorm.DB, dataItems []*model.DataItem) (err error) {
+	costs := make(map[model.BundlingService]*model.BundlingCost)
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+
+	for _, dataItem := range dataItems {
+		if dataItem.State != model.BundleStateUploaded || dataItem.Service.Status != pgtype.Present {
+			continue
+		}
+
+		service := model.BundlingService(dataItem.Service.String)
+		cost, ok := costs[service]
+		if !ok {
+			cost = &model.BundlingCost{Day: day, Service: service}
+			costs[service] = cost
+		}
+
+		cost.Uploads += 1
+		cost.Size += dataItem.Size.Int
+		cost.Price += dataItem.Price.Int
+	}
+
+	for _, cost := range costs {
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "day"}, {Name: "service"}, {Name: "contract_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"uploads": gorm.Expr("bundling_costs.uploads + EXCLUDED.uploads"),
+				"size":    gorm.Expr("bundling_costs.size + EXCLUDED.size"),
+				"price":   gorm.Expr("bundling_costs.price + EXCLUDED.price"),
+			}),
+		}).
+			Create(cost).
+			Error
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
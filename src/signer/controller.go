@@ -0,0 +1,26 @@
+package signer
+
+import (
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+type Controller struct {
+	*task.Task
+}
+
+// Reference external signer. Keeps private keys in a separate, locked down process.
+// Other modes use it with signer:// key references.
+func NewController(config *config.Config) (self *Controller, err error) {
+	self = new(Controller)
+	self.Task = task.NewTask(config, "signer-controller")
+
+	// Serves signatures
+	server := NewServer(config)
+
+	// Setup everything, will start upon calling Controller.Start()
+	self.Task.
+		WithSubtask(server.Task)
+
+	return
+}
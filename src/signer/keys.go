@@ -0,0 +1,53 @@
+package signer
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+)
+
+var signatureTypes = map[string]bundlr.SignatureType{
+	"arweave":        bundlr.SignatureTypeArweave,
+	"ethereum":       bundlr.SignatureTypeEthereum,
+	"typed-ethereum": bundlr.SignatureTypeTypedEthereum,
+	"ed25519":        bundlr.SignatureTypeEd25519,
+	"solana":         bundlr.SignatureTypeSolana,
+	"aptos":          bundlr.SignatureTypeInjectedAptos,
+}
+
+// Parses keys in format name:type:key. Key may be a file:// or env:// reference, but not another external signer.
+func parseKeys(keys []string) (out map[string]bundlr.Signer, err error) {
+	out = make(map[string]bundlr.Signer, len(keys))
+	for _, entry := range keys {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			err = errors.New("key should be in format name:type:key")
+			return
+		}
+		name, typeName, ref := parts[0], parts[1], parts[2]
+
+		signatureType, ok := signatureTypes[typeName]
+		if !ok {
+			err = errors.New("unsupported key type: " + typeName)
+			return
+		}
+
+		if _, ok := out[name]; ok {
+			err = errors.New("duplicated key name: " + name)
+			return
+		}
+
+		var key string
+		key, err = bundlr.ResolveKey(ref)
+		if err != nil {
+			return
+		}
+
+		out[name], err = bundlr.NewSignerFromKey(key, signatureType)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,208 @@
+package signer
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/bundlr/responses"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/middleware"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const unixPrefix = "unix://"
+
+// Serves signatures made with keys that never leave this process.
+// Protocol is described in bundlr.RemoteSigner.
+type Server struct {
+	*task.Task
+
+	httpServer *http.Server
+	Router     *gin.Engine
+
+	// Keys by name
+	signers map[string]bundlr.Signer
+
+	// Optional bearer token
+	token string
+}
+
+func NewServer(config *config.Config) (self *Server) {
+	self = new(Server)
+
+	self.Task = task.NewTask(config, "signer-server").
+		WithSubtaskFunc(self.run).
+		WithOnBeforeStart(self.loadKeys).
+		WithOnStop(self.stop)
+
+	gin.SetMode(gin.ReleaseMode)
+	self.Router = gin.New()
+	self.Router.Use(
+		gin.RecoveryWithWriter(self.Log.WriterLevel(logrus.ErrorLevel)),
+		middleware.HandleRequestId(),
+		middleware.HandleLogging(config),
+		middleware.HandleTimeout(config.Signer.RequestTimeout),
+	)
+	self.httpServer = &http.Server{
+		Handler: self.Router,
+	}
+
+	return
+}
+
+func (self *Server) loadKeys() (err error) {
+	self.signers, err = parseKeys(self.Config.Signer.Keys)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to load keys")
+		return
+	}
+
+	if self.Config.Signer.AuthToken != "" {
+		self.token, err = bundlr.ResolveKey(self.Config.Signer.AuthToken)
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to load auth token")
+			return
+		}
+	}
+
+	for name, signer := range self.signers {
+		self.Log.WithField("name", name).WithField("type", signer.GetType()).Info("Loaded key")
+	}
+	return
+}
+
+func (self *Server) listen() (listener net.Listener, err error) {
+	address := self.Config.Signer.ListenAddress
+	if !strings.HasPrefix(address, unixPrefix) {
+		return net.Listen("tcp", address)
+	}
+
+	// Remove the socket left after an unclean shutdown
+	path := strings.TrimPrefix(address, unixPrefix)
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	listener, err = net.Listen("unix", path)
+	if err != nil {
+		return
+	}
+
+	// Only the owner of the process can connect
+	err = os.Chmod(path, 0600)
+	return
+}
+
+func (self *Server) run() (err error) {
+	v1 := self.Router.Group("v1")
+	{
+		v1.GET("health", self.onHealth)
+
+		keys := v1.Group("keys", self.authorize)
+		{
+			keys.GET(":key", self.onGetKey)
+			keys.POST(":key/sign", self.onSign)
+		}
+	}
+
+	listener, err := self.listen()
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to listen")
+		return
+	}
+
+	err = self.httpServer.Serve(listener)
+	if err != nil && err != http.ErrServerClosed {
+		self.Log.WithError(err).Error("Failed to start signer server")
+		return
+	}
+	return nil
+}
+
+func (self *Server) authorize(c *gin.Context) {
+	if self.token == "" {
+		return
+	}
+
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(self.token)) != 1 {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+}
+
+func (self *Server) getSigner(c *gin.Context) (signer bundlr.Signer, ok bool) {
+	signer, ok = self.signers[c.Param("key")]
+	if !ok {
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+	return
+}
+
+func (self *Server) onHealth(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
+
+func (self *Server) onGetKey(c *gin.Context) {
+	signer, ok := self.getSigner(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SignerKey{
+		SignatureType: int(signer.GetType()),
+		Owner:         base64.RawURLEncoding.EncodeToString(signer.GetOwner()),
+	})
+}
+
+func (self *Server) onSign(c *gin.Context) {
+	signer, ok := self.getSigner(c)
+	if !ok {
+		return
+	}
+
+	var request bundlr.RemoteSignRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(request.Data)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	signature, err := signer.Sign(data)
+	if err != nil {
+		self.Log.WithError(err).WithField("key", c.Param("key")).Error("Failed to sign")
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.Sign{
+		Signature: base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+func (self *Server) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), self.Config.StopTimeout)
+	defer cancel()
+
+	err := self.httpServer.Shutdown(ctx)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to gracefully shutdown signer server")
+		return
+	}
+}
@@ -0,0 +1,192 @@
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/bundle"
+	"github.com/warp-contracts/syncer/src/check"
+	"github.com/warp-contracts/syncer/src/contract"
+	"github.com/warp-contracts/syncer/src/evolve"
+	"github.com/warp-contracts/syncer/src/forward"
+	"github.com/warp-contracts/syncer/src/gateway"
+	"github.com/warp-contracts/syncer/src/relay"
+	"github.com/warp-contracts/syncer/src/send"
+	"github.com/warp-contracts/syncer/src/sync"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	monitor_supervisor "github.com/warp-contracts/syncer/src/utils/monitoring/supervisor"
+	"github.com/warp-contracts/syncer/src/utils/peer_monitor"
+	"github.com/warp-contracts/syncer/src/utils/shared"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+type Controller struct {
+	*task.Task
+}
+
+// Constructs the mode's controller using the shared resources
+type constructor func(config *config.Config, resources *shared.Resources) (*task.Task, error)
+
+var constructors = map[string]constructor{
+	"sync": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := sync.NewController(config, resources, 0, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"contract": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := contract.NewController(config, resources, 0, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"forward": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := forward.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"bundle": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := bundle.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"check": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := check.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"relay": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := relay.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"evolve": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := evolve.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"send": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := send.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+	"gateway": func(config *config.Config, resources *shared.Resources) (*task.Task, error) {
+		c, err := gateway.NewController(config, resources)
+		if err != nil {
+			return nil, err
+		}
+		return c.Task, nil
+	},
+}
+
+// Modes that talk to Arweave nodes
+var arweaveModes = []string{"sync", "contract", "relay", "bundle", "check", "evolve", "send"}
+
+// Modes that keep the list of Arweave peers up to date
+var peerModes = []string{"sync", "contract", "relay"}
+
+func GetModes() (out []string) {
+	for mode := range constructors {
+		out = append(out, mode)
+	}
+	slices.Sort(out)
+	return
+}
+
+// Runs several modes in one process.
+// Modes share the DB connection pool, Arweave client with its peer monitor and the monitoring server.
+// Metrics of each mode are prefixed with the mode's name
+func NewController(config *config.Config, modes []string) (self *Controller, err error) {
+	self = new(Controller)
+	self.Task = task.NewTask(config, "supervisor")
+
+	if len(modes) == 0 {
+		err = fmt.Errorf("no modes to run, expected some of: %s", strings.Join(GetModes(), ", "))
+		return
+	}
+	for i, mode := range modes {
+		if _, ok := constructors[mode]; !ok {
+			err = fmt.Errorf("unknown mode %s, expected one of: %s", mode, strings.Join(GetModes(), ", "))
+			return
+		}
+		if slices.Contains(modes[:i], mode) {
+			err = fmt.Errorf("mode %s given more than once", mode)
+			return
+		}
+	}
+
+	resources := new(shared.Resources)
+
+	// SQL database
+	resources.DB, err = model.NewConnection(self.Ctx, config, "supervisor")
+	if err != nil {
+		return
+	}
+
+	// Monitoring of all modes
+	resources.Monitor = monitor_supervisor.NewMonitor()
+	resources.Server = monitoring.NewServer(config).
+		WithMonitor(resources.Monitor)
+
+	// Arweave client, peers are updated once for all modes
+	if containsAny(modes, arweaveModes) {
+		resources.ArweaveClient = arweave.NewClient(self.Ctx, config)
+	}
+
+	if containsAny(modes, peerModes) {
+		resources.PeerMonitor = peer_monitor.NewPeerMonitor(config).
+			WithClient(resources.ArweaveClient).
+			WithMonitor(resources.Monitor)
+	}
+
+	// Controllers of modes
+	controllers := make([]*task.Task, 0, len(modes))
+	for _, mode := range modes {
+		var controller *task.Task
+		controller, err = constructors[mode](config.WithMode(mode), resources)
+		if err != nil {
+			err = fmt.Errorf("failed to create %s: %w", mode, err)
+			return
+		}
+		controllers = append(controllers, controller)
+	}
+
+	// Setup everything, will start upon calling Controller.Start()
+	self.Task.
+		WithSubtask(resources.Monitor.Task).
+		WithSubtask(resources.Server.Task).
+		WithSubtaskSlice(controllers)
+
+	if resources.PeerMonitor != nil {
+		self.Task.WithSubtask(resources.PeerMonitor.Task)
+	}
+
+	return
+}
+
+func containsAny(modes, expected []string) bool {
+	for _, mode := range modes {
+		if slices.Contains(expected, mode) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,15 +1,15 @@
 package sync
 
 import (
-	"time"
-
-	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/deadletter"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
+	"github.com/warp-contracts/syncer/src/utils/leader"
 	"github.com/warp-contracts/syncer/src/utils/listener"
 	"github.com/warp-contracts/syncer/src/utils/model"
-	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	monitor_syncer "github.com/warp-contracts/syncer/src/utils/monitoring/syncer"
 	"github.com/warp-contracts/syncer/src/utils/peer_monitor"
+	"github.com/warp-contracts/syncer/src/utils/shared"
 	"github.com/warp-contracts/syncer/src/utils/task"
 )
 
@@ -19,7 +19,7 @@ type Controller struct {
 
 // Main class that orchestrates main syncer functionalities
 // Setups listening and storing interactions
-func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint64, replaceExisting bool) (self *Controller, err error) {
+func NewController(config *config.Config, resources *shared.Resources, startBlockHeight, stopBlockHeight uint64, replaceExisting bool) (self *Controller, err error) {
 	self = new(Controller)
 
 	self.Task = task.NewTask(config, "controller")
@@ -27,16 +27,16 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 	monitor := monitor_syncer.NewMonitor().
 		WithMaxHistorySize(30)
 
-	server := monitoring.NewServer(config).
-		WithMonitor(monitor)
+	server := resources.ServeMonitor(config, "sync", monitor)
 
-	watched := func() *task.Task {
-		db, err := model.NewConnection(self.Ctx, self.Config, "syncer")
-		if err != nil {
-			panic(err)
-		}
+	// Connection pool survives restarts of the watched task
+	db, err := resources.GetDB(self.Ctx, config, "syncer")
+	if err != nil {
+		return
+	}
 
-		client := arweave.NewClient(self.Ctx, config)
+	watched := func() *task.Task {
+		client := resources.GetArweaveClient(self.Ctx, config)
 
 		peerMonitor := peer_monitor.NewPeerMonitor(config).
 			WithClient(client).
@@ -80,31 +80,45 @@ func NewController(config *config.Config, startBlockHeight, stopBlockHeight uint
 			WithInputChannel(parser.Output).
 			WithMonitor(monitor).
 			WithReplaceExistingData(replaceExisting).
+			WithErrorSink(deadletter.NewSink(config, db, "syncer")).
+			WithDB(db)
+
+		// Saves sampled latencies for the slo command
+		recorder := freshness.NewRecorder(config).
 			WithDB(db)
 
 		return task.NewTask(config, "watched").
-			WithSubtask(peerMonitor.Task).
+			WithConditionalSubtask(!resources.IsPeerMonitorShared(), peerMonitor.Task).
 			WithSubtask(networkMonitor.Task).
 			WithSubtask(blockDownloader.Task).
 			WithSubtask(transactionDownloader.Task).
 			WithSubtask(parser.Task).
-			WithSubtask(store.Task)
+			WithSubtask(store.Task).
+			WithConditionalSubtask(config.Freshness.Enabled, recorder.Task)
 	}
 
 	watchdog := task.NewWatchdog(config).
 		WithTask(watched).
-		WithIsOK(30*time.Second, func() bool {
+		WithRunReport(monitor.GetReport().Run).
+		WithIsOK(config.Watchdog.CheckInterval, func() bool {
 			isOK := monitor.IsOK()
 			if !isOK {
 				monitor.Clear()
-				monitor.GetReport().Run.Errors.NumWatchdogRestarts.Inc()
 			}
 			return isOK
 		})
 
+	if config.LeaderElection.Enabled {
+		// Only the leader runs the pipeline, standby waits for the lease
+		elector := leader.WatchStandby(config, "syncer", db, monitor, watchdog)
+		self.Task.
+			WithSubtask(elector.Task).
+			WithOnAfterStop(elector.Release)
+	}
+
 	self.Task = self.Task.
 		WithSubtask(monitor.Task).
-		WithSubtask(server.Task).
+		WithConditionalSubtask(server != nil, server).
 		WithConditionalSubtask(config.Syncer.Enabled, watchdog.Task)
 
 	return
@@ -8,7 +8,11 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 	"github.com/warp-contracts/syncer/src/utils/warp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Gets contract's source and init state
@@ -57,22 +61,29 @@ func (self *Parser) WithInputChannel(v chan *listener.Payload) *Parser {
 func (self *Parser) run() error {
 	// Each payload has a slice of transactions
 	for payload := range self.input {
+		_, span := payload.Trace.StartSpan(self.Ctx, "parser",
+			trace.WithAttributes(attribute.Int64("height", payload.BlockHeight)))
 
 		interactions, err := self.parseAll(payload)
+		span.SetAttributes(attribute.Int("interactions", len(interactions)))
+		tracing.End(span, err)
 		if err != nil {
 			return err
 		}
 
 		self.Log.WithField("height", payload.BlockHeight).WithField("len", len(interactions)).Debug("Parsed interactions")
-		select {
-		case <-self.Ctx.Done():
-			return nil
-		case self.Output <- &Payload{
+		out := &Payload{
 			BlockHeight:    uint64(payload.BlockHeight),
 			BlockHash:      payload.BlockHash,
 			BlockTimestamp: uint64(payload.BlockTimestamp),
 			Interactions:   interactions,
-		}:
+		}
+		out.Trace.InheritFrom(&payload.Trace)
+
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case self.Output <- out:
 		}
 	}
 
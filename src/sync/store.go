@@ -1,14 +1,20 @@
 package sync
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -19,14 +25,18 @@ import (
 type Store struct {
 	*task.Processor[*Payload, *model.Interaction]
 
-	DB      *gorm.DB
-	monitor monitoring.Monitor
+	DB        *gorm.DB
+	monitor   monitoring.Monitor
+	freshness *freshness.Stage
 
 	savedBlockHeight  uint64
 	finishedTimestamp uint64
 	finishedHeight    uint64
 	finishedBlockHash []byte
 
+	// Spans of payloads waiting for the flush
+	links []trace.Link
+
 	replaceExistingData bool
 }
 
@@ -37,11 +47,22 @@ func NewStore(config *config.Config) (self *Store) {
 		WithBatchSize(config.Syncer.StoreBatchSize).
 		WithOnFlush(config.Syncer.StoreMaxTimeInQueue, self.flush).
 		WithOnProcess(self.process).
-		WithBackoff(0, config.Syncer.StoreMaxBackoffInterval)
+		WithBackoff(config.Syncer.StoreMaxBackoffElapsedTime, config.Syncer.StoreMaxBackoffInterval)
+
+	// Latency from the Arweave block till saving interactions
+	self.freshness = freshness.NewStage(config, freshness.PipelineL1, freshness.StageSync)
+
+	// Batching is adjusted upon configuration file change
+	self.Processor.WithOnConfigChange(self.reload)
 
 	return
 }
 
+func (self *Store) reload(config *config.Config) {
+	self.SetBatchSize(config.Syncer.StoreBatchSize)
+	self.SetFlushInterval(config.Syncer.StoreMaxTimeInQueue)
+}
+
 func (self *Store) WithMonitor(v monitoring.Monitor) *Store {
 	self.monitor = v
 	return self
@@ -52,6 +73,11 @@ func (self *Store) WithInputChannel(v chan *Payload) *Store {
 	return self
 }
 
+func (self *Store) WithErrorSink(v task.ErrorSink) *Store {
+	self.Processor = self.Processor.WithErrorSink(v)
+	return self
+}
+
 func (self *Store) WithDB(v *gorm.DB) *Store {
 	self.DB = v
 	return self
@@ -66,6 +92,7 @@ func (self *Store) process(payload *Payload) (out []*model.Interaction, err erro
 	self.finishedTimestamp = payload.BlockTimestamp
 	self.finishedHeight = payload.BlockHeight
 	self.finishedBlockHash = payload.BlockHash
+	self.links = append(self.links, tracing.Links(&payload.Trace)...)
 	out = payload.Interactions
 	return
 }
@@ -84,6 +111,14 @@ func (self *Store) flush(data []*model.Interaction) (out []*model.Interaction, e
 	self.Log.WithField("count", len(data)).Debug("Flushing interactions")
 	defer self.Log.Trace("Flushing interactions done")
 
+	// Flush handles many blocks, their traces are linked
+	ctx, span := tracing.Start(self.Ctx, "store.flush",
+		trace.WithLinks(self.links...),
+		trace.WithAttributes(attribute.Int("interactions", len(data))))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	// Set sync timestamp
 	now := time.Now().UnixMilli()
 	for _, interaction := range data {
@@ -94,7 +129,7 @@ func (self *Store) flush(data []*model.Interaction) (out []*model.Interaction, e
 		}
 	}
 
-	err = self.DB.WithContext(self.Ctx).
+	err = self.DB.WithContext(ctx).
 		Transaction(func(tx *gorm.DB) error {
 			err = self.updateFinishedBlock(tx)
 			if err != nil {
@@ -110,8 +145,13 @@ func (self *Store) flush(data []*model.Interaction) (out []*model.Interaction, e
 	// Successfuly saved interactions
 	self.monitor.GetReport().Syncer.State.InteractionsSaved.Add(uint64(len(data)))
 
+	for _, interaction := range data {
+		self.freshness.Observe(interaction.InteractionId.Base64(), time.Unix(interaction.BlockTimestamp, 0))
+	}
+
 	// Update saved block height
 	self.savedBlockHeight = self.finishedHeight
+	self.links = nil
 
 	self.monitor.GetReport().Syncer.State.FinishedHeight.Store(int64(self.savedBlockHeight))
 
@@ -122,7 +162,7 @@ func (self *Store) flush(data []*model.Interaction) (out []*model.Interaction, e
 
 func (self *Store) updateFinishedBlock(tx *gorm.DB) (err error) {
 	var state model.State
-	err = tx.WithContext(self.Ctx).
+	err = tx.
 		Where("name = ?", model.SyncedComponentInteractions).
 		First(&state).
 		Error
@@ -134,7 +174,7 @@ func (self *Store) updateFinishedBlock(tx *gorm.DB) (err error) {
 
 	// Replace finished block info, if it's newer
 	if state.FinishedBlockHeight < self.finishedHeight {
-		err = tx.WithContext(self.Ctx).
+		err = tx.
 			Model(&model.State{
 				Name: model.SyncedComponentInteractions,
 			}).
@@ -196,7 +236,7 @@ func (self *Store) saveInteractions(tx *gorm.DB, data []*model.Interaction) (err
 		}
 	}
 
-	err = tx.WithContext(self.Ctx).
+	err = tx.
 		Table("interactions").
 		Clauses(onConflict).
 		CreateInBatches(data, self.Config.Syncer.StoreBatchSize).
@@ -209,3 +249,21 @@ func (self *Store) saveInteractions(tx *gorm.DB, data []*model.Interaction) (err
 	}
 	return nil
 }
+
+// Saves an interaction that the store failed to save, used to replay dead letters
+func ReplayInteraction(ctx context.Context, db *gorm.DB, payload []byte) (err error) {
+	var interaction model.Interaction
+	err = json.Unmarshal(payload, &interaction)
+	if err != nil {
+		return
+	}
+
+	return db.WithContext(ctx).
+		Table(model.TableInteraction).
+		Clauses(clause.OnConflict{
+			DoNothing: true,
+			Columns:   []clause.Column{{Name: "interaction_id"}},
+		}).
+		Create(&interaction).
+		Error
+}
@@ -3,6 +3,7 @@ package sync
 import (
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 type Payload struct {
@@ -10,4 +11,6 @@ type Payload struct {
 	BlockHash      arweave.Base64String
 	BlockTimestamp uint64
 	Interactions   []*model.Interaction
+
+	Trace tracing.Carrier
 }
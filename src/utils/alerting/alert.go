@@ -0,0 +1,101 @@
+package alerting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusFiring   Status = "firing"
+	StatusResolved Status = "resolved"
+)
+
+// Result of evaluating a rule against one report
+type Alert struct {
+	// Name of the rule
+	Name string `json:"name"`
+
+	// Mode whose report triggered the alert, empty if there's only one mode
+	Mode string `json:"mode,omitempty"`
+
+	Status      Status `json:"status"`
+	Severity    string `json:"severity,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Value that was compared with the threshold
+	Value     float64 `json:"value"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+
+	// Unix timestamps of the condition becoming true and false
+	StartsAt int64 `json:"starts_at"`
+	EndsAt   int64 `json:"ends_at,omitempty"`
+}
+
+// Same alert from the same mode is sent once per firing period
+func (self *Alert) key() string {
+	return self.Mode + "/" + self.Name
+}
+
+func (self *Alert) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", strings.ToUpper(string(self.Status)), self.Name)
+	if self.Mode != "" {
+		fmt.Fprintf(&b, " (%s)", self.Mode)
+	}
+	fmt.Fprintf(&b, ": %g %s %g", self.Value, self.Operator, self.Threshold)
+	if self.Description != "" {
+		fmt.Fprintf(&b, " - %s", self.Description)
+	}
+	return b.String()
+}
+
+func compare(rule *config.AlertRule, value float64) bool {
+	switch rule.Operator {
+	case ">":
+		return value > rule.Threshold
+	case ">=":
+		return value >= rule.Threshold
+	case "<":
+		return value < rule.Threshold
+	case "<=":
+		return value <= rule.Threshold
+	case "==":
+		return value == rule.Threshold
+	case "!=":
+		return value != rule.Threshold
+	}
+	return false
+}
+
+// Numeric value at the path in the report converted to a tree of maps, e.g. syncer.state.finished_height.
+// Booleans are 1 or 0
+func lookup(tree map[string]any, path string) (out float64, ok bool) {
+	var node any = tree
+	for _, key := range strings.Split(path, ".") {
+		m, isMap := node.(map[string]any)
+		if !isMap {
+			return
+		}
+		node, ok = m[key]
+		if !ok {
+			return
+		}
+	}
+
+	switch v := node.(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,218 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring/report"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Periodically evaluates alert rules against monitor reports.
+// Alert fires after its condition holds for the rule's For duration and gets resolved once the condition stops holding.
+// Sinks are notified only about these transitions (and repeated firing, if configured)
+type Alerter struct {
+	*task.Task
+
+	rules          []config.AlertRule
+	sinks          []Sink
+	repeatInterval time.Duration
+	sinkTimeout    time.Duration
+
+	mtx     sync.RWMutex
+	sources []source
+	states  map[string]*state
+}
+
+// Report of a mode
+type source struct {
+	mode   string
+	report *report.Report
+}
+
+// State of a rule evaluated against a report
+type state struct {
+	alert        Alert
+	lastNotified time.Time
+
+	// Previous value, used to compute rates
+	previousValue float64
+	previousTime  time.Time
+}
+
+func NewAlerter(config *config.Config) (self *Alerter) {
+	self = new(Alerter)
+	self.rules = config.Alerting.Rules
+	self.repeatInterval = config.Alerting.RepeatInterval
+	self.sinkTimeout = config.Alerting.SinkTimeout
+	self.states = make(map[string]*state)
+
+	for _, sinkConfig := range config.Alerting.Sinks {
+		self.sinks = append(self.sinks, NewSink(sinkConfig))
+	}
+	if len(self.sinks) == 0 {
+		self.sinks = append(self.sinks, newLogSink())
+	}
+
+	self.Task = task.NewTask(config, "alerter").
+		WithPeriodicSubtaskFunc(config.Alerting.Interval, self.evaluate)
+
+	return
+}
+
+// Rules are evaluated against the report. Mode is empty if there's only one mode running
+func (self *Alerter) WithReport(mode string, v *report.Report) *Alerter {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.sources = append(self.sources, source{mode: mode, report: v})
+	return self
+}
+
+// Pending and firing alerts
+func (self *Alerter) GetAlerts() (out []Alert) {
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+
+	out = make([]Alert, 0, len(self.states))
+	for _, state := range self.states {
+		if state.alert.Status == StatusPending || state.alert.Status == StatusFiring {
+			out = append(out, state.alert)
+		}
+	}
+	slices.SortFunc(out, func(a, b Alert) int {
+		return strings.Compare(a.key(), b.key())
+	})
+	return
+}
+
+func (self *Alerter) evaluate() error {
+	now := time.Now()
+
+	self.mtx.Lock()
+	var notifications []Alert
+	for _, source := range self.sources {
+		tree, err := toTree(source.report)
+		if err != nil {
+			self.Log.WithError(err).WithField("mode", source.mode).Error("Failed to convert report")
+			continue
+		}
+
+		for i := range self.rules {
+			alert := self.evaluateRule(&self.rules[i], source.mode, tree, now)
+			if alert != nil {
+				notifications = append(notifications, *alert)
+			}
+		}
+	}
+	self.mtx.Unlock()
+
+	for i := range notifications {
+		self.notify(&notifications[i])
+	}
+	return nil
+}
+
+// Returns the alert if sinks need to be notified
+func (self *Alerter) evaluateRule(rule *config.AlertRule, mode string, tree map[string]any, now time.Time) *Alert {
+	if rule.Mode != "" && rule.Mode != mode {
+		return nil
+	}
+
+	value, ok := lookup(tree, rule.Value)
+	if !ok {
+		// Report doesn't have this value, e.g. it's of another mode
+		return nil
+	}
+	if rule.Minus != "" {
+		minus, ok := lookup(tree, rule.Minus)
+		if !ok {
+			return nil
+		}
+		value -= minus
+	}
+
+	key := mode + "/" + rule.Name
+	s, ok := self.states[key]
+	if !ok {
+		s = &state{alert: Alert{Name: rule.Name, Mode: mode}}
+		self.states[key] = s
+	}
+
+	if rule.Rate {
+		previousValue, previousTime := s.previousValue, s.previousTime
+		s.previousValue, s.previousTime = value, now
+		if previousTime.IsZero() {
+			// Need two samples
+			return nil
+		}
+		value = (value - previousValue) / now.Sub(previousTime).Minutes()
+	}
+
+	alert := &s.alert
+	alert.Value = value
+	alert.Operator = rule.Operator
+	alert.Threshold = rule.Threshold
+	alert.Severity = rule.Severity
+	alert.Description = rule.Description
+
+	isActive := compare(rule, value)
+	switch {
+	case isActive && alert.Status != StatusPending && alert.Status != StatusFiring:
+		alert.Status = StatusPending
+		alert.StartsAt = now.Unix()
+		alert.EndsAt = 0
+		if rule.For > 0 {
+			return nil
+		}
+		fallthrough
+	case isActive && alert.Status == StatusPending:
+		if now.Sub(time.Unix(alert.StartsAt, 0)) < rule.For {
+			return nil
+		}
+		alert.Status = StatusFiring
+	case isActive && alert.Status == StatusFiring:
+		if self.repeatInterval <= 0 || now.Sub(s.lastNotified) < self.repeatInterval {
+			// Already notified
+			return nil
+		}
+	case !isActive && alert.Status == StatusFiring:
+		alert.Status = StatusResolved
+		alert.EndsAt = now.Unix()
+	case !isActive && alert.Status == StatusPending:
+		// Didn't hold long enough to fire
+		alert.Status = ""
+		return nil
+	default:
+		return nil
+	}
+
+	s.lastNotified = now
+	out := *alert
+	return &out
+}
+
+func (self *Alerter) notify(alert *Alert) {
+	for _, sink := range self.sinks {
+		ctx, cancel := context.WithTimeout(self.Ctx, self.sinkTimeout)
+		err := sink.Send(ctx, alert)
+		cancel()
+		if err != nil {
+			self.Log.WithError(err).WithField("alert", alert.Name).Error("Failed to send alert")
+		}
+	}
+}
+
+// Report as a tree of maps, with the same names as in the state endpoint
+func toTree(r *report.Report) (out map[string]any, err error) {
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(buf, &out)
+	return
+}
@@ -0,0 +1,74 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+func TestAlerterTransitions(t *testing.T) {
+	rule := &config.AlertRule{
+		Name:      "lag",
+		Value:     "network_info.state.arweave_current_height",
+		Minus:     "syncer.state.finished_height",
+		Operator:  ">",
+		Threshold: 10,
+		For:       5 * time.Minute,
+	}
+	alerter := NewAlerter(config.Default())
+
+	tree := func(network, finished float64) map[string]any {
+		return map[string]any{
+			"network_info": map[string]any{"state": map[string]any{"arweave_current_height": network}},
+			"syncer":       map[string]any{"state": map[string]any{"finished_height": finished}},
+		}
+	}
+	now := time.Now()
+
+	// Pending until the condition holds long enough
+	require.Nil(t, alerter.evaluateRule(rule, "", tree(100, 50), now))
+	require.Len(t, alerter.GetAlerts(), 1)
+	require.Equal(t, StatusPending, alerter.GetAlerts()[0].Status)
+
+	alert := alerter.evaluateRule(rule, "", tree(100, 50), now.Add(5*time.Minute))
+	require.NotNil(t, alert)
+	require.Equal(t, StatusFiring, alert.Status)
+	require.Equal(t, float64(50), alert.Value)
+
+	// Deduplicated
+	require.Nil(t, alerter.evaluateRule(rule, "", tree(100, 60), now.Add(6*time.Minute)))
+
+	alert = alerter.evaluateRule(rule, "", tree(100, 95), now.Add(7*time.Minute))
+	require.NotNil(t, alert)
+	require.Equal(t, StatusResolved, alert.Status)
+	require.Empty(t, alerter.GetAlerts())
+
+	// Reports without the value are skipped
+	require.Nil(t, alerter.evaluateRule(rule, "", map[string]any{}, now))
+}
+
+func TestAlerterRate(t *testing.T) {
+	rule := &config.AlertRule{
+		Name:      "turbo",
+		Value:     "bundler.errors.turbo_error",
+		Rate:      true,
+		Operator:  ">=",
+		Threshold: 2,
+	}
+	alerter := NewAlerter(config.Default())
+
+	tree := func(errors float64) map[string]any {
+		return map[string]any{"bundler": map[string]any{"errors": map[string]any{"turbo_error": errors}}}
+	}
+	now := time.Now()
+
+	require.Nil(t, alerter.evaluateRule(rule, "", tree(10), now))
+	require.Nil(t, alerter.evaluateRule(rule, "", tree(11), now.Add(time.Minute)))
+
+	alert := alerter.evaluateRule(rule, "", tree(15), now.Add(3*time.Minute))
+	require.NotNil(t, alert)
+	require.Equal(t, StatusFiring, alert.Status)
+	require.Equal(t, float64(2), alert.Value)
+}
@@ -0,0 +1,89 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Destination of firing and resolved alerts
+type Sink interface {
+	Send(ctx context.Context, alert *Alert) error
+}
+
+func NewSink(sinkConfig config.AlertSink) Sink {
+	switch sinkConfig.Type {
+	case "webhook":
+		return &WebhookSink{url: sinkConfig.Url, client: resty.New()}
+	case "slack":
+		return &SlackSink{url: sinkConfig.Url, client: resty.New()}
+	default:
+		return newLogSink()
+	}
+}
+
+func newLogSink() *LogSink {
+	return &LogSink{log: logger.NewSublogger("alerts")}
+}
+
+// Writes alerts to the application log
+type LogSink struct {
+	log *logrus.Entry
+}
+
+func (self *LogSink) Send(ctx context.Context, alert *Alert) error {
+	log := self.log.WithField("alert", alert.Name).
+		WithField("mode", alert.Mode).
+		WithField("severity", alert.Severity).
+		WithField("value", alert.Value).
+		WithField("threshold", alert.Threshold)
+
+	if alert.Status == StatusResolved {
+		log.Info(alert.String())
+	} else {
+		log.Warn(alert.String())
+	}
+	return nil
+}
+
+// Posts alerts as JSON
+type WebhookSink struct {
+	url    string
+	client *resty.Client
+}
+
+func (self *WebhookSink) Send(ctx context.Context, alert *Alert) error {
+	return post(ctx, self.client, self.url, alert)
+}
+
+// Posts alerts as Slack-compatible messages, works with Slack's incoming webhooks and compatible services
+type SlackSink struct {
+	url    string
+	client *resty.Client
+}
+
+func (self *SlackSink) Send(ctx context.Context, alert *Alert) error {
+	return post(ctx, self.client, self.url, map[string]string{
+		"text": alert.String(),
+	})
+}
+
+func post(ctx context.Context, client *resty.Client, url string, body any) error {
+	resp, err := client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(body).
+		Post(url)
+	if err != nil {
+		return err
+	}
+	if !resp.IsSuccess() {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+	}
+	return nil
+}
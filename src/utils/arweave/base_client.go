@@ -13,6 +13,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/build_info"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
@@ -80,7 +81,7 @@ func (self *BaseClient) Reset() {
 			SetHeader("User-Agent", "warp.cc/syncer/"+build_info.Version).
 			SetRetryCount(1).
 			SetLogger(NewLogger(true /*force all logs to trace*/)).
-			SetTransport(self.createTransport()).
+			SetTransport(tracing.NewTransport(self.createTransport())).
 			AddRetryCondition(self.onRetryCondition).
 			// AddRetryAfterErrorCondition().
 			OnBeforeRequest(self.onForcePeer).
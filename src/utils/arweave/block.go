@@ -6,6 +6,8 @@ import (
 	"crypto/sha512"
 	"fmt"
 	"reflect"
+
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 const (
@@ -67,6 +69,9 @@ type Block struct {
 	RedenominationHeight          uint64             `json:"redenomination_height"`
 	DoubleSigningProof            DoubleSigningProof `json:"double_signing_proof"`
 	PreviousCumulativeDiff        BigInt             `json:"previous_cumulative_diff"`
+
+	// Spans of the stages that handled the block, not part of the block
+	Trace tracing.Carrier `json:"-"`
 }
 
 type POA struct {
@@ -3,6 +3,7 @@ package arweave
 import (
 	"crypto/sha512"
 	"fmt"
+	"io"
 )
 
 func DeepHash(data []any) [48]byte {
@@ -11,6 +12,44 @@ func DeepHash(data []any) [48]byte {
 	return deepHashAcc(data, tagHash)
 }
 
+// Same as DeepHash, but elements may also be io.Reader.
+// Readers are consumed once and never buffered, so arbitrarily large blobs can be hashed with constant memory.
+func DeepHashStream(data []any) (out [48]byte, err error) {
+	tag := append([]byte("list"), []byte(fmt.Sprintf("%d", len(data)))...)
+	acc := sha512.Sum384(tag)
+
+	for _, d := range data {
+		var dHash [48]byte
+		if reader, ok := d.(io.Reader); ok {
+			dHash, err = deepHashReader(reader)
+			if err != nil {
+				return
+			}
+		} else {
+			dHash = deepHashChunk(d)
+		}
+
+		hashPair := append(acc[:], dHash[:]...)
+		acc = sha512.Sum384(hashPair)
+	}
+
+	return acc, nil
+}
+
+// Blob's hash doesn't depend on its length, so it can be computed first and the length tag added afterwards
+func deepHashReader(reader io.Reader) (out [48]byte, err error) {
+	blobHasher := sha512.New384()
+	n, err := io.Copy(blobHasher, reader)
+	if err != nil {
+		return
+	}
+
+	tag := append([]byte("blob"), []byte(fmt.Sprintf("%d", n))...)
+	tagHash := sha512.Sum384(tag)
+	tagged := append(tagHash[:], blobHasher.Sum(nil)...)
+	return sha512.Sum384(tagged), nil
+}
+
 func deepHashBytes(x []byte) [48]byte {
 	tag := append([]byte("blob"), []byte(fmt.Sprintf("%d", len(x)))...)
 	tagHash := sha512.Sum384(tag)
@@ -32,9 +71,14 @@ func deepHashAcc(data []interface{}, acc [48]byte) [48]byte {
 		return acc
 	}
 
-	dHash := [48]byte{}
-	d := data[0]
+	dHash := deepHashChunk(data[0])
+
+	hashPair := append(acc[:], dHash[:]...)
+	newAcc := sha512.Sum384(hashPair)
+	return deepHashAcc(data[1:], newAcc)
+}
 
+func deepHashChunk(d any) (dHash [48]byte) {
 	switch x := d.(type) {
 	case []byte:
 		dHash = deepHashBytes(x)
@@ -57,8 +101,5 @@ func deepHashAcc(data []interface{}, acc [48]byte) [48]byte {
 	default:
 		panic("unsupported deep hash type")
 	}
-
-	hashPair := append(acc[:], dHash[:]...)
-	newAcc := sha512.Sum384(hashPair)
-	return deepHashAcc(data[1:], newAcc)
+	return
 }
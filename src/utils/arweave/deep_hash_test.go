@@ -0,0 +1,25 @@
+package arweave
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepHashStream(t *testing.T) {
+	blob := []byte(strings.Repeat("warp", 100000))
+
+	expected := DeepHash([]any{"dataitem", "1", []byte{1, 0}, Base64String{}, blob})
+
+	actual, err := DeepHashStream([]any{"dataitem", "1", []byte{1, 0}, Base64String{}, bytes.NewReader(blob)})
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// Empty stream
+	expected = DeepHash([]any{[]byte{}})
+	actual, err = DeepHashStream([]any{bytes.NewReader(nil)})
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
@@ -0,0 +1,121 @@
+package balance_monitor
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/turbo"
+
+	"github.com/dvsekhvalnov/jose2go/base64url"
+)
+
+// Periodically checks the balance of the bundling wallet in Irys and Turbo.
+// Low balance is reported to the monitor, which marks the service as unhealthy.
+type BalanceMonitor struct {
+	*task.Task
+
+	irysClient  *bundlr.Client
+	turboClient *turbo.Client
+	monitor     monitoring.Monitor
+
+	// Arweave address of the wallet used for bundling
+	address string
+}
+
+func NewBalanceMonitor(config *config.Config) (self *BalanceMonitor) {
+	self = new(BalanceMonitor)
+
+	self.Task = task.NewTask(config, "balance-monitor").
+		WithPeriodicSubtaskFunc(config.Bundlr.BalanceMonitorInterval, self.runPeriodically).
+		WithOnBeforeStart(self.setAddress)
+
+	return
+}
+
+func (self *BalanceMonitor) WithIrysClient(client *bundlr.Client) *BalanceMonitor {
+	self.irysClient = client
+	return self
+}
+
+func (self *BalanceMonitor) WithTurboClient(client *turbo.Client) *BalanceMonitor {
+	self.turboClient = client
+	return self
+}
+
+func (self *BalanceMonitor) WithMonitor(monitor monitoring.Monitor) *BalanceMonitor {
+	self.monitor = monitor
+	return self
+}
+
+func (self *BalanceMonitor) setAddress() (err error) {
+	signer, err := bundlr.NewSigner(self.Config.Bundlr.Wallet, bundlr.SignatureTypeArweave)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to parse bundling wallet")
+		return
+	}
+
+	// Address of a wallet is a Base64URL encoded SHA-256 hash of the owner
+	hash := sha256.Sum256(signer.GetOwner())
+	self.address = base64url.Encode(hash[:])
+
+	self.Log.WithField("address", self.address).Info("Monitoring balance of the bundling wallet")
+	return
+}
+
+func (self *BalanceMonitor) runPeriodically() error {
+	// Irys is used only if it gets some traffic
+	if self.irysClient != nil && self.Config.Bundlr.IrysSendProbability > 0 {
+		self.checkIrys()
+	}
+
+	// The rest of the traffic goes to Turbo
+	if self.turboClient != nil && self.Config.Bundlr.IrysSendProbability < 100 {
+		self.checkTurbo()
+	}
+
+	return nil
+}
+
+func (self *BalanceMonitor) checkIrys() {
+	balance, err := self.irysClient.GetBalance(self.Ctx, self.address)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to get Irys balance")
+		self.monitor.GetReport().Balance.Errors.IrysBalanceErrors.Inc()
+		return
+	}
+
+	state := &self.monitor.GetReport().Balance.State
+	state.IrysBalance.Store(balance)
+	state.IrysLastCheckTimestamp.Store(time.Now().Unix())
+	state.IsIrysBalanceLow.Store(self.isLow(balance, self.Config.Bundlr.IrysMinBalance, "irys"))
+}
+
+func (self *BalanceMonitor) checkTurbo() {
+	balance, err := self.turboClient.GetBalance(self.Ctx, self.address)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to get Turbo balance")
+		self.monitor.GetReport().Balance.Errors.TurboBalanceErrors.Inc()
+		return
+	}
+
+	state := &self.monitor.GetReport().Balance.State
+	state.TurboBalance.Store(balance)
+	state.TurboLastCheckTimestamp.Store(time.Now().Unix())
+	state.IsTurboBalanceLow.Store(self.isLow(balance, self.Config.Bundlr.TurboMinBalance, "turbo"))
+}
+
+func (self *BalanceMonitor) isLow(balance, threshold int64, service string) bool {
+	if threshold <= 0 || balance >= threshold {
+		return false
+	}
+
+	self.Log.WithField("service", service).
+		WithField("balance", balance).
+		WithField("threshold", threshold).
+		Warn("Bundling wallet balance is below the threshold")
+	return true
+}
@@ -0,0 +1,81 @@
+package bundlr
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/cosmos/btcutil/base58"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// Derives the wallet address from the owner field of a data item.
+// Address format depends on the chain the signature type comes from.
+//
+// Arweave and Ethereum items always got a Base64URL encoded SHA-256 hash of the owner, regardless of the chain.
+// Contracts' state keys on this value, so it has to stay this way.
+func GetAddress(signatureType SignatureType, owner []byte) (address string, err error) {
+	switch signatureType {
+	case SignatureTypeArweave, SignatureTypeEthereum:
+		// For Arweave it's the hash of the n value from the JWK
+		// https://docs.arweave.org/developers/server/http-api#addressing
+		hash := sha256.Sum256(owner)
+		address = base64.RawURLEncoding.EncodeToString(hash[:])
+		return
+	}
+
+	signer, err := GetSigner(signatureType, owner)
+	if err != nil {
+		return
+	}
+	if len(owner) != signer.GetOwnerLength() {
+		err = ErrNotEnoughBytesForOwner
+		return
+	}
+
+	switch signatureType {
+	case SignatureTypeEd25519, SignatureTypeSolana:
+		// Base58 encoded public key
+		address = base58.Encode(owner)
+
+	case SignatureTypeTypedEthereum:
+		// Owner already is an address
+		if !common.IsHexAddress(string(owner)) {
+			err = ErrTypedEthereumInvalidOwner
+			return
+		}
+		address = common.HexToAddress(string(owner)).Hex()
+
+	case SignatureTypeInjectedAptos:
+		// SHA3-256 of the public key followed by the single key scheme id
+		address = aptosAddress(owner, 0x00)
+
+	case SignatureTypeMultiAptos:
+		// SHA3-256 of the public keys and threshold followed by the multi key scheme id
+		publicKeys := signer.(*MultiAptosSigner).GetPublicKeys()
+		if len(publicKeys) == 0 {
+			err = ErrMultiAptosInvalidOwner
+			return
+		}
+
+		buf := make([]byte, 0, len(publicKeys)*ed25519.PublicKeySize+1)
+		for _, publicKey := range publicKeys {
+			buf = append(buf, publicKey...)
+		}
+		buf = append(buf, owner[multiAptosOwnerLength-1])
+		address = aptosAddress(buf, 0x01)
+
+	default:
+		err = ErrUnsupportedSignatureType
+	}
+	return
+}
+
+func aptosAddress(publicKey []byte, scheme byte) string {
+	hash := sha3.New256()
+	hash.Write(publicKey)
+	hash.Write([]byte{scheme})
+	return "0x" + hex.EncodeToString(hash.Sum(nil))
+}
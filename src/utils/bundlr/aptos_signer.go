@@ -0,0 +1,62 @@
+package bundlr
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+)
+
+// Signs data items the way Aptos wallets do (signMessage with a fixed nonce).
+// Key is a regular ED25519 key, but the signed message is wrapped in a text envelope.
+type AptosSigner struct {
+	PrivateKey ed25519.PrivateKey
+	Owner      []byte
+}
+
+// Accepts a 32 byte seed or a 64 byte private key
+func NewAptosSigner(privateKey []byte) (self *AptosSigner, err error) {
+	self = new(AptosSigner)
+
+	switch len(privateKey) {
+	case ed25519.SeedSize:
+		self.PrivateKey = ed25519.NewKeyFromSeed(privateKey)
+	case ed25519.PrivateKeySize:
+		self.PrivateKey = ed25519.PrivateKey(privateKey)
+	default:
+		err = ErrEd25519InvalidPrivateKey
+		return
+	}
+
+	self.Owner = []byte(self.PrivateKey.Public().(ed25519.PublicKey))
+
+	return
+}
+
+// Message that is actually signed by the wallet
+// https://github.com/Irys-xyz/arbundles/blob/master/src/signing/chains/InjectedAptosSigner.ts
+func AptosMessage(data []byte) []byte {
+	return []byte("APTOS\nmessage: " + hex.EncodeToString(data) + "\nnonce: bundlr")
+}
+
+func (self *AptosSigner) Sign(data []byte) (signature []byte, err error) {
+	return ed25519.Sign(self.PrivateKey, AptosMessage(data)), nil
+}
+
+func (self *AptosSigner) Verify(data []byte, signature []byte) (err error) {
+	return ed25519Verify(self.Owner, AptosMessage(data), signature)
+}
+
+func (self *AptosSigner) GetOwner() []byte {
+	return self.Owner
+}
+
+func (self *AptosSigner) GetType() SignatureType {
+	return SignatureTypeInjectedAptos
+}
+
+func (self *AptosSigner) GetSignatureLength() int {
+	return ed25519.SignatureSize
+}
+
+func (self *AptosSigner) GetOwnerLength() int {
+	return ed25519.PublicKeySize
+}
@@ -0,0 +1,79 @@
+package bundlr
+
+import (
+	"encoding/hex"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"testing"
+)
+
+// Signature and data item produced by testdata/generate.js
+const (
+	APTOS_SIGNATURE    = `56b3c389556e95ce024f9ffd807e42b5e0009bc4fb2dd43160d399d1d68c39d652445a3070b77760340e020ad4caadbb484065093f6b433da76f9142c388d30b`
+	APTOS_ADDRESS      = `0x63c5215e87770d17b9f4cd47c777e322f4eb152cfd2054c1080fd9d57c48913b`
+	APTOS_DATA_ITEM    = `aptos.bin`
+	APTOS_DATA_ITEM_ID = `gJ4jSpuO2nz89BSPjrxI7rxDPyR5o4JdUF53_sOrKos`
+)
+
+func TestAptosSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(AptosSignerTestSuite))
+}
+
+type AptosSignerTestSuite struct {
+	suite.Suite
+	signer *AptosSigner
+}
+
+func (s *AptosSignerTestSuite) SetupSuite() {
+	seed, err := hex.DecodeString(ED25519_SEED)
+	require.Nil(s.T(), err)
+
+	s.signer, err = NewAptosSigner(seed)
+	require.Nil(s.T(), err)
+}
+
+func (s *AptosSignerTestSuite) TestMessage() {
+	require.Equal(s.T(), "APTOS\nmessage: 0102ff\nnonce: bundlr", string(AptosMessage([]byte{0x01, 0x02, 0xff})))
+}
+
+func (s *AptosSignerTestSuite) TestVector() {
+	data := []byte("to be signed")
+
+	signature, err := s.signer.Sign(data)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), APTOS_SIGNATURE, hex.EncodeToString(signature))
+	require.Equal(s.T(), len(signature), s.signer.GetSignatureLength())
+
+	verifier, err := GetSigner(SignatureTypeInjectedAptos, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), verifier.Verify(data, signature))
+
+	// Plain ED25519 signature of the data isn't accepted
+	ed25519Signer, err := NewEd25519Signer(s.signer.PrivateKey)
+	require.Nil(s.T(), err)
+	plain, err := ed25519Signer.Sign(data)
+	require.Nil(s.T(), err)
+	require.ErrorIs(s.T(), verifier.Verify(data, plain), ErrEd25519SignatureMismatch)
+}
+
+func (s *AptosSignerTestSuite) TestDataItem() {
+	item := readTestDataItem(s.T(), APTOS_DATA_ITEM)
+	require.Equal(s.T(), SignatureTypeInjectedAptos, item.SignatureType)
+	require.Equal(s.T(), APTOS_DATA_ITEM_ID, item.Id.Base64())
+	require.Equal(s.T(), s.signer.GetOwner(), []byte(item.Owner))
+	require.Len(s.T(), item.Target, 32)
+	require.Len(s.T(), item.Anchor, 32)
+	require.Nil(s.T(), item.VerifySignature())
+
+	// Data changed after signing
+	item.Data = []byte("other")
+	require.ErrorIs(s.T(), item.VerifySignature(), ErrEd25519SignatureMismatch)
+}
+
+func (s *AptosSignerTestSuite) TestAddress() {
+	address, err := GetAddress(SignatureTypeInjectedAptos, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), APTOS_ADDRESS, address)
+}
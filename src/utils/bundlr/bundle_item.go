@@ -21,6 +21,9 @@ type BundleItem struct {
 	Data          arweave.Base64String `json:"data"`
 	Id            arweave.Base64String `json:"id"`
 
+	// Optional, used instead of Data. Data is streamed from it when signing and encoding, never buffered in memory
+	DataSource DataSource `json:"-"`
+
 	// Not in the standard, used internally
 	tagsBytes []byte `json:"-"`
 }
@@ -91,12 +94,21 @@ func (self *BundleItem) NestBundles(dataItems []*BundleItem) (err error) {
 }
 
 func (self *BundleItem) Size() (out int) {
+	out = self.HeaderSize()
+	if out <= 0 {
+		return
+	}
+	return out + self.dataSize()
+}
+
+// Size of everything that precedes the data
+func (self *BundleItem) HeaderSize() (out int) {
 	signer, err := GetSigner(self.SignatureType, nil)
 	if err != nil {
 		return
 	}
 
-	out = 2 /*signature type */ + signer.GetSignatureLength() + signer.GetOwnerLength() + 1 /*target flag*/ + 1 /*anchor flag*/ + len(self.Data) + 8 /*len tags*/ + 8 /*len tags bytes*/
+	out = 2 /*signature type */ + signer.GetSignatureLength() + signer.GetOwnerLength() + 1 /*target flag*/ + 1 /*anchor flag*/ + 8 /*len tags*/ + 8 /*len tags bytes*/
 	if len(self.Target) > 0 {
 		out += len(self.Target)
 	}
@@ -114,6 +126,13 @@ func (self *BundleItem) Size() (out int) {
 	return
 }
 
+func (self *BundleItem) dataSize() int {
+	if self.DataSource != nil {
+		return int(self.DataSource.Size())
+	}
+	return len(self.Data)
+}
+
 func (self *BundleItem) String() string {
 	buf, err := json.MarshalIndent(self, "", "  ")
 	if err != nil {
@@ -159,8 +178,8 @@ func (self *BundleItem) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, aux)
 }
 
-func (self *BundleItem) sign(signer Signer) (id, signature []byte, err error) {
-	// Tags
+// Deep hash of the item, data is streamed from the reader
+func (self *BundleItem) deepHash(data io.Reader) (out [48]byte, err error) {
 	err = self.ensureTagsSerialized()
 	if err != nil {
 		return
@@ -174,10 +193,30 @@ func (self *BundleItem) sign(signer Signer) (id, signature []byte, err error) {
 		self.Target,
 		self.Anchor,
 		self.tagsBytes,
-		self.Data,
+		data,
 	}
 
-	deepHash := arweave.DeepHash(values)
+	return arweave.DeepHashStream(values)
+}
+
+func (self *BundleItem) openData() (io.ReadCloser, error) {
+	if self.DataSource != nil {
+		return self.DataSource.Open()
+	}
+	return io.NopCloser(bytes.NewReader(self.Data)), nil
+}
+
+func (self *BundleItem) sign(signer Signer) (id, signature []byte, err error) {
+	data, err := self.openData()
+	if err != nil {
+		return
+	}
+	defer data.Close()
+
+	deepHash, err := self.deepHash(data)
+	if err != nil {
+		return
+	}
 
 	// Compute the signature
 	signature, err = signer.Sign(deepHash[:])
@@ -201,6 +240,24 @@ func (self *BundleItem) Reader() (out *bytes.Buffer, err error) {
 	return
 }
 
+// Encoded bundle item, data is streamed and never copied into one buffer.
+// Each call returns a new reader, positioned at the beginning of the item.
+func (self *BundleItem) OpenReader() (out io.ReadCloser, err error) {
+	var header bytes.Buffer
+	header.Grow(self.HeaderSize())
+	err = self.EncodeHeader(&header)
+	if err != nil {
+		return
+	}
+
+	data, err := self.openData()
+	if err != nil {
+		return
+	}
+
+	return newMultiReadCloser(io.NopCloser(&header), data), nil
+}
+
 func (self *BundleItem) Sign(signer Signer) (err error) {
 	if signer == nil {
 		err = ErrSignerNotSpecified
@@ -223,6 +280,23 @@ func (self *BundleItem) IsSigned() bool {
 }
 
 func (self *BundleItem) Encode(out io.Writer) (err error) {
+	err = self.EncodeHeader(out)
+	if err != nil {
+		return
+	}
+
+	data, err := self.openData()
+	if err != nil {
+		return
+	}
+	defer data.Close()
+
+	_, err = io.Copy(out, data)
+	return
+}
+
+// Writes everything except the data
+func (self *BundleItem) EncodeHeader(out io.Writer) (err error) {
 	if !self.IsSigned() {
 		err = ErrNotSigned
 		return
@@ -289,10 +363,6 @@ func (self *BundleItem) Encode(out io.Writer) (err error) {
 	if err != nil {
 		return
 	}
-	_, err = out.Write(self.Data)
-	if err != nil {
-		return
-	}
 
 	return
 }
@@ -304,6 +374,25 @@ func (self *BundleItem) Unmarshal(buf []byte) (err error) {
 
 // Reverse operation of Reader
 func (self *BundleItem) UnmarshalFromReader(reader io.Reader) (err error) {
+	err = self.DecodeHeader(reader)
+	if err != nil {
+		return
+	}
+
+	// The rest is just data
+	var data bytes.Buffer
+	_, err = data.ReadFrom(reader)
+	if err != nil {
+		return
+	}
+	self.Data = data.Bytes()
+
+	return
+}
+
+// Reverse operation of EncodeHeader. Reader is left positioned at the beginning of the data,
+// so the caller decides whether to buffer it, stream it elsewhere or verify it with VerifySignatureStream.
+func (self *BundleItem) DecodeHeader(reader io.Reader) (err error) {
 	// Signature type
 	signatureType := make([]byte, 2)
 	n, err := io.ReadFull(reader, signatureType)
@@ -441,14 +530,6 @@ func (self *BundleItem) UnmarshalFromReader(reader io.Reader) (err error) {
 		}
 	}
 
-	// The rest is just data
-	var data bytes.Buffer
-	_, err = data.ReadFrom(reader)
-	if err != nil {
-		return
-	}
-	self.Data = data.Bytes()
-
 	// Id is calculated from the signature
 	idArray := sha256.Sum256(self.Signature)
 	self.Id = idArray[:]
@@ -509,23 +590,21 @@ func (self *BundleItem) Verify() (err error) {
 }
 
 func (self *BundleItem) VerifySignature() (err error) {
-	err = self.ensureTagsSerialized()
+	data, err := self.openData()
 	if err != nil {
 		return
 	}
+	defer data.Close()
 
-	values := []any{
-		"dataitem",
-		"1",
-		self.SignatureType.Bytes(),
-		self.Owner,
-		self.Target,
-		self.Anchor,
-		self.tagsBytes,
-		self.Data,
-	}
+	return self.VerifySignatureStream(data)
+}
 
-	deepHash := arweave.DeepHash(values)
+// Verifies the signature with data read from the stream, e.g. the rest of the reader passed to DecodeHeader
+func (self *BundleItem) VerifySignatureStream(data io.Reader) (err error) {
+	deepHash, err := self.deepHash(data)
+	if err != nil {
+		return
+	}
 
 	signer, err := GetSigner(self.SignatureType, self.Owner)
 	if err != nil {
@@ -2,6 +2,10 @@ package bundlr
 
 import (
 	"bytes"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -20,6 +24,19 @@ type BundleItemTestSuite struct {
 	signer *ArweaveSigner
 }
 
+// Data item signed outside of this package, see testdata/generate.js
+func readTestDataItem(t *testing.T, name string) (item *BundleItem) {
+	buf, err := os.ReadFile(filepath.Join("testdata", name))
+	require.Nil(t, err)
+
+	item = new(BundleItem)
+	require.Nil(t, item.Unmarshal(buf))
+	require.Nil(t, item.Verify())
+	require.Equal(t, Tags{{Name: "App-Name", Value: "SmartWeaveAction"}, {Name: "Input", Value: `{"function":"transfer"}`}}, item.Tags)
+	require.Equal(t, "to be signed", string(item.Data))
+	return
+}
+
 func (s *BundleItemTestSuite) SetupSuite() {
 	var err error
 	s.signer, err = NewArweaveSigner(EMPTY_ARWEAVE_WALLET)
@@ -153,3 +170,125 @@ func (s *BundleItemTestSuite) TestMarshalUnmarshalJSON() {
 	require.Equal(s.T(), item.Size(), parsed.Size())
 	require.Equal(s.T(), item.Signature, parsed.Signature)
 }
+
+func (s *BundleItemTestSuite) TestSignatureTypes() {
+	seed, err := hex.DecodeString(ED25519_SEED)
+	require.Nil(s.T(), err)
+
+	ed25519Signer, err := NewEd25519Signer(seed)
+	require.Nil(s.T(), err)
+	solanaSigner, err := NewSolanaSigner(SOLANA_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+	ethereumSigner, err := NewEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+	aptosSigner, err := NewAptosSigner(seed)
+	require.Nil(s.T(), err)
+	multiAptosSigner, err := NewMultiAptosSigner(1, seed)
+	require.Nil(s.T(), err)
+	typedEthereumSigner, err := NewTypedEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+
+	for _, signer := range []Signer{s.signer, ed25519Signer, ethereumSigner, solanaSigner, aptosSigner, multiAptosSigner, typedEthereumSigner} {
+		item := BundleItem{
+			Target: arweave.Base64String(tool.RandomString(32)),
+			Anchor: arweave.Base64String(tool.RandomString(32)),
+			Tags:   Tags{Tag{Name: "1", Value: "2"}, Tag{Name: "3", Value: "4"}},
+			Data:   arweave.Base64String(tool.RandomString(100)),
+		}
+
+		err = item.Sign(signer)
+		require.Nil(s.T(), err, signer.GetType())
+
+		buf, err := item.Marshal()
+		require.Nil(s.T(), err, signer.GetType())
+		require.Equal(s.T(), item.Size(), len(buf), signer.GetType())
+
+		parsed := BundleItem{}
+		err = parsed.Unmarshal(buf)
+		require.Nil(s.T(), err, signer.GetType())
+		require.Equal(s.T(), signer.GetType(), parsed.SignatureType)
+		require.Nil(s.T(), parsed.Verify(), signer.GetType())
+		require.Nil(s.T(), parsed.VerifySignature(), signer.GetType())
+
+		// Tampered data is rejected
+		parsed.Data = arweave.Base64String(tool.RandomString(100))
+		require.NotNil(s.T(), parsed.VerifySignature(), signer.GetType())
+	}
+}
+
+func (s *BundleItemTestSuite) TestStream() {
+	data := []byte(tool.RandomString(100000))
+
+	path := filepath.Join(s.T().TempDir(), "data")
+	require.Nil(s.T(), os.WriteFile(path, data, 0600))
+	source, err := NewFileDataSource(path)
+	require.Nil(s.T(), err)
+
+	item := BundleItem{
+		SignatureType: SignatureTypeArweave,
+		Anchor:        arweave.Base64String(tool.RandomString(32)),
+		Tags:          Tags{Tag{Name: "1", Value: "2"}, Tag{Name: "3", Value: "4"}},
+		DataSource:    source,
+	}
+
+	err = item.Sign(s.signer)
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), item.VerifySignature())
+
+	// Streamed encoding is the same as the buffered one
+	reader, err := item.OpenReader()
+	require.Nil(s.T(), err)
+	streamed, err := io.ReadAll(reader)
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), reader.Close())
+
+	buf, err := item.Marshal()
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), buf, streamed)
+	require.Equal(s.T(), item.Size(), len(streamed))
+
+	// Decoding the header leaves the data in the stream
+	parsed := BundleItem{}
+	stream := bytes.NewReader(streamed)
+	err = parsed.DecodeHeader(stream)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), item.HeaderSize(), len(streamed)-stream.Len())
+	require.Equal(s.T(), item.Id, parsed.Id)
+	require.Nil(s.T(), parsed.VerifySignatureStream(stream))
+
+	// Same item with data in memory
+	parsed = BundleItem{}
+	require.Nil(s.T(), parsed.Unmarshal(streamed))
+	require.Equal(s.T(), arweave.Base64String(data), parsed.Data)
+	require.Nil(s.T(), parsed.VerifySignature())
+}
+
+func (s *BundleItemTestSuite) TestNestedBundleDataSource() {
+	nested := make([]*BundleItem, 3)
+	sources := make([]DataSource, len(nested))
+	for i := range nested {
+		nested[i] = &BundleItem{
+			Tags: Tags{Tag{Name: "1", Value: "2"}},
+			Data: arweave.Base64String(tool.RandomString(100 * (i + 1))),
+		}
+		require.Nil(s.T(), nested[i].Sign(s.signer))
+
+		buf, err := nested[i].Marshal()
+		require.Nil(s.T(), err)
+		sources[i] = NewBytesDataSource(buf)
+	}
+
+	expected := BundleItem{}
+	require.Nil(s.T(), expected.NestBundles(nested))
+
+	source, err := NewNestedBundleDataSource(sources...)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), int64(len(expected.Data)), source.Size())
+
+	reader, err := source.Open()
+	require.Nil(s.T(), err)
+	actual, err := io.ReadAll(reader)
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), reader.Close())
+	require.Equal(s.T(), []byte(expected.Data), actual)
+}
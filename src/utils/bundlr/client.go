@@ -2,8 +2,8 @@ package bundlr
 
 import (
 	"context"
-	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/warp-contracts/syncer/src/utils/bundlr/responses"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -22,21 +22,11 @@ func NewClient(ctx context.Context, config *config.Bundlr) (self *Client) {
 }
 
 func (self *Client) Upload(ctx context.Context, item *BundleItem) (out *responses.Upload, resp *resty.Response, err error) {
-	reader, err := item.Reader()
-	if err != nil {
-		return
-	}
-
-	body, err := io.ReadAll(reader)
-	if err != nil {
-		return
-	}
-
 	req, cancel := self.Request(ctx)
 	defer cancel()
 
-	resp, err = req.
-		SetBody(body).
+	// Item is streamed, memory usage doesn't depend on its size
+	resp, err = SetStreamBody(req, item).
 		SetResult(&responses.Upload{}).
 		ForceContentType("application/json").
 		SetHeader("Content-Type", "application/octet-stream").
@@ -95,3 +85,41 @@ func (self *Client) GetStatus(ctx context.Context, id string) (out *responses.St
 
 	return
 }
+
+// Price (in winc) of uploading a data item of the given size. It rarely changes, see price_cache.PriceCache
+func (self *Client) GetPrice(ctx context.Context, size int) (out int64, err error) {
+	req, cancel := self.Request(ctx)
+	defer cancel()
+
+	resp, err := req.
+		SetPathParam("size", strconv.Itoa(size)).
+		Get("/price/arweave/{size}")
+	if err != nil {
+		return
+	}
+
+	return ParseWinc(resp.String())
+}
+
+// Balance (in winc) of the given Arweave address
+func (self *Client) GetBalance(ctx context.Context, address string) (out int64, err error) {
+	req, cancel := self.Request(ctx)
+	defer cancel()
+
+	resp, err := req.
+		SetResult(&responses.Balance{}).
+		ForceContentType("application/json").
+		SetQueryParam("address", address).
+		Get("/account/balance/arweave")
+	if err != nil {
+		return
+	}
+
+	balance, ok := resp.Result().(*responses.Balance)
+	if !ok {
+		err = ErrFailedToParse
+		return
+	}
+
+	return ParseWinc(balance.Balance)
+}
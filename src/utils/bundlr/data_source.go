@@ -0,0 +1,145 @@
+package bundlr
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// Data of a bundle item that isn't held in memory.
+// It needs to be read at least twice - once for signing and once for encoding - so it's reopened each time.
+type DataSource interface {
+	// Reader positioned at the beginning of the data
+	Open() (io.ReadCloser, error)
+
+	// Length of the data in bytes
+	Size() int64
+}
+
+type bytesDataSource []byte
+
+// Data already in memory, useful for passing it to APIs that expect a DataSource
+func NewBytesDataSource(data []byte) DataSource {
+	return bytesDataSource(data)
+}
+
+func (self bytesDataSource) Open() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(self)), nil
+}
+
+func (self bytesDataSource) Size() int64 {
+	return int64(len(self))
+}
+
+type fileDataSource struct {
+	path string
+	size int64
+}
+
+// Data stored in a file. File shouldn't change after the source is created.
+func NewFileDataSource(path string) (out DataSource, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	return &fileDataSource{path: path, size: info.Size()}, nil
+}
+
+func (self *fileDataSource) Open() (io.ReadCloser, error) {
+	return os.Open(self.path)
+}
+
+func (self *fileDataSource) Size() int64 {
+	return self.size
+}
+
+type nestedBundleDataSource struct {
+	header []byte
+	items  []DataSource
+	size   int64
+}
+
+// Binary bundle (ANS-104) made of already encoded bundle items.
+// Same as BundleItem.NestBundles, but items are streamed instead of marshalled into one buffer.
+func NewNestedBundleDataSource(items ...DataSource) (out DataSource, err error) {
+	self := &nestedBundleDataSource{
+		items: items,
+		size:  int64(32 + 64*len(items)),
+	}
+
+	var header bytes.Buffer
+	header.Grow(int(self.size))
+	header.Write(LongTo32ByteArray(len(items)))
+
+	for _, item := range items {
+		// Id is the only thing needed from the encoded item, it's enough to decode its header
+		var reader io.ReadCloser
+		reader, err = item.Open()
+		if err != nil {
+			return
+		}
+
+		var decoded BundleItem
+		err = decoded.DecodeHeader(reader)
+		reader.Close()
+		if err != nil {
+			return
+		}
+
+		header.Write(LongTo32ByteArray(int(item.Size())))
+		header.Write(decoded.Id)
+		self.size += item.Size()
+	}
+
+	self.header = header.Bytes()
+
+	return self, nil
+}
+
+func (self *nestedBundleDataSource) Open() (out io.ReadCloser, err error) {
+	readers := make([]io.ReadCloser, 0, len(self.items)+1)
+	readers = append(readers, io.NopCloser(bytes.NewReader(self.header)))
+
+	for _, item := range self.items {
+		var reader io.ReadCloser
+		reader, err = item.Open()
+		if err != nil {
+			newMultiReadCloser(readers...).Close()
+			return
+		}
+		readers = append(readers, reader)
+	}
+
+	return newMultiReadCloser(readers...), nil
+}
+
+func (self *nestedBundleDataSource) Size() int64 {
+	return self.size
+}
+
+// Like io.MultiReader, but closes all the readers
+type multiReadCloser struct {
+	io.Reader
+	closers []io.ReadCloser
+}
+
+func newMultiReadCloser(readers ...io.ReadCloser) *multiReadCloser {
+	plain := make([]io.Reader, len(readers))
+	for i, reader := range readers {
+		plain[i] = reader
+	}
+	return &multiReadCloser{
+		Reader:  io.MultiReader(plain...),
+		closers: readers,
+	}
+}
+
+func (self *multiReadCloser) Close() (err error) {
+	for _, closer := range self.closers {
+		errClose := closer.Close()
+		if errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package bundlr
+
+import (
+	"crypto/ed25519"
+
+	"github.com/cosmos/btcutil/base58"
+)
+
+// Signs data items with an ED25519 key.
+// Solana uses the same scheme, it only has a different signature type.
+type Ed25519Signer struct {
+	PrivateKey    ed25519.PrivateKey
+	Owner         []byte
+	SignatureType SignatureType
+}
+
+// Accepts a 32 byte seed or a 64 byte private key
+func NewEd25519Signer(privateKey []byte) (self *Ed25519Signer, err error) {
+	self = new(Ed25519Signer)
+	self.SignatureType = SignatureTypeEd25519
+
+	switch len(privateKey) {
+	case ed25519.SeedSize:
+		self.PrivateKey = ed25519.NewKeyFromSeed(privateKey)
+	case ed25519.PrivateKeySize:
+		self.PrivateKey = ed25519.PrivateKey(privateKey)
+	default:
+		err = ErrEd25519InvalidPrivateKey
+		return
+	}
+
+	self.Owner = []byte(self.PrivateKey.Public().(ed25519.PublicKey))
+
+	return
+}
+
+// Solana keys are exported as base58 encoded 64 byte private keys
+func NewSolanaSigner(privateKeyBase58 string) (self *Ed25519Signer, err error) {
+	buf := base58.Decode(privateKeyBase58)
+	if len(buf) != ed25519.PrivateKeySize {
+		err = ErrEd25519InvalidPrivateKey
+		return
+	}
+
+	self, err = NewEd25519Signer(buf)
+	if err != nil {
+		return
+	}
+	self.SignatureType = SignatureTypeSolana
+
+	return
+}
+
+func (self *Ed25519Signer) Sign(data []byte) (signature []byte, err error) {
+	return ed25519.Sign(self.PrivateKey, data), nil
+}
+
+func (self *Ed25519Signer) Verify(data []byte, signature []byte) (err error) {
+	return ed25519Verify(self.Owner, data, signature)
+}
+
+func ed25519Verify(owner, data, signature []byte) (err error) {
+	if len(owner) != ed25519.PublicKeySize {
+		err = ErrEd25519InvalidOwner
+		return
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(owner), data, signature) {
+		err = ErrEd25519SignatureMismatch
+		return
+	}
+
+	return
+}
+
+func (self *Ed25519Signer) GetOwner() []byte {
+	return self.Owner
+}
+
+func (self *Ed25519Signer) GetType() SignatureType {
+	return self.SignatureType
+}
+
+func (self *Ed25519Signer) GetSignatureLength() int {
+	return ed25519.SignatureSize
+}
+
+func (self *Ed25519Signer) GetOwnerLength() int {
+	return ed25519.PublicKeySize
+}
@@ -0,0 +1,86 @@
+package bundlr
+
+import (
+	"encoding/hex"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"testing"
+)
+
+// Test vector 1 from RFC 8032
+const (
+	ED25519_SEED       = `9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60`
+	ED25519_PUBLIC_KEY = `d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a`
+	ED25519_SIGNATURE  = `e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b`
+
+	// Same key in the format exported by Solana wallets
+	SOLANA_PRIVATE_KEY = `49W385L4rePHy6PAaQUovbD2aacgN4HsKXSMeUzRg4fmwXszN91JuMFrQRj3vMDpZuRF3ZknQBuRBoWQJEfXstMw`
+	SOLANA_ADDRESS     = `FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z`
+)
+
+func TestEd25519SignerTestSuite(t *testing.T) {
+	suite.Run(t, new(Ed25519SignerTestSuite))
+}
+
+type Ed25519SignerTestSuite struct {
+	suite.Suite
+}
+
+func (s *Ed25519SignerTestSuite) TestCreation() {
+	seed, err := hex.DecodeString(ED25519_SEED)
+	require.Nil(s.T(), err)
+
+	signer, err := NewEd25519Signer(seed)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeEd25519, signer.GetType())
+	require.Equal(s.T(), ED25519_PUBLIC_KEY, hex.EncodeToString(signer.GetOwner()))
+	require.Equal(s.T(), signer.GetOwnerLength(), len(signer.GetOwner()))
+
+	_, err = NewEd25519Signer(seed[:10])
+	require.ErrorIs(s.T(), err, ErrEd25519InvalidPrivateKey)
+}
+
+func (s *Ed25519SignerTestSuite) TestSolanaCreation() {
+	signer, err := NewSolanaSigner(SOLANA_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeSolana, signer.GetType())
+	require.Equal(s.T(), ED25519_PUBLIC_KEY, hex.EncodeToString(signer.GetOwner()))
+
+	address, err := GetAddress(SignatureTypeSolana, signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SOLANA_ADDRESS, address)
+}
+
+func (s *Ed25519SignerTestSuite) TestVector() {
+	seed, err := hex.DecodeString(ED25519_SEED)
+	require.Nil(s.T(), err)
+
+	signer, err := NewEd25519Signer(seed)
+	require.Nil(s.T(), err)
+
+	signature, err := signer.Sign([]byte{})
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), ED25519_SIGNATURE, hex.EncodeToString(signature))
+
+	// Verification with the public key only
+	verifier, err := GetSigner(SignatureTypeEd25519, signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), verifier.Verify([]byte{}, signature))
+	require.ErrorIs(s.T(), verifier.Verify([]byte("other"), signature), ErrEd25519SignatureMismatch)
+}
+
+func (s *Ed25519SignerTestSuite) TestSignAndVerify() {
+	signer, err := NewSolanaSigner(SOLANA_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+
+	data := []byte("to be signed")
+
+	signature, err := signer.Sign(data)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), len(signature), signer.GetSignatureLength())
+
+	err = signer.Verify(data, signature)
+	require.Nil(s.T(), err)
+}
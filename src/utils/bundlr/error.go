@@ -34,4 +34,19 @@ var (
 	ErrNestedBundleInvalidLength         = errors.New("nested bundle invalid length in one of the fields")
 	ErrAlreadyReceived                   = errors.New("data item already received")
 	ErrPaymentRequired                   = errors.New("payment required")
+	ErrEd25519InvalidPrivateKey          = errors.New("invalid ed25519 private key")
+	ErrEd25519InvalidOwner               = errors.New("invalid ed25519 public key")
+	ErrEd25519SignatureMismatch          = errors.New("ed25519 signature mismatch")
+	ErrMultiAptosTooManyKeys             = errors.New("too many keys in multisig aptos signer, max is 32")
+	ErrMultiAptosInvalidThreshold        = errors.New("invalid threshold of multisig aptos signer")
+	ErrMultiAptosInvalidOwner            = errors.New("invalid multisig aptos owner")
+	ErrMultiAptosInvalidSignature        = errors.New("invalid multisig aptos signature")
+	ErrMultiAptosSignatureMismatch       = errors.New("multisig aptos signature mismatch")
+	ErrTypedEthereumInvalidOwner         = errors.New("typed ethereum owner isn't an address")
+	ErrTypedEthereumSignatureMismatch    = errors.New("typed ethereum signature mismatch")
+	ErrKeyRefEnvNotSet                   = errors.New("environment variable with the key is not set")
+	ErrKeyRefNotResolvable               = errors.New("key held by an external signer can't be loaded")
+	ErrRemoteSignerInvalidUrl            = errors.New("invalid external signer url")
+	ErrRemoteSignerFailed                = errors.New("external signer failed to sign")
+	ErrRemoteSignerTypeMismatch          = errors.New("external signer uses a different signature type")
 )
@@ -91,6 +91,11 @@ func (self *EthereumSigner) Verify(data []byte, signature []byte) (err error) {
 // }
 
 func (self *EthereumSigner) GetOwner() []byte {
+	if self.PrivateKey == nil {
+		// Signer created only for verification
+		return self.Owner
+	}
+
 	publicKeyECDSA, ok := self.PrivateKey.Public().(*ecdsa.PublicKey)
 	if !ok {
 		panic(ErrFailedToParseEthereumPublicKey)
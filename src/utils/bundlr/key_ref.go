@@ -0,0 +1,104 @@
+package bundlr
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/cosmos/btcutil/base58"
+)
+
+// Keys in the configuration can be passed inline or as a reference:
+// - file:///path/to/key reads the key from a file,
+// - env://NAME reads the key from an environment variable,
+// - signer://... delegates signing to an external process, the key never gets loaded (see RemoteSigner).
+const (
+	KeyRefFile   = "file://"
+	KeyRefEnv    = "env://"
+	KeyRefSigner = "signer://"
+)
+
+// Returns the key the reference points to. Values that aren't references are returned as is.
+func ResolveKey(ref string) (key string, err error) {
+	switch {
+	case strings.HasPrefix(ref, KeyRefFile):
+		var buf []byte
+		buf, err = os.ReadFile(strings.TrimPrefix(ref, KeyRefFile))
+		if err != nil {
+			return
+		}
+		key = strings.TrimSpace(string(buf))
+	case strings.HasPrefix(ref, KeyRefEnv):
+		var ok bool
+		key, ok = os.LookupEnv(strings.TrimPrefix(ref, KeyRefEnv))
+		if !ok {
+			err = ErrKeyRefEnvNotSet
+			return
+		}
+		key = strings.TrimSpace(key)
+	case strings.HasPrefix(ref, KeyRefSigner):
+		err = ErrKeyRefNotResolvable
+	default:
+		key = ref
+	}
+	return
+}
+
+// Creates a signer from the key reference.
+// External signer is used for signer:// references, it has to use the expected signature type.
+func NewSigner(ref string, signatureType SignatureType) (signer Signer, err error) {
+	if strings.HasPrefix(ref, KeyRefSigner) {
+		signer, err = NewRemoteSigner(ref)
+		if err != nil {
+			return
+		}
+		if signer.GetType() != signatureType {
+			err = ErrRemoteSignerTypeMismatch
+			return
+		}
+		return
+	}
+
+	key, err := ResolveKey(ref)
+	if err != nil {
+		return
+	}
+
+	return NewSignerFromKey(key, signatureType)
+}
+
+// Creates a signer from the key in the format usual for the chain:
+// - Arweave: JWK,
+// - Ethereum: hex encoded private key,
+// - Solana: base58 encoded private key,
+// - ED25519 and Aptos: hex encoded seed or private key.
+func NewSignerFromKey(key string, signatureType SignatureType) (signer Signer, err error) {
+	switch signatureType {
+	case SignatureTypeArweave:
+		return NewArweaveSigner(key)
+	case SignatureTypeEthereum:
+		return NewEthereumSigner(key)
+	case SignatureTypeTypedEthereum:
+		return NewTypedEthereumSigner(key)
+	case SignatureTypeSolana:
+		return NewSolanaSigner(key)
+	case SignatureTypeEd25519, SignatureTypeInjectedAptos:
+		var buf []byte
+		buf, err = hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			// Also accept the Solana format
+			buf = base58.Decode(key)
+			if len(buf) == 0 {
+				return
+			}
+			err = nil
+		}
+		if signatureType == SignatureTypeEd25519 {
+			return NewEd25519Signer(buf)
+		}
+		return NewAptosSigner(buf)
+	default:
+		err = ErrUnsupportedSignatureType
+	}
+	return
+}
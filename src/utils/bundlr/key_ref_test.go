@@ -0,0 +1,75 @@
+package bundlr
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"testing"
+)
+
+func TestKeyRefTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyRefTestSuite))
+}
+
+type KeyRefTestSuite struct {
+	suite.Suite
+}
+
+func (s *KeyRefTestSuite) TestInline() {
+	key, err := ResolveKey(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), ETHEREUM_PRIVATE_KEY, key)
+}
+
+func (s *KeyRefTestSuite) TestFile() {
+	path := filepath.Join(s.T().TempDir(), "wallet.json")
+	err := os.WriteFile(path, []byte(EMPTY_ARWEAVE_WALLET+"\n"), 0600)
+	require.Nil(s.T(), err)
+
+	key, err := ResolveKey("file://" + path)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), EMPTY_ARWEAVE_WALLET, key)
+
+	signer, err := NewSigner("file://"+path, SignatureTypeArweave)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeArweave, signer.GetType())
+
+	_, err = ResolveKey("file://" + path + ".missing")
+	require.NotNil(s.T(), err)
+}
+
+func (s *KeyRefTestSuite) TestEnv() {
+	s.T().Setenv("SYNCER_TEST_KEY", ETHEREUM_PRIVATE_KEY)
+
+	signer, err := NewSigner("env://SYNCER_TEST_KEY", SignatureTypeEthereum)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeEthereum, signer.GetType())
+
+	_, err = ResolveKey("env://SYNCER_TEST_KEY_MISSING")
+	require.ErrorIs(s.T(), err, ErrKeyRefEnvNotSet)
+}
+
+func (s *KeyRefTestSuite) TestSignerNotResolvable() {
+	_, err := ResolveKey("signer://unix/tmp/signer.sock")
+	require.ErrorIs(s.T(), err, ErrKeyRefNotResolvable)
+}
+
+func (s *KeyRefTestSuite) TestKeyFormats() {
+	signer, err := NewSignerFromKey(SOLANA_PRIVATE_KEY, SignatureTypeSolana)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeSolana, signer.GetType())
+
+	signer, err = NewSignerFromKey(ED25519_SEED, SignatureTypeEd25519)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeEd25519, signer.GetType())
+
+	signer, err = NewSignerFromKey("0x"+ED25519_SEED, SignatureTypeInjectedAptos)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeInjectedAptos, signer.GetType())
+
+	_, err = NewSignerFromKey(ED25519_SEED, SignatureTypeMultiAptos)
+	require.ErrorIs(s.T(), err, ErrUnsupportedSignatureType)
+}
@@ -0,0 +1,137 @@
+package bundlr
+
+import (
+	"crypto/ed25519"
+)
+
+const (
+	multiAptosMaxKeys     = 32
+	multiAptosOwnerLength = multiAptosMaxKeys*ed25519.PublicKeySize + 1 /* threshold */
+	multiAptosBitmapPos   = multiAptosMaxKeys * ed25519.SignatureSize
+	multiAptosSigLength   = multiAptosBitmapPos + 4 /* bitmap */
+)
+
+// Signs data items with an Aptos K-of-N multisig account.
+// Owner is a list of 32 public key slots (unused are zeroed) followed by the threshold.
+// Signature is a list of 32 signature slots followed by a bitmap of slots that are signed.
+type MultiAptosSigner struct {
+	PrivateKeys []ed25519.PrivateKey
+	Owner       []byte
+}
+
+// Each private key is a 32 byte seed or a 64 byte private key.
+// Signer has all keys of the account and signs with the first threshold of them.
+func NewMultiAptosSigner(threshold int, privateKeys ...[]byte) (self *MultiAptosSigner, err error) {
+	if len(privateKeys) > multiAptosMaxKeys {
+		err = ErrMultiAptosTooManyKeys
+		return
+	}
+	if threshold < 1 || threshold > len(privateKeys) {
+		err = ErrMultiAptosInvalidThreshold
+		return
+	}
+
+	self = new(MultiAptosSigner)
+	self.Owner = make([]byte, multiAptosOwnerLength)
+	self.Owner[multiAptosOwnerLength-1] = byte(threshold)
+
+	for i, privateKey := range privateKeys {
+		var key ed25519.PrivateKey
+		switch len(privateKey) {
+		case ed25519.SeedSize:
+			key = ed25519.NewKeyFromSeed(privateKey)
+		case ed25519.PrivateKeySize:
+			key = ed25519.PrivateKey(privateKey)
+		default:
+			err = ErrEd25519InvalidPrivateKey
+			return
+		}
+		self.PrivateKeys = append(self.PrivateKeys, key)
+		copy(self.Owner[i*ed25519.PublicKeySize:], key.Public().(ed25519.PublicKey))
+	}
+
+	return
+}
+
+func (self *MultiAptosSigner) Sign(data []byte) (signature []byte, err error) {
+	signature = make([]byte, multiAptosSigLength)
+	threshold := int(self.Owner[multiAptosOwnerLength-1])
+
+	for i := 0; i < threshold; i++ {
+		copy(signature[i*ed25519.SignatureSize:], ed25519.Sign(self.PrivateKeys[i], data))
+
+		// Bitmap is big endian, first key is the most significant bit
+		signature[multiAptosBitmapPos+i/8] |= 1 << (7 - i%8)
+	}
+
+	return
+}
+
+func (self *MultiAptosSigner) Verify(data []byte, signature []byte) (err error) {
+	if len(self.Owner) != multiAptosOwnerLength {
+		err = ErrMultiAptosInvalidOwner
+		return
+	}
+	if len(signature) != multiAptosSigLength {
+		err = ErrMultiAptosInvalidSignature
+		return
+	}
+
+	// Every included signature must be valid. Like in arbundles the threshold isn't checked,
+	// but unlike there an item without any signature isn't accepted
+	bitmap := signature[multiAptosBitmapPos:]
+	var signed int
+	for i := 0; i < multiAptosMaxKeys; i++ {
+		if bitmap[i/8]&(1<<(7-i%8)) == 0 {
+			continue
+		}
+
+		publicKey := self.Owner[i*ed25519.PublicKeySize : (i+1)*ed25519.PublicKeySize]
+		sig := signature[i*ed25519.SignatureSize : (i+1)*ed25519.SignatureSize]
+		if !ed25519.Verify(ed25519.PublicKey(publicKey), data, sig) {
+			err = ErrMultiAptosSignatureMismatch
+			return
+		}
+		signed++
+	}
+
+	if signed == 0 {
+		err = ErrMultiAptosSignatureMismatch
+		return
+	}
+
+	return
+}
+
+// Public keys of the account, without the unused slots
+func (self *MultiAptosSigner) GetPublicKeys() (out []ed25519.PublicKey) {
+	if len(self.Owner) != multiAptosOwnerLength {
+		return
+	}
+
+	empty := make([]byte, ed25519.PublicKeySize)
+	for i := 0; i < multiAptosMaxKeys; i++ {
+		publicKey := self.Owner[i*ed25519.PublicKeySize : (i+1)*ed25519.PublicKeySize]
+		if string(publicKey) == string(empty) {
+			break
+		}
+		out = append(out, ed25519.PublicKey(publicKey))
+	}
+	return
+}
+
+func (self *MultiAptosSigner) GetOwner() []byte {
+	return self.Owner
+}
+
+func (self *MultiAptosSigner) GetType() SignatureType {
+	return SignatureTypeMultiAptos
+}
+
+func (self *MultiAptosSigner) GetSignatureLength() int {
+	return multiAptosSigLength
+}
+
+func (self *MultiAptosSigner) GetOwnerLength() int {
+	return multiAptosOwnerLength
+}
@@ -0,0 +1,110 @@
+package bundlr
+
+import (
+	"encoding/hex"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"testing"
+)
+
+// Data items produced by testdata/generate.js
+const (
+	MULTI_APTOS_SEED_2                       = `4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb`
+	MULTI_APTOS_SEED_3                       = `c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7`
+	MULTI_APTOS_BITMAP                       = `c0000000`
+	MULTI_APTOS_ADDRESS                      = `0x8c4e464658a4db4ee036361ca7d826547d80827fd5db6d4d6e277bba160188f8`
+	MULTI_APTOS_DATA_ITEM                    = `multi_aptos.bin`
+	MULTI_APTOS_DATA_ITEM_ID                 = `T-ZGdEkdWwGm4eARgxMkOknuF0-fyC639YmUpTiIF8A`
+	MULTI_APTOS_BELOW_THRESHOLD_DATA_ITEM    = `multi_aptos_below_threshold.bin`
+	MULTI_APTOS_BELOW_THRESHOLD_DATA_ITEM_ID = `P6dYGd5p6SFSMd7QGP03rRSpqrEpySYRjSbx-3hU--g`
+)
+
+func TestMultiAptosSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(MultiAptosSignerTestSuite))
+}
+
+type MultiAptosSignerTestSuite struct {
+	suite.Suite
+	signer *MultiAptosSigner
+}
+
+func (s *MultiAptosSignerTestSuite) SetupSuite() {
+	var seeds [][]byte
+	for _, seedHex := range []string{ED25519_SEED, MULTI_APTOS_SEED_2, MULTI_APTOS_SEED_3} {
+		seed, err := hex.DecodeString(seedHex)
+		require.Nil(s.T(), err)
+		seeds = append(seeds, seed)
+	}
+
+	var err error
+	s.signer, err = NewMultiAptosSigner(2, seeds...)
+	require.Nil(s.T(), err)
+}
+
+func (s *MultiAptosSignerTestSuite) TestCreation() {
+	require.Equal(s.T(), s.signer.GetOwnerLength(), len(s.signer.GetOwner()))
+	require.Equal(s.T(), 3, len(s.signer.GetPublicKeys()))
+	require.Equal(s.T(), ED25519_PUBLIC_KEY, hex.EncodeToString(s.signer.GetPublicKeys()[0]))
+	require.Equal(s.T(), byte(2), s.signer.GetOwner()[s.signer.GetOwnerLength()-1])
+
+	_, err := NewMultiAptosSigner(4, s.signer.PrivateKeys[0], s.signer.PrivateKeys[1])
+	require.ErrorIs(s.T(), err, ErrMultiAptosInvalidThreshold)
+}
+
+func (s *MultiAptosSignerTestSuite) TestVector() {
+	data := []byte("to be signed")
+
+	signature, err := s.signer.Sign(data)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), s.signer.GetSignatureLength(), len(signature))
+	require.Equal(s.T(), MULTI_APTOS_BITMAP, hex.EncodeToString(signature[multiAptosBitmapPos:]))
+
+	verifier, err := GetSigner(SignatureTypeMultiAptos, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), verifier.Verify(data, signature))
+	require.ErrorIs(s.T(), verifier.Verify([]byte("other"), signature), ErrMultiAptosSignatureMismatch)
+}
+
+func (s *MultiAptosSignerTestSuite) TestDataItem() {
+	// Signed by the first and the last key
+	item := readTestDataItem(s.T(), MULTI_APTOS_DATA_ITEM)
+	require.Equal(s.T(), SignatureTypeMultiAptos, item.SignatureType)
+	require.Equal(s.T(), MULTI_APTOS_DATA_ITEM_ID, item.Id.Base64())
+	require.Equal(s.T(), s.signer.GetOwner(), []byte(item.Owner))
+	require.Equal(s.T(), "a0000000", hex.EncodeToString(item.Signature[multiAptosBitmapPos:]))
+	require.Nil(s.T(), item.VerifySignature())
+
+	// Data changed after signing
+	item.Data = []byte("other")
+	require.ErrorIs(s.T(), item.VerifySignature(), ErrMultiAptosSignatureMismatch)
+}
+
+func (s *MultiAptosSignerTestSuite) TestBelowThreshold() {
+	// Signed only by the second key, arbundles doesn't check the threshold
+	item := readTestDataItem(s.T(), MULTI_APTOS_BELOW_THRESHOLD_DATA_ITEM)
+	require.Equal(s.T(), MULTI_APTOS_BELOW_THRESHOLD_DATA_ITEM_ID, item.Id.Base64())
+	require.Equal(s.T(), "40000000", hex.EncodeToString(item.Signature[multiAptosBitmapPos:]))
+	require.Nil(s.T(), item.VerifySignature())
+}
+
+func (s *MultiAptosSignerTestSuite) TestNoSignature() {
+	data := []byte("to be signed")
+
+	signature, err := s.signer.Sign(data)
+	require.Nil(s.T(), err)
+
+	// Remove all signatures from the bitmap
+	copy(signature[multiAptosBitmapPos:], []byte{0, 0, 0, 0})
+
+	verifier, err := GetSigner(SignatureTypeMultiAptos, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.ErrorIs(s.T(), verifier.Verify(data, signature), ErrMultiAptosSignatureMismatch)
+}
+
+func (s *MultiAptosSignerTestSuite) TestAddress() {
+	address, err := GetAddress(SignatureTypeMultiAptos, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), MULTI_APTOS_ADDRESS, address)
+}
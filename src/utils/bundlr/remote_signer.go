@@ -0,0 +1,185 @@
+package bundlr
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr/responses"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	RemoteSignerDefaultKey     = "default"
+	RemoteSignerDefaultTimeout = 10 * time.Second
+)
+
+// Request sent to the external signer
+type RemoteSignRequest struct {
+	// Base64URL encoded data to sign
+	Data string `json:"data"`
+}
+
+// Delegates signing to an external process, private key never gets loaded into this process.
+// The signer is addressed with an url:
+// - signer://unix/path/to/signer.sock - HTTP over a unix socket,
+// - signer://host:port - plain HTTP, should only be used on localhost or a private network.
+// Optional query parameters:
+// - key - name of the key held by the signer, defaults to "default",
+// - token - bearer token sent to the signer, may be a key reference (e.g. env://SIGNER_TOKEN),
+// - timeout - max time of one request, defaults to 10s.
+//
+// Protocol:
+// - GET /v1/keys/{key} returns responses.SignerKey,
+// - POST /v1/keys/{key}/sign with RemoteSignRequest returns responses.Sign.
+type RemoteSigner struct {
+	client *resty.Client
+	key    string
+
+	// Owner and type reported by the signer. Used for verification, which doesn't need the private key.
+	verifier Signer
+}
+
+func NewRemoteSigner(ref string) (self *RemoteSigner, err error) {
+	self = new(RemoteSigner)
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Scheme+"://" != KeyRefSigner || u.Host == "" {
+		err = ErrRemoteSignerInvalidUrl
+		return
+	}
+
+	query := u.Query()
+
+	self.key = query.Get("key")
+	if self.key == "" {
+		self.key = RemoteSignerDefaultKey
+	}
+
+	timeout := RemoteSignerDefaultTimeout
+	if query.Has("timeout") {
+		timeout, err = time.ParseDuration(query.Get("timeout"))
+		if err != nil {
+			return
+		}
+	}
+
+	self.client = resty.New().
+		SetTimeout(timeout).
+		SetHeader("Accept", "application/json")
+
+	if u.Host == "unix" {
+		// Host is ignored, all connections go to the socket
+		socketPath := u.Path
+		self.client.
+			SetBaseURL("http://signer").
+			SetTransport(&http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			})
+	} else {
+		self.client.SetBaseURL("http://" + u.Host + strings.TrimSuffix(u.Path, "/"))
+	}
+
+	if query.Has("token") {
+		var token string
+		token, err = ResolveKey(query.Get("token"))
+		if err != nil {
+			return
+		}
+		self.client.SetAuthToken(token)
+	}
+
+	err = self.fetchKey()
+	return
+}
+
+func (self *RemoteSigner) fetchKey() (err error) {
+	resp, err := self.client.R().
+		SetResult(&responses.SignerKey{}).
+		ForceContentType("application/json").
+		SetPathParam("key", self.key).
+		Get("/v1/keys/{key}")
+	if err != nil {
+		return
+	}
+	if !resp.IsSuccess() {
+		err = ErrRemoteSignerFailed
+		return
+	}
+
+	key, ok := resp.Result().(*responses.SignerKey)
+	if !ok {
+		err = ErrFailedToParse
+		return
+	}
+
+	owner, err := base64.RawURLEncoding.DecodeString(key.Owner)
+	if err != nil {
+		return
+	}
+
+	self.verifier, err = GetSigner(SignatureType(key.SignatureType), owner)
+	return
+}
+
+func (self *RemoteSigner) Sign(data []byte) (signature []byte, err error) {
+	resp, err := self.client.R().
+		SetBody(RemoteSignRequest{Data: base64.RawURLEncoding.EncodeToString(data)}).
+		SetResult(&responses.Sign{}).
+		ForceContentType("application/json").
+		SetPathParam("key", self.key).
+		Post("/v1/keys/{key}/sign")
+	if err != nil {
+		return
+	}
+	if !resp.IsSuccess() {
+		err = ErrRemoteSignerFailed
+		return
+	}
+
+	out, ok := resp.Result().(*responses.Sign)
+	if !ok {
+		err = ErrFailedToParse
+		return
+	}
+
+	signature, err = base64.RawURLEncoding.DecodeString(out.Signature)
+	if err != nil {
+		return
+	}
+
+	if len(signature) != self.GetSignatureLength() {
+		err = ErrRemoteSignerFailed
+		return
+	}
+
+	return
+}
+
+func (self *RemoteSigner) Verify(data []byte, signature []byte) (err error) {
+	return self.verifier.Verify(data, signature)
+}
+
+func (self *RemoteSigner) GetOwner() []byte {
+	return self.verifier.GetOwner()
+}
+
+func (self *RemoteSigner) GetType() SignatureType {
+	return self.verifier.GetType()
+}
+
+func (self *RemoteSigner) GetSignatureLength() int {
+	return self.verifier.GetSignatureLength()
+}
+
+func (self *RemoteSigner) GetOwnerLength() int {
+	return self.verifier.GetOwnerLength()
+}
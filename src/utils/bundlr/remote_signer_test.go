@@ -0,0 +1,124 @@
+package bundlr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/bundlr/responses"
+	"github.com/warp-contracts/syncer/src/utils/tool"
+
+	"testing"
+)
+
+func TestRemoteSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoteSignerTestSuite))
+}
+
+type RemoteSignerTestSuite struct {
+	suite.Suite
+	signer *EthereumSigner
+}
+
+func (s *RemoteSignerTestSuite) SetupSuite() {
+	var err error
+	s.signer, err = NewEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+}
+
+// Minimal implementation of the external signer protocol
+func (s *RemoteSignerTestSuite) handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/keys/", func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/v1/keys/")
+		switch {
+		case path == RemoteSignerDefaultKey && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(responses.SignerKey{
+				SignatureType: int(s.signer.GetType()),
+				Owner:         base64.RawURLEncoding.EncodeToString(s.signer.GetOwner()),
+			})
+		case path == RemoteSignerDefaultKey+"/sign" && r.Method == http.MethodPost:
+			var request RemoteSignRequest
+			err := json.NewDecoder(r.Body).Decode(&request)
+			require.Nil(s.T(), err)
+			data, err := base64.RawURLEncoding.DecodeString(request.Data)
+			require.Nil(s.T(), err)
+			signature, err := s.signer.Sign(data)
+			require.Nil(s.T(), err)
+			_ = json.NewEncoder(w).Encode(responses.Sign{
+				Signature: base64.RawURLEncoding.EncodeToString(signature),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return mux
+}
+
+func (s *RemoteSignerTestSuite) verifyBundleItem(signer Signer) {
+	item := BundleItem{
+		Tags: Tags{Tag{Name: "1", Value: "2"}},
+		Data: arweave.Base64String(tool.RandomString(100)),
+	}
+	err := item.Sign(signer)
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), item.VerifySignature())
+	require.Equal(s.T(), s.signer.GetOwner(), []byte(item.Owner))
+}
+
+func (s *RemoteSignerTestSuite) TestHttp() {
+	server := httptest.NewServer(s.handler("secret"))
+	defer server.Close()
+
+	s.T().Setenv("SYNCER_TEST_SIGNER_TOKEN", "secret")
+	ref := "signer://" + strings.TrimPrefix(server.URL, "http://") + "?token=env://SYNCER_TEST_SIGNER_TOKEN"
+
+	signer, err := NewSigner(ref, SignatureTypeEthereum)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), SignatureTypeEthereum, signer.GetType())
+	s.verifyBundleItem(signer)
+
+	// Wrong type
+	_, err = NewSigner(ref, SignatureTypeArweave)
+	require.ErrorIs(s.T(), err, ErrRemoteSignerTypeMismatch)
+
+	// Missing token
+	_, err = NewRemoteSigner("signer://" + strings.TrimPrefix(server.URL, "http://"))
+	require.ErrorIs(s.T(), err, ErrRemoteSignerFailed)
+
+	// Unknown key
+	_, err = NewRemoteSigner(ref + "&key=other")
+	require.ErrorIs(s.T(), err, ErrRemoteSignerFailed)
+}
+
+func (s *RemoteSignerTestSuite) TestUnixSocket() {
+	path := filepath.Join(s.T().TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", path)
+	require.Nil(s.T(), err)
+
+	server := httptest.NewUnstartedServer(s.handler(""))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	signer, err := NewSigner("signer://unix"+path, SignatureTypeEthereum)
+	require.Nil(s.T(), err)
+	s.verifyBundleItem(signer)
+}
+
+func (s *RemoteSignerTestSuite) TestInvalidUrl() {
+	_, err := NewRemoteSigner("signer://")
+	require.ErrorIs(s.T(), err, ErrRemoteSignerInvalidUrl)
+}
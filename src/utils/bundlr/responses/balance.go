@@ -0,0 +1,6 @@
+package responses
+
+type Balance struct {
+	// Balance in atomic units of the currency (winc for Arweave)
+	Balance string `json:"balance"`
+}
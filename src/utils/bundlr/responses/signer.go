@@ -0,0 +1,15 @@
+package responses
+
+// Public part of a key held by an external signer
+type SignerKey struct {
+	// Signature type, same as in the data item
+	SignatureType int `json:"signature_type"`
+
+	// Base64URL encoded owner, as put in the data item
+	Owner string `json:"owner"`
+}
+
+type Sign struct {
+	// Base64URL encoded signature
+	Signature string `json:"signature"`
+}
@@ -7,8 +7,13 @@ type SignatureType int
 // Values are taken from bundlr library
 // https://github.com/Bundlr-Network/arbundles/blob/5413fe576098355f7502a5fa9456f8db6a861492/src/constants.ts#L4
 const (
-	SignatureTypeArweave SignatureType = 1
-	SignatureTypeEthereum SignatureType = 3
+	SignatureTypeArweave       SignatureType = 1
+	SignatureTypeEd25519       SignatureType = 2
+	SignatureTypeEthereum      SignatureType = 3
+	SignatureTypeSolana        SignatureType = 4
+	SignatureTypeInjectedAptos SignatureType = 5
+	SignatureTypeMultiAptos    SignatureType = 6
+	SignatureTypeTypedEthereum SignatureType = 7
 )
 
 func (self SignatureType) Bytes() []byte {
@@ -17,10 +17,27 @@ func GetSigner(SignatureType SignatureType, owner []byte) (signer Signer, err er
 		signer = &ArweaveSigner{
 			Owner: owner,
 		}
+	case SignatureTypeEd25519, SignatureTypeSolana:
+		signer = &Ed25519Signer{
+			Owner:         owner,
+			SignatureType: SignatureType,
+		}
 	case SignatureTypeEthereum:
 		signer = &EthereumSigner{
 			Owner: owner,
 		}
+	case SignatureTypeInjectedAptos:
+		signer = &AptosSigner{
+			Owner: owner,
+		}
+	case SignatureTypeMultiAptos:
+		signer = &MultiAptosSigner{
+			Owner: owner,
+		}
+	case SignatureTypeTypedEthereum:
+		signer = &TypedEthereumSigner{
+			Owner: owner,
+		}
 	default:
 		err = ErrUnsupportedSignatureType
 	}
@@ -0,0 +1,57 @@
+package bundlr
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Makes the request send the encoded item as its body without buffering it.
+// Resty reads io.Reader bodies into memory, so the item is passed in the context and attached in OnStreamBodyPreRequest.
+func SetStreamBody(req *resty.Request, item *BundleItem) *resty.Request {
+	return req.SetContext(context.WithValue(req.Context(), ContextStreamBody, item))
+}
+
+// Attaches the streamed body. It's called before each attempt, so retries send the whole item again
+func OnStreamBodyPreRequest(c *resty.Client, req *http.Request) (err error) {
+	item, ok := req.Context().Value(ContextStreamBody).(*BundleItem)
+	if !ok {
+		return
+	}
+
+	req.Body = newStreamBody(item)
+	req.ContentLength = int64(item.Size())
+	req.GetBody = func() (io.ReadCloser, error) {
+		return newStreamBody(item), nil
+	}
+	return
+}
+
+// Opens the item lazily, upon the first read
+type streamBody struct {
+	item   *BundleItem
+	reader io.ReadCloser
+}
+
+func newStreamBody(item *BundleItem) *streamBody {
+	return &streamBody{item: item}
+}
+
+func (self *streamBody) Read(p []byte) (n int, err error) {
+	if self.reader == nil {
+		self.reader, err = self.item.OpenReader()
+		if err != nil {
+			return
+		}
+	}
+	return self.reader.Read(p)
+}
+
+func (self *streamBody) Close() (err error) {
+	if self.reader == nil {
+		return
+	}
+	return self.reader.Close()
+}
@@ -0,0 +1,59 @@
+package bundlr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/tool"
+)
+
+func TestStreamBodyRetry(t *testing.T) {
+	signer, err := NewArweaveSigner(EMPTY_ARWEAVE_WALLET)
+	require.NoError(t, err)
+
+	item := &BundleItem{
+		Tags: Tags{Tag{Name: "1", Value: "2"}},
+		Data: arweave.Base64String(tool.RandomString(10000)),
+	}
+	require.NoError(t, item.Sign(signer))
+
+	expected, err := item.Marshal()
+	require.NoError(t, err)
+
+	// First attempt fails, retry needs to send the whole item again
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, int64(len(expected)), r.ContentLength)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, body)
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req := resty.New().
+		SetRetryCount(1).
+		AddRetryCondition(func(resp *resty.Response, err error) bool {
+			return resp != nil && resp.StatusCode() >= 500
+		}).
+		SetPreRequestHook(OnStreamBodyPreRequest).
+		R()
+
+	resp, err := SetStreamBody(req, item).Post(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+
+	require.Len(t, bodies, 2)
+	for _, body := range bodies {
+		require.Equal(t, expected, body)
+	}
+}
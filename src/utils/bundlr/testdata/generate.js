@@ -0,0 +1,307 @@
+// Builds data items signed the way arbundles signers do, without using the Go code.
+// Follows arbundles: ar-data-create (layout), deepHash, tags (avro) and
+// InjectedAptosSigner, MultiSignatureAptosSigner, TypedEthereumSigner.
+// Run with `node generate.js` in this directory, it only needs Node.js.
+"use strict";
+
+const crypto = require("crypto");
+const fs = require("fs");
+
+const DATA = Buffer.from("to be signed");
+const TAGS = [
+  { name: "App-Name", value: "SmartWeaveAction" },
+  { name: "Input", value: '{"function":"transfer"}' },
+];
+const TARGET = crypto.createHash("sha256").update("target").digest();
+const ANCHOR = crypto.createHash("sha256").update("anchor").digest();
+
+// Same seeds as in the Go tests
+const ED25519_SEEDS = [
+  "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+  "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+  "c5aa8df43f9f837bedb7442f31dcb7b166d38535076f094b85ce3a2e0b4458f7",
+];
+const ETHEREUM_PRIVATE_KEY = "f4a2b939592564feb35ab10a8e04f6f2fe0943579fb3c9c33505298978b74893";
+
+// ---- deep hash ----
+
+const sha384 = (...parts) => crypto.createHash("sha384").update(Buffer.concat(parts)).digest();
+
+function deepHash(data) {
+  if (Array.isArray(data)) {
+    let acc = sha384(Buffer.from("list" + data.length));
+    for (const chunk of data) {
+      acc = sha384(acc, deepHash(chunk));
+    }
+    return acc;
+  }
+  const blob = Buffer.from(data);
+  return sha384(sha384(Buffer.from("blob" + blob.length)), sha384(blob));
+}
+
+// ---- tags ----
+
+function zigzag(n) {
+  const out = [];
+  let v = BigInt(n) >= 0n ? BigInt(n) << 1n : (-BigInt(n) << 1n) - 1n;
+  do {
+    let byte = Number(v & 0x7fn);
+    v >>= 7n;
+    if (v > 0n) byte |= 0x80;
+    out.push(byte);
+  } while (v > 0n);
+  return Buffer.from(out);
+}
+
+function avroBytes(s) {
+  const buf = Buffer.from(s);
+  return Buffer.concat([zigzag(buf.length), buf]);
+}
+
+function serializeTags(tags) {
+  if (tags.length == 0) return Buffer.alloc(0);
+  const parts = [zigzag(tags.length)];
+  for (const tag of tags) {
+    parts.push(avroBytes(tag.name), avroBytes(tag.value));
+  }
+  parts.push(zigzag(0));
+  return Buffer.concat(parts);
+}
+
+// ---- data item ----
+
+function le(n, size) {
+  const buf = Buffer.alloc(8);
+  buf.writeBigUInt64LE(BigInt(n), 0);
+  return buf.subarray(0, size);
+}
+
+function createData(signer, { target, anchor } = {}) {
+  const tags = serializeTags(TAGS);
+  const signatureData = deepHash([
+    Buffer.from("dataitem"),
+    Buffer.from("1"),
+    Buffer.from(signer.signatureType.toString()),
+    signer.owner,
+    target || Buffer.alloc(0),
+    anchor || Buffer.alloc(0),
+    tags,
+    DATA,
+  ]);
+  const signature = signer.sign(signatureData);
+  if (signature.length != signer.signatureLength) throw new Error("bad signature length");
+
+  const item = Buffer.concat([
+    le(signer.signatureType, 2),
+    signature,
+    signer.owner,
+    target ? Buffer.concat([Buffer.from([1]), target]) : Buffer.from([0]),
+    anchor ? Buffer.concat([Buffer.from([1]), anchor]) : Buffer.from([0]),
+    le(TAGS.length, 8),
+    le(tags.length, 8),
+    tags,
+    DATA,
+  ]);
+  return { item, id: crypto.createHash("sha256").update(signature).digest() };
+}
+
+// ---- ed25519 ----
+
+function ed25519Key(seedHex) {
+  const der = Buffer.concat([Buffer.from("302e020100300506032b657004220420", "hex"), Buffer.from(seedHex, "hex")]);
+  const privateKey = crypto.createPrivateKey({ key: der, format: "der", type: "pkcs8" });
+  const publicKey = crypto.createPublicKey(privateKey).export({ format: "der", type: "spki" }).subarray(-32);
+  return { privateKey, publicKey };
+}
+
+// InjectedAptosSigner: the wallet signs a text envelope with the hex of the message
+function aptosSigner(seedHex) {
+  const key = ed25519Key(seedHex);
+  return {
+    signatureType: 5,
+    signatureLength: 64,
+    owner: key.publicKey,
+    sign: (message) =>
+      crypto.sign(null, Buffer.from(`APTOS\nmessage: ${Buffer.from(message).toString("hex")}\nnonce: bundlr`), key.privateKey),
+  };
+}
+
+// MultiSignatureAptosSigner: 32 public key slots + threshold, 32 signature slots + 4 byte bitmap.
+// Signs with the keys in the given slots
+function multiAptosSigner(seedsHex, threshold, signingSlots) {
+  const keys = seedsHex.map(ed25519Key);
+  const owner = Buffer.alloc(32 * 32 + 1);
+  keys.forEach((key, i) => key.publicKey.copy(owner, i * 32));
+  owner[32 * 32] = threshold;
+  return {
+    signatureType: 6,
+    signatureLength: 64 * 32 + 4,
+    owner,
+    sign: (message) => {
+      const signature = Buffer.alloc(64 * 32 + 4);
+      for (const slot of signingSlots) {
+        crypto.sign(null, Buffer.from(message), keys[slot].privateKey).copy(signature, slot * 64);
+        signature[64 * 32 + Math.floor(slot / 8)] |= 1 << (7 - (slot % 8));
+      }
+      return signature;
+    },
+  };
+}
+
+// ---- keccak256 ----
+
+const MASK = (1n << 64n) - 1n;
+const ROUND_CONSTANTS = [
+  0x0000000000000001n, 0x0000000000008082n, 0x800000000000808an, 0x8000000080008000n,
+  0x000000000000808bn, 0x0000000080000001n, 0x8000000080008081n, 0x8000000000008009n,
+  0x000000000000008an, 0x0000000000000088n, 0x0000000080008009n, 0x000000008000000an,
+  0x000000008000808bn, 0x800000000000008bn, 0x8000000000008089n, 0x8000000000008003n,
+  0x8000000000008002n, 0x8000000000000080n, 0x000000000000800an, 0x800000008000000an,
+  0x8000000080008081n, 0x8000000000008080n, 0x0000000080000001n, 0x8000000080008008n,
+];
+const ROTATIONS = [
+  [0, 36, 3, 41, 18],
+  [1, 44, 10, 45, 2],
+  [62, 6, 43, 15, 61],
+  [28, 55, 25, 21, 56],
+  [27, 20, 39, 8, 14],
+];
+const rotl = (v, n) => (n == 0 ? v : ((v << BigInt(n)) | (v >> BigInt(64 - n))) & MASK);
+
+function keccakF(state) {
+  for (const rc of ROUND_CONSTANTS) {
+    const c = [0, 1, 2, 3, 4].map((x) => state[x][0] ^ state[x][1] ^ state[x][2] ^ state[x][3] ^ state[x][4]);
+    const d = [0, 1, 2, 3, 4].map((x) => c[(x + 4) % 5] ^ rotl(c[(x + 1) % 5], 1));
+    for (let x = 0; x < 5; x++) for (let y = 0; y < 5; y++) state[x][y] ^= d[x];
+    const b = [0, 1, 2, 3, 4].map(() => [0n, 0n, 0n, 0n, 0n]);
+    for (let x = 0; x < 5; x++) for (let y = 0; y < 5; y++) b[y][(2 * x + 3 * y) % 5] = rotl(state[x][y], ROTATIONS[x][y]);
+    for (let x = 0; x < 5; x++)
+      for (let y = 0; y < 5; y++) state[x][y] = b[x][y] ^ (~b[(x + 1) % 5][y] & MASK & b[(x + 2) % 5][y]);
+    state[0][0] ^= rc;
+  }
+}
+
+function keccak256(...parts) {
+  const rate = 136;
+  const message = Buffer.concat(parts);
+  const padded = Buffer.alloc(Math.floor(message.length / rate + 1) * rate);
+  message.copy(padded);
+  padded[message.length] ^= 0x01;
+  padded[padded.length - 1] ^= 0x80;
+
+  const state = [0, 1, 2, 3, 4].map(() => [0n, 0n, 0n, 0n, 0n]);
+  for (let offset = 0; offset < padded.length; offset += rate) {
+    for (let i = 0; i < rate / 8; i++) {
+      state[i % 5][Math.floor(i / 5)] ^= padded.readBigUInt64LE(offset + i * 8);
+    }
+    keccakF(state);
+  }
+
+  const out = Buffer.alloc(32);
+  for (let i = 0; i < 4; i++) out.writeBigUInt64LE(state[i % 5][Math.floor(i / 5)], i * 8);
+  return out;
+}
+
+// ---- secp256k1 ----
+
+const P = 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2fn;
+const N = 0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141n;
+const G = [
+  0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798n,
+  0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8n,
+];
+const mod = (a, m) => ((a % m) + m) % m;
+function inv(a, m) {
+  let [r0, r1, s0, s1] = [mod(a, m), m, 1n, 0n];
+  while (r1 != 0n) {
+    const q = r0 / r1;
+    [r0, r1, s0, s1] = [r1, r0 - q * r1, s1, s0 - q * s1];
+  }
+  return mod(s0, m);
+}
+function add(a, b) {
+  if (a == null) return b;
+  if (b == null) return a;
+  if (a[0] == b[0] && mod(a[1] + b[1], P) == 0n) return null;
+  const l = a[0] == b[0] ? mod(3n * a[0] * a[0] * inv(2n * a[1], P), P) : mod((b[1] - a[1]) * inv(b[0] - a[0], P), P);
+  const x = mod(l * l - a[0] - b[0], P);
+  return [x, mod(l * (a[0] - x) - a[1], P)];
+}
+function mul(k, point) {
+  let out = null;
+  for (; k > 0n; k >>= 1n, point = add(point, point)) if (k & 1n) out = add(out, point);
+  return out;
+}
+const toBigInt = (buf) => BigInt("0x" + Buffer.from(buf).toString("hex"));
+const toBuffer = (n) => Buffer.from(n.toString(16).padStart(64, "0"), "hex");
+
+// Returns r || s || v with low s and v of 27 or 28, like ethers
+function ecdsaSign(hash, privateKey) {
+  const z = toBigInt(hash);
+  const d = toBigInt(privateKey);
+  const k = mod(toBigInt(crypto.createHmac("sha256", privateKey).update(hash).digest()), N - 1n) + 1n;
+  const R = mul(k, G);
+  const r = mod(R[0], N);
+  let s = mod(inv(k, N) * (z + r * d), N);
+  let recovery = Number(R[1] & 1n);
+  if (s > N / 2n) {
+    s = N - s;
+    recovery ^= 1;
+  }
+  return Buffer.concat([toBuffer(r), toBuffer(s), Buffer.from([27 + recovery])]);
+}
+
+function checksumAddress(address) {
+  const hash = keccak256(Buffer.from(address)).toString("hex");
+  return "0x" + [...address].map((c, i) => (parseInt(hash[i], 16) >= 8 ? c.toUpperCase() : c)).join("");
+}
+
+// TypedEthereumSigner: EIP-712 typed data with the message and the signer's address
+function typedEthereumHash(address, message) {
+  const domainHash = keccak256(
+    keccak256(Buffer.from("EIP712Domain(string name,string version)")),
+    keccak256(Buffer.from("Bundlr")),
+    keccak256(Buffer.from("1")),
+  );
+  const structHash = keccak256(
+    keccak256(Buffer.from("Bundlr(bytes Transaction hash,address address)")),
+    keccak256(message),
+    Buffer.concat([Buffer.alloc(12), Buffer.from(address.slice(2), "hex")]),
+  );
+  return keccak256(Buffer.from([0x19, 0x01]), domainHash, structHash);
+}
+
+function typedEthereumSigner(privateKeyHex) {
+  const privateKey = Buffer.from(privateKeyHex, "hex");
+  const publicKey = mul(toBigInt(privateKey), G);
+  const address = checksumAddress(
+    keccak256(toBuffer(publicKey[0]), toBuffer(publicKey[1])).subarray(12).toString("hex"),
+  );
+  return {
+    address,
+    signatureType: 7,
+    signatureLength: 65,
+    owner: Buffer.from(address),
+    sign: (message) => ecdsaSign(typedEthereumHash(address, message), privateKey),
+  };
+}
+
+// ---- output ----
+
+const typedEthereum = typedEthereumSigner(ETHEREUM_PRIVATE_KEY);
+console.log("typed ethereum address", typedEthereum.address);
+console.log("typed ethereum hash of DATA", typedEthereumHash(typedEthereum.address, DATA).toString("hex"));
+console.log("aptos signature of DATA", aptosSigner(ED25519_SEEDS[0]).sign(DATA).toString("hex"));
+
+const items = {
+  "aptos.bin": createData(aptosSigner(ED25519_SEEDS[0]), { target: TARGET, anchor: ANCHOR }),
+  // 2 of 3, signed by the first and the last key
+  "multi_aptos.bin": createData(multiAptosSigner(ED25519_SEEDS, 2, [0, 2]), { anchor: ANCHOR }),
+  // 2 of 3, signed only by the second key. Accepted by arbundles, it doesn't check the threshold
+  "multi_aptos_below_threshold.bin": createData(multiAptosSigner(ED25519_SEEDS, 2, [1])),
+  "typed_ethereum.bin": createData(typedEthereum, { target: TARGET }),
+};
+for (const [name, { item, id }] of Object.entries(items)) {
+  fs.writeFileSync(name, item);
+  console.log(name, id.toString("base64url"));
+}
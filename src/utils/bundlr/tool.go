@@ -1,6 +1,10 @@
 package bundlr
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+)
 
 func LongTo32ByteArray(long int) (out []byte) {
 	buf := make([]byte, 32)
@@ -19,3 +23,13 @@ func ShortTo2ByteArray(long int) []byte {
 	binary.LittleEndian.PutUint16(buf, uint16(long))
 	return buf
 }
+
+// Parses an amount of winc returned by bundling services as a decimal string
+func ParseWinc(s string) (out int64, err error) {
+	v, ok := new(big.Int).SetString(strings.Trim(strings.TrimSpace(s), `"`), 10)
+	if !ok || !v.IsInt64() {
+		err = ErrFailedToParse
+		return
+	}
+	return v.Int64(), nil
+}
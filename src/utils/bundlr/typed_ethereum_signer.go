@@ -0,0 +1,118 @@
+package bundlr
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethereum_crypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signs data items with EIP-712 typed data, the way browser wallets do it.
+// Owner is the ASCII encoded address of the wallet, not the public key.
+// https://github.com/Irys-xyz/arbundles/blob/master/src/signing/chains/TypedEthereumSigner.ts
+type TypedEthereumSigner struct {
+	PrivateKey *ecdsa.PrivateKey
+	Owner      []byte
+}
+
+var (
+	typedEthereumDomainTypeHash = ethereum_crypto.Keccak256([]byte("EIP712Domain(string name,string version)"))
+	typedEthereumTypeHash       = ethereum_crypto.Keccak256([]byte("Bundlr(bytes Transaction hash,address address)"))
+	typedEthereumDomainHash     = ethereum_crypto.Keccak256(
+		typedEthereumDomainTypeHash,
+		ethereum_crypto.Keccak256([]byte("Bundlr")),
+		ethereum_crypto.Keccak256([]byte("1")),
+	)
+)
+
+func NewTypedEthereumSigner(privateKeyHex string) (self *TypedEthereumSigner, err error) {
+	self = new(TypedEthereumSigner)
+
+	buf, err := hexutil.Decode(privateKeyHex)
+	if err != nil {
+		return
+	}
+
+	self.PrivateKey, err = ethereum_crypto.ToECDSA(buf)
+	if err != nil {
+		return
+	}
+
+	address := ethereum_crypto.PubkeyToAddress(self.PrivateKey.PublicKey)
+	self.Owner = []byte(address.Hex())
+
+	return
+}
+
+// EIP-712 hash of the Bundlr message
+func TypedEthereumHash(address common.Address, data []byte) []byte {
+	structHash := ethereum_crypto.Keccak256(
+		typedEthereumTypeHash,
+		ethereum_crypto.Keccak256(data),
+		common.LeftPadBytes(address.Bytes(), 32),
+	)
+	return ethereum_crypto.Keccak256([]byte{0x19, 0x01}, typedEthereumDomainHash, structHash)
+}
+
+func (self *TypedEthereumSigner) Sign(data []byte) (signature []byte, err error) {
+	address := ethereum_crypto.PubkeyToAddress(self.PrivateKey.PublicKey)
+
+	signature, err = ethereum_crypto.Sign(TypedEthereumHash(address, data), self.PrivateKey)
+	if err != nil {
+		return
+	}
+
+	// Wallets return V as 27 or 28
+	signature[ethereum_crypto.RecoveryIDOffset] += 27
+	return
+}
+
+func (self *TypedEthereumSigner) Verify(data []byte, signature []byte) (err error) {
+	if len(self.Owner) != self.GetOwnerLength() || !common.IsHexAddress(string(self.Owner)) {
+		err = ErrTypedEthereumInvalidOwner
+		return
+	}
+
+	if len(signature) != ethereum_crypto.SignatureLength {
+		err = ErrTypedEthereumSignatureMismatch
+		return
+	}
+
+	// Accept both V encodings
+	sig := make([]byte, ethereum_crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[ethereum_crypto.RecoveryIDOffset] >= 27 {
+		sig[ethereum_crypto.RecoveryIDOffset] -= 27
+	}
+
+	address := common.HexToAddress(string(self.Owner))
+	publicKey, err := ethereum_crypto.SigToPub(TypedEthereumHash(address, data), sig)
+	if err != nil {
+		err = ErrTypedEthereumSignatureMismatch
+		return
+	}
+
+	if ethereum_crypto.PubkeyToAddress(*publicKey) != address {
+		err = ErrTypedEthereumSignatureMismatch
+		return
+	}
+
+	return
+}
+
+func (self *TypedEthereumSigner) GetOwner() []byte {
+	return self.Owner
+}
+
+func (self *TypedEthereumSigner) GetType() SignatureType {
+	return SignatureTypeTypedEthereum
+}
+
+func (self *TypedEthereumSigner) GetSignatureLength() int {
+	return ethereum_crypto.SignatureLength
+}
+
+func (self *TypedEthereumSigner) GetOwnerLength() int {
+	return 2 + 2*common.AddressLength
+}
@@ -0,0 +1,89 @@
+package bundlr
+
+import (
+	"encoding/hex"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Hash and data item produced by testdata/generate.js
+const (
+	ETHEREUM_ADDRESS            = `0xd5e099c71B797516c10ED0F0d895f429C2781142`
+	TYPED_ETHEREUM_HASH         = `e61c4486aaa18fc4f36b3d04499ac3804ca010bada0d218eb2dfdcceddcec3c7`
+	TYPED_ETHEREUM_DATA_ITEM    = `typed_ethereum.bin`
+	TYPED_ETHEREUM_DATA_ITEM_ID = `Yfz-EWmZbopogaJ_wInEFUnCUxAh2_EMX_s1CGKMEWM`
+)
+
+func TestTypedEthereumSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(TypedEthereumSignerTestSuite))
+}
+
+type TypedEthereumSignerTestSuite struct {
+	suite.Suite
+	signer *TypedEthereumSigner
+}
+
+func (s *TypedEthereumSignerTestSuite) SetupSuite() {
+	var err error
+	s.signer, err = NewTypedEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+}
+
+func (s *TypedEthereumSignerTestSuite) TestCreation() {
+	require.Equal(s.T(), ETHEREUM_ADDRESS, string(s.signer.GetOwner()))
+	require.Equal(s.T(), s.signer.GetOwnerLength(), len(s.signer.GetOwner()))
+}
+
+func (s *TypedEthereumSignerTestSuite) TestHash() {
+	hash := TypedEthereumHash(common.HexToAddress(ETHEREUM_ADDRESS), []byte("to be signed"))
+	require.Equal(s.T(), TYPED_ETHEREUM_HASH, hex.EncodeToString(hash))
+}
+
+func (s *TypedEthereumSignerTestSuite) TestDataItem() {
+	item := readTestDataItem(s.T(), TYPED_ETHEREUM_DATA_ITEM)
+	require.Equal(s.T(), SignatureTypeTypedEthereum, item.SignatureType)
+	require.Equal(s.T(), TYPED_ETHEREUM_DATA_ITEM_ID, item.Id.Base64())
+	require.Equal(s.T(), s.signer.GetOwner(), []byte(item.Owner))
+	require.Nil(s.T(), item.VerifySignature())
+
+	// Data changed after signing
+	item.Data = []byte("other")
+	require.ErrorIs(s.T(), item.VerifySignature(), ErrTypedEthereumSignatureMismatch)
+}
+
+func (s *TypedEthereumSignerTestSuite) TestVector() {
+	data := []byte("to be signed")
+
+	signature, err := s.signer.Sign(data)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), s.signer.GetSignatureLength(), len(signature))
+
+	verifier, err := GetSigner(SignatureTypeTypedEthereum, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), verifier.Verify(data, signature))
+	require.ErrorIs(s.T(), verifier.Verify([]byte("other"), signature), ErrTypedEthereumSignatureMismatch)
+
+	// Lowercase address is accepted as well
+	verifier, err = GetSigner(SignatureTypeTypedEthereum, []byte("0xd5e099c71b797516c10ed0f0d895f429c2781142"))
+	require.Nil(s.T(), err)
+	require.Nil(s.T(), verifier.Verify(data, signature))
+}
+
+func (s *TypedEthereumSignerTestSuite) TestAddress() {
+	address, err := GetAddress(SignatureTypeTypedEthereum, s.signer.GetOwner())
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), ETHEREUM_ADDRESS, address)
+
+	ethereumSigner, err := NewEthereumSigner(ETHEREUM_PRIVATE_KEY)
+	require.Nil(s.T(), err)
+
+	// Plain Ethereum items keep the SHA-256 based address
+	address, err = GetAddress(SignatureTypeEthereum, ethereumSigner.GetOwner())
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), "TjVUo-lR3ozlZlMqYIJYqGAihbxM_nEYMidq8gywJNA", address)
+}
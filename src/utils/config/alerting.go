@@ -0,0 +1,86 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Declarative alerts evaluated periodically against the monitor's report
+type Alerting struct {
+	// If false rules aren't evaluated
+	Enabled bool
+
+	// How often rules are evaluated
+	Interval time.Duration
+
+	// Firing alert is sent again after this long, 0 means it's sent only once until resolved
+	RepeatInterval time.Duration
+
+	// Timeout of sending an alert to a single sink
+	SinkTimeout time.Duration
+
+	Rules []AlertRule
+
+	// No sinks means alerts are only logged
+	Sinks []AlertSink
+}
+
+type AlertRule struct {
+	// Unique name of the rule, used to deduplicate alerts
+	Name string
+
+	// Evaluated only against the report of this mode (see the run command), empty means all reports having the values
+	Mode string
+
+	// Path to a numeric field in the report, using JSON names, e.g. syncer.state.finished_height
+	Value string
+
+	// Optional path subtracted from Value, e.g. to get the lag behind the network height
+	Minus string
+
+	// If true the change of Value per minute is compared instead of Value itself
+	Rate bool
+
+	// One of: >, >=, <, <=, ==, !=
+	Operator string
+
+	Threshold float64
+
+	// Condition needs to hold for this long before the alert fires
+	For time.Duration
+
+	// Passed to sinks, e.g. warning or critical
+	Severity string
+
+	// Human readable description sent along with the alert
+	Description string
+}
+
+type AlertSink struct {
+	// One of: log, webhook (alert as JSON), slack (Slack-compatible JSON with a text message)
+	Type string
+
+	// Endpoint receiving alerts in a POST request, used with webhook and slack
+	Url string
+}
+
+// Some rule is evaluated against the value at the path, e.g. bundler.state.pending_bundle_items
+func (self *Alerting) UsesValue(path string) bool {
+	if !self.Enabled {
+		return false
+	}
+	for _, rule := range self.Rules {
+		if rule.Value == path || rule.Minus == path {
+			return true
+		}
+	}
+	return false
+}
+
+func setAlertingDefaults() {
+	viper.SetDefault("Alerting.Enabled", "false")
+	viper.SetDefault("Alerting.Interval", "30s")
+	viper.SetDefault("Alerting.RepeatInterval", "0")
+	viper.SetDefault("Alerting.SinkTimeout", "10s")
+}
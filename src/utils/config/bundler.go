@@ -59,6 +59,9 @@ type Bundler struct {
 
 	// Number of workers that send bundles in parallel
 	BundlerNumBundlingWorkers int
+
+	// Priority lanes and fair scheduling between contracts
+	Scheduler Scheduler
 }
 
 func setBundlerDefaults() {
@@ -79,4 +82,6 @@ func setBundlerDefaults() {
 	viper.SetDefault("Bundler.ConfirmerInterval", "1s")
 	viper.SetDefault("Bundler.ConfirmerBackoffMaxElapsedTime", "0")
 	viper.SetDefault("Bundler.ConfirmerBackoffMaxInterval", "8s")
+
+	setSchedulerDefaults("Bundler")
 }
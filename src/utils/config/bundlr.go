@@ -13,6 +13,9 @@ type Bundlr struct {
 	// List of urls that can be used to upload bundles, order matters
 	TurboUrls []string
 
+	// Url of the Turbo payment service, used for checking the wallet balance
+	TurboPaymentUrl string
+
 	// Power of Turbo. TurboSendFactor + IrysSendFactor is 100%. If set to 0, skips sending to this provider.
 	// This is a way to divide traffic between bundle providers
 	TurboSendProbability int
@@ -47,13 +50,29 @@ type Bundlr struct {
 	// Max num requests to particular peer per interval
 	LimiterBurstSize int
 
-	// Wallet used to signing transactions
+	// Wallet used to signing transactions. Inline JWK or a file://, env:// or signer:// reference
 	Wallet string
+
+	// Disables periodic checks of the wallet balance
+	BalanceMonitorDisabled bool
+
+	// How often is the wallet balance checked
+	BalanceMonitorInterval time.Duration
+
+	// Balance (in winc) below which Irys is considered unhealthy. 0 disables the check
+	IrysMinBalance int64
+
+	// Balance (in winc) below which Turbo is considered unhealthy. 0 disables the check
+	TurboMinBalance int64
+
+	// How often cached Irys prices are refreshed in the background
+	IrysPriceCacheTTL time.Duration
 }
 
 func setBundlrDefaults() {
 	viper.SetDefault("Bundlr.Urls", []string{"https://node1.bundlr.network"})
 	viper.SetDefault("Bundlr.TurboUrls", []string{"https://upload.ardrive.dev"})
+	viper.SetDefault("Bundlr.TurboPaymentUrl", "https://payment.ardrive.dev")
 	viper.SetDefault("Bundlr.TurboSendProbability", "0")
 	viper.SetDefault("Bundlr.IrysSendProbability", "100")
 	viper.SetDefault("Bundlr.RequestTimeout", "60s")
@@ -64,6 +83,11 @@ func setBundlrDefaults() {
 	viper.SetDefault("Bundlr.TLSHandshakeTimeout", "10s")
 	viper.SetDefault("Bundlr.LimiterInterval", "1ms")
 	viper.SetDefault("Bundlr.LimiterBurstSize", "1000000")
+	viper.SetDefault("Bundlr.BalanceMonitorDisabled", "false")
+	viper.SetDefault("Bundlr.BalanceMonitorInterval", "1m")
+	viper.SetDefault("Bundlr.IrysMinBalance", "0")
+	viper.SetDefault("Bundlr.TurboMinBalance", "0")
+	viper.SetDefault("Bundlr.IrysPriceCacheTTL", "10m")
 	// This is an empty wallet
 	viper.SetDefault("Bundlr.Wallet", `{
 		"d": "IVv3IzUPbj2yJP9qqJcH3cVI86jWdhZCpNoomLeJaH0rpKnujzlDSADC2yuFNBnS_sIthk1-w83_bkTwwOOCAn_9LZbkKYEd2onZ7iWAh--tMB5ijNHv0acn64TZjS-5aH6WgfsxwCjrXj57ejnh7GaterucVpTX_RlGtpp5IWY5ISM-5JLBm2wLLnXjhsJD51a03eClxy0MAclG6suOkm2pRF7yl1sJjQ23kZ7xExpO-Lb_j8o1JEGao5xI1TPWdJyovuhPrWK14l3JXU9URz6IKFH9xuvbWjqWhyVQVjUBBWg5B5DbzQhI_6tPVHb8eUBP9L9BNkRyr5cWU1SCYynzEa9_1cXjLuYNtTUB9358bkveYiZRlvSjCYoNd6lSFtESbyMfvmU2FF7gnduVqzdTPuisfHHNYQKCall-emCt9Oiy26OJ2uMX-dfqutcZd65OlJN5KG65h6D8cp7xjDlwHx4VeK2qI-dyzOS6ufZlG0nrNEfzRDekmRsFCgZxJUjc0JjCMde5LRKZhsmltntizeaURw69dnNTrtrLFQLlo6X3wEHzyjFNqaqJDQmB6UnpdOjZp6FeotV02FpeqhJZ8pA1kYywO9LFB-iciy7h-bufHoK5Owti-CwOMADdwzYPPaKrbhc7ZhAuogQTMfFSHJtL5_le_Y-k8FTtu4E",
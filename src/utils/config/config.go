@@ -41,8 +41,16 @@ type Config struct {
 	Checker               Checker
 	Database              Database
 	ReadOnlyDatabase      Database
+	Replication           Replication
+	Tracing               Tracing
+	DeadLetter            DeadLetter
+	Watchdog              Watchdog
+	LeaderElection        LeaderElection
+	Alerting              Alerting
+	Freshness             Freshness
 	Contract              Contract
 	Redis                 []Redis
+	Webhook               []Webhook
 	AppSync               AppSync
 	Forwarder             Forwarder
 	Relayer               Relayer
@@ -51,6 +59,10 @@ type Config struct {
 	Interactor            Interactor
 	Evolver               Evolver
 	WarpySyncer           WarpySyncer
+	Signer                Signer
+
+	// Mode the configuration is used by, not loaded from the file
+	mode string
 }
 
 func setDefaults() {
@@ -71,6 +83,13 @@ func setDefaults() {
 	setCheckerDefaults()
 	setDatabaseDefaults()
 	setReadOnlyDatabaseDefaults()
+	setReplicationDefaults()
+	setTracingDefaults()
+	setDeadLetterDefaults()
+	setWatchdogDefaults()
+	setLeaderElectionDefaults()
+	setAlertingDefaults()
+	setFreshnessDefaults()
 	setContractDefaults()
 	setRedisDefaults()
 	setAppSyncDefaults()
@@ -81,6 +100,7 @@ func setDefaults() {
 	setSequencerDefaults()
 	setEvolverDefaults()
 	setWarpySyncerDefaults()
+	setSignerDefaults()
 }
 
 func Default() (config *Config) {
@@ -88,6 +108,20 @@ func Default() (config *Config) {
 	return
 }
 
+// Copy of the configuration used by the given mode. Modes run by one process share everything but the mode.
+func (self *Config) WithMode(mode string) *Config {
+	out := *self
+	out.mode = mode
+	return &out
+}
+
+func (self *Config) Mode() string {
+	if self == nil {
+		return ""
+	}
+	return self.mode
+}
+
 func IsIndex(s string) bool {
 	for _, c := range s {
 		if c < '0' || c > '9' {
@@ -99,44 +133,28 @@ func IsIndex(s string) bool {
 
 func BindEnv(path []string, val reflect.Value) {
 	if val.Kind() == reflect.Slice {
-		_, ok := val.Interface().([]Redis)
-		if ok {
+		elem := val.Type().Elem()
+		if elem.Kind() == reflect.Struct {
+			// Slice of structs, e.g. []Redis
 			for i := 0; i < MAX_SLICE_LEN; i++ {
 				newPath := make([]string, len(path))
 				copy(newPath, path)
 				newPath = append(newPath, fmt.Sprintf("%d", i))
-				BindEnv(newPath, reflect.ValueOf(Redis{}))
+				BindEnv(newPath, reflect.New(elem).Elem())
 			}
 		} else {
 			// Slice of base types
-			key := strings.ToLower(strings.Join(path, "."))
-			env := "SYNCER_" + strcase.ToScreamingSnake(strings.Join(path, "_"))
-			err := viper.BindEnv(key, env)
-			if err != nil {
-				panic(err)
-			}
+			bindEnvKey(path)
 		}
 	} else if val.Kind() != reflect.Struct {
 		// Base types
-		// key := strings.ToLower(strings.Join(path, "."))
-		key := path[0]
-		for _, p := range path[1:] {
-			if IsIndex(p) {
-				key += "[" + p + "]"
-				// key += "." + p
-			} else {
-				key += "." + p
-			}
-		}
-
-		env := "SYNCER_" + strcase.ToScreamingSnake(strings.Join(path, "_"))
-		err := viper.BindEnv(key, env)
-		if err != nil {
-			panic(err)
-		}
+		bindEnvKey(path)
 	} else {
 		// Iterates over struct fields
 		for i := 0; i < val.NumField(); i++ {
+			if !val.Type().Field(i).IsExported() {
+				continue
+			}
 			newPath := make([]string, len(path))
 			copy(newPath, path)
 			newPath = append(newPath, val.Type().Field(i).Name)
@@ -145,6 +163,24 @@ func BindEnv(path []string, val reflect.Value) {
 	}
 }
 
+// Binds the key to the upper snake case ENV name, indexes in the path are elements of slices of structs
+func bindEnvKey(path []string) {
+	key := path[0]
+	for _, p := range path[1:] {
+		if IsIndex(p) {
+			key += "[" + p + "]"
+		} else {
+			key += "." + p
+		}
+	}
+
+	env := "SYNCER_" + strcase.ToScreamingSnake(strings.Join(path, "_"))
+	err := viper.BindEnv(key, env)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func getSliceLength(key string) int {
 	var max int
 	for viperKey := range viper.AllSettings() {
@@ -185,6 +221,7 @@ func Load(filename string) (config *Config, err error) {
 	// Visits every field and registers upper snake case ENV name for it
 	// Works with embedded structs
 	BindEnv([]string{}, reflect.ValueOf(Config{}))
+	bindRelayerDeprecatedEnv()
 
 	// Empty filename means we use default values
 	if filename != "" {
@@ -199,8 +236,16 @@ func Load(filename string) (config *Config, err error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// Needed to watch the file for changes
+		viper.SetConfigFile(filename)
 	}
 
+	return unmarshal()
+}
+
+// Builds configuration from values already read by viper
+func unmarshal() (config *Config, err error) {
 	config = new(Config)
 	err = viper.Unmarshal(&config)
 	if err != nil {
@@ -212,5 +257,15 @@ func Load(filename string) (config *Config, err error) {
 		return nil, err
 	}
 
+	err = unmarshalWebhook(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = unmarshalRelayer(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return
 }
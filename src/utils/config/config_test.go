@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -44,3 +45,162 @@ func TestLoadConfigFromEnv(t *testing.T) {
 
 	assert.Equal(t, "warp", c.Database.Name)
 }
+
+func TestLoadWebhookFromEnv(t *testing.T) {
+	os.Setenv("SYNCER_WEBHOOK_0_URL", "https://example.com/hook")
+	os.Setenv("SYNCER_WEBHOOK_0_BATCH_SIZE", "5")
+	defer os.Unsetenv("SYNCER_WEBHOOK_0_URL")
+	defer os.Unsetenv("SYNCER_WEBHOOK_0_BATCH_SIZE")
+
+	c, err := Load("")
+	assert.Nil(t, err)
+
+	assert.Len(t, c.Webhook, 1)
+	assert.Equal(t, "https://example.com/hook", c.Webhook[0].Url)
+	assert.Equal(t, 5, c.Webhook[0].BatchSize)
+
+	// Defaults of the fields that weren't set
+	assert.Equal(t, time.Second, c.Webhook[0].BatchMaxInterval)
+}
+
+func TestLoadDeprecatedSequencerUrlFromEnv(t *testing.T) {
+	os.Setenv("SYNCER_RELAYER_SEQUENCER_URL", "tcp://sequencer:26657")
+	defer os.Unsetenv("SYNCER_RELAYER_SEQUENCER_URL")
+
+	c, err := Load("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tcp://sequencer:26657"}, c.Relayer.SequencerUrls)
+
+	// Both keys with different urls
+	os.Setenv("SYNCER_RELAYER_SEQUENCER_URLS", "tcp://a:26657,tcp://b:26657")
+	defer os.Unsetenv("SYNCER_RELAYER_SEQUENCER_URLS")
+
+	_, err = Load("")
+	assert.ErrorContains(t, err, "Relayer.SequencerUrl")
+}
+
+func TestLoadRedisFilterFromEnv(t *testing.T) {
+	os.Setenv("SYNCER_REDIS_0_FILTER_INCLUDE_CONTRACTS", "contract1,contract2")
+	os.Setenv("SYNCER_FORWARDER_PUBLISHER_APP_SYNC_FILTER_EXCLUDE_FUNCTIONS", "transfer")
+	defer os.Unsetenv("SYNCER_REDIS_0_FILTER_INCLUDE_CONTRACTS")
+	defer os.Unsetenv("SYNCER_FORWARDER_PUBLISHER_APP_SYNC_FILTER_EXCLUDE_FUNCTIONS")
+
+	c, err := Load("")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"contract1", "contract2"}, c.Redis[0].Filter.IncludeContracts)
+	assert.Empty(t, c.Redis[0].Filter.ExcludeContracts)
+	assert.Equal(t, []string{"transfer"}, c.Forwarder.PublisherAppSyncFilter.ExcludeFunctions)
+}
+
+func TestRedacted(t *testing.T) {
+	c, err := Load("")
+	assert.Nil(t, err)
+	c.Database.Password = "secret"
+	c.Bundlr.Wallet = "file:///run/secrets/wallet.json"
+	c.Signer.Keys = []string{"default:arweave:{\"kty\":\"RSA\"}", "eth:ethereum:env://ETH_KEY"}
+
+	redacted := c.Redacted()
+
+	database := redacted["Database"].(map[string]any)
+	assert.Equal(t, "***", database["Password"])
+	assert.Equal(t, "127.0.0.1", database["Host"])
+	assert.Equal(t, "15s", database["PingTimeout"])
+
+	// References aren't secret
+	assert.Equal(t, "file:///run/secrets/wallet.json", redacted["Bundlr"].(map[string]any)["Wallet"])
+	assert.Equal(t, []any{"default:arweave:***", "eth:ethereum:env://ETH_KEY"}, redacted["Signer"].(map[string]any)["Keys"])
+
+	// Original isn't modified
+	assert.Equal(t, "secret", c.Database.Password)
+
+	c.WarpySyncer.WriterApiKey = "writer-key"
+	redacted = c.Redacted()
+	assert.Equal(t, "***", redacted["WarpySyncer"].(map[string]any)["WriterApiKey"])
+
+	// Alert sink URLs carry tokens, webhook URLs aren't secret
+	c.Alerting.Sinks = []AlertSink{{Type: "slack", Url: "https://hooks.slack.com/services/T/B/token"}}
+	c.Webhook = []Webhook{{Url: "https://example.com/hook"}}
+	redacted = c.Redacted()
+	assert.Equal(t, "***", redacted["Alerting"].(map[string]any)["Sinks"].([]any)[0].(map[string]any)["Url"])
+	assert.Equal(t, "https://example.com/hook", redacted["Webhook"].([]any)[0].(map[string]any)["Url"])
+}
+
+func TestAlertingUsesValue(t *testing.T) {
+	alerting := Alerting{
+		Rules: []AlertRule{{Value: "bundler.state.pending_bundle_items_age"}, {Value: "syncer.state.network_height", Minus: "syncer.state.finished_height"}},
+	}
+	assert.False(t, alerting.UsesValue("bundler.state.pending_bundle_items_age"))
+
+	alerting.Enabled = true
+	assert.True(t, alerting.UsesValue("bundler.state.pending_bundle_items_age"))
+	assert.True(t, alerting.UsesValue("syncer.state.finished_height"))
+	assert.False(t, alerting.UsesValue("bundler.state.pending_bundle_items"))
+}
+
+func TestValidateReloaded(t *testing.T) {
+	c, err := Load("")
+	assert.Nil(t, err)
+
+	c.Syncer.StoreBatchSize = 0
+	assert.Nil(t, validateReloaded(c, nil))
+	assert.Nil(t, validateReloaded(c, []string{"forward"}))
+	assert.ErrorContains(t, validateReloaded(c, []string{"forward", "sync"}), "Syncer.StoreBatchSize")
+
+	// Commands that aren't modes only check the common sections
+	SetReloadModes("show", "sync")
+	defer SetReloadModes()
+	assert.Equal(t, []string{"sync"}, watchModes)
+}
+
+func TestValidate(t *testing.T) {
+	c, err := Load("")
+	assert.Nil(t, err)
+
+	for _, mode := range append(GetModes(), "") {
+		assert.Nil(t, c.Validate(mode), mode)
+	}
+
+	c.Syncer.StoreBatchSize = 0
+	c.Database.MaxIdleConns = c.Database.MaxOpenConns + 1
+	c.Watchdog.BackoffMaxInterval = -time.Second
+	c.LeaderElection.Enabled = true
+	c.LeaderElection.RenewInterval = c.LeaderElection.LeaseDuration
+	err = c.Validate("sync")
+	assert.ErrorContains(t, err, "Syncer.StoreBatchSize: must be positive")
+	assert.ErrorContains(t, err, "Database: MaxIdleConns")
+	assert.ErrorContains(t, err, "Watchdog.BackoffMaxInterval: must not be negative")
+	assert.ErrorContains(t, err, "LeaderElection: RenewInterval must be shorter than LeaseDuration")
+	assert.ErrorContains(t, err, "LeaderElection: LeaseDuration must be longer than RenewInterval and StopTimeout together")
+
+	// Sections of other modes aren't checked, common ones are
+	err = c.Validate("signer")
+	assert.ErrorContains(t, err, "Watchdog")
+	assert.NotContains(t, err.Error(), "Syncer")
+
+	assert.NotNil(t, c.Validate("unknown"))
+}
+
+func TestSchema(t *testing.T) {
+	schema := Schema()
+	properties := schema["properties"].(map[string]any)
+
+	syncer := properties["Syncer"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "integer", syncer["StoreBatchSize"].(map[string]any)["type"])
+	assert.Equal(t, "duration", syncer["StoreMaxTimeInQueue"].(map[string]any)["format"])
+
+	redis := properties["Redis"].(map[string]any)
+	assert.Equal(t, "array", redis["type"])
+	assert.Equal(t, "object", redis["items"].(map[string]any)["type"])
+}
+
+func TestWithMode(t *testing.T) {
+	c := Default()
+	sync := c.WithMode("sync")
+	assert.Equal(t, "sync", sync.Mode())
+	assert.Equal(t, "", c.Mode())
+	assert.Equal(t, c.Syncer, sync.Syncer)
+
+	var nilConfig *Config
+	assert.Equal(t, "", nilConfig.Mode())
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Items that pipeline stores failed to process or save are kept for inspection and replay
+type DeadLetter struct {
+	// If false failed items are only logged
+	Enabled bool
+
+	// Where failed items are written: "db" (dead_letters table) or "file" (NDJSON)
+	Sink string
+
+	// Path of the NDJSON file, used with the "file" sink
+	FilePath string
+}
+
+func setDeadLetterDefaults() {
+	viper.SetDefault("DeadLetter.Enabled", "false")
+	viper.SetDefault("DeadLetter.Sink", "db")
+	viper.SetDefault("DeadLetter.FilePath", "dead_letters.ndjson")
+}
@@ -0,0 +1,28 @@
+package config
+
+// Selects interactions passed to a publisher.
+// Empty include list matches everything, exclude lists take precedence over include lists.
+type Filter struct {
+	// Contract ids
+	IncludeContracts []string
+	ExcludeContracts []string
+
+	// Contract source ids
+	IncludeSources []string
+	ExcludeSources []string
+
+	// Names of the called functions
+	IncludeFunctions []string
+	ExcludeFunctions []string
+
+	// Interaction tags, either "name" or "name=value"
+	IncludeTags []string
+	ExcludeTags []string
+}
+
+func (self *Filter) IsEmpty() bool {
+	return len(self.IncludeContracts) == 0 && len(self.ExcludeContracts) == 0 &&
+		len(self.IncludeSources) == 0 && len(self.ExcludeSources) == 0 &&
+		len(self.IncludeFunctions) == 0 && len(self.ExcludeFunctions) == 0 &&
+		len(self.IncludeTags) == 0 && len(self.ExcludeTags) == 0
+}
@@ -22,6 +22,9 @@ type Forwarder struct {
 	// Interactions are saved to this AppSync channel
 	PublisherAppSyncChannelName string
 
+	// Interactions published to AppSync, reloaded upon configuration file change
+	PublisherAppSyncFilter Filter
+
 	// How long to wait before after receiving a new block height before sending L1 interactions
 	// This delay ensures sequencer finishes handling requests in time
 	HeightDelay time.Duration
@@ -37,6 +40,20 @@ type Forwarder struct {
 	// How many L2 interactions are cached in queue
 	// Those are L2 interactions streamed live from the database
 	InteractionsStreamerQueueSize int
+
+	// If true, sort key of the last delivered interaction is saved for each publisher.
+	// Upon start interactions that weren't delivered are replayed before the live ones
+	OutboxEnabled bool
+
+	// How often delivery state is saved to the database
+	OutboxFlushInterval time.Duration
+
+	// Max number of interactions taken by a publisher and not delivered yet.
+	// When it's reached the oldest one is treated as failed and replayed after a restart
+	OutboxMaxPending int
+
+	// How many interactions are fetched from the DB at once during the replay
+	ReplayBatchSize int
 }
 
 func setForwarderDefaults() {
@@ -49,4 +66,8 @@ func setForwarderDefaults() {
 	viper.SetDefault("Forwarder.ArweaveFetcherQueueSize", "3000")
 	viper.SetDefault("Forwarder.ArweaveFetcherBlockSendTimeout", "300s")
 	viper.SetDefault("Forwarder.InteractionsStreamerQueueSize", "10")
+	viper.SetDefault("Forwarder.OutboxEnabled", "true")
+	viper.SetDefault("Forwarder.OutboxFlushInterval", "1s")
+	viper.SetDefault("Forwarder.OutboxMaxPending", "100000")
+	viper.SetDefault("Forwarder.ReplayBatchSize", "100")
 }
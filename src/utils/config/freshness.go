@@ -0,0 +1,50 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// End-to-end freshness of interactions, measured from the Arweave block timestamp (L1)
+// or the sequencer block timestamp (L2) to each stage that handles the interaction
+type Freshness struct {
+	// If false latencies aren't measured
+	Enabled bool
+
+	// Share of interactions whose latency is measured, between 0 and 1.
+	// Every stage samples the same interactions, so their samples can be joined
+	SampleRatio float64
+
+	// Share of sampled interactions that need to be within the target, e.g. 0.99
+	Objective float64
+
+	// Latency targets, counted from the origin of the pipeline
+	L1Target time.Duration
+	L2Target time.Duration
+
+	// Interactions older than this are handled while catching up (e.g. after downtime or a replay), they aren't sampled.
+	// 0 means no limit
+	CatchUpThreshold time.Duration
+
+	// How often samples are saved to the database, for the slo command
+	FlushInterval time.Duration
+
+	// Max number of samples waiting to be saved, new samples are dropped when it's reached
+	MaxBufferSize int
+
+	// Samples older than this are deleted
+	Retention time.Duration
+}
+
+func setFreshnessDefaults() {
+	viper.SetDefault("Freshness.Enabled", "false")
+	viper.SetDefault("Freshness.SampleRatio", "0.1")
+	viper.SetDefault("Freshness.Objective", "0.99")
+	viper.SetDefault("Freshness.L1Target", "40m")
+	viper.SetDefault("Freshness.L2Target", "2h")
+	viper.SetDefault("Freshness.CatchUpThreshold", "24h")
+	viper.SetDefault("Freshness.FlushInterval", "30s")
+	viper.SetDefault("Freshness.MaxBufferSize", "10000")
+	viper.SetDefault("Freshness.Retention", "168h")
+}
@@ -13,7 +13,7 @@ type Interactor struct {
 	// How often to generate data item
 	GeneratorInterval time.Duration
 
-	// Key/wallet used to sign the generated data item
+	// Key/wallet used to sign the generated data item. Inline key or a file://, env:// or signer:// reference
 	GeneratorEthereumKey string
 
 	// How often to check for processed data items in database
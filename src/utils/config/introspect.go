@@ -0,0 +1,144 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const redacted = "***"
+
+// Fields holding credentials or private keys, redacted wherever they're used
+var secretFields = map[string]struct{}{
+	"Password":             {},
+	"MigrationPassword":    {},
+	"ClientKey":            {},
+	"Wallet":               {},
+	"Secret":               {},
+	"Token":                {},
+	"AuthToken":            {},
+	"Keys":                 {},
+	"GeneratorEthereumKey": {},
+	"SyncerSigner":         {},
+	"SyncerApiKey":         {},
+	"SyncerRpcApiKey":      {},
+	"WriterApiKey":         {},
+}
+
+// Fields secret only in the given struct, e.g. Slack webhook URLs carry the token
+var secretTypeFields = map[string]struct{}{
+	"AlertSink.Url": {},
+}
+
+// References to keys kept elsewhere aren't secret themselves
+var secretReferencePrefixes = []string{"file://", "env://", "signer://"}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Effective configuration as a tree of maps, with secrets redacted and durations formatted as strings.
+// Safe to be printed or logged
+func (self *Config) Redacted() map[string]any {
+	return toMap(reflect.ValueOf(*self), "").(map[string]any)
+}
+
+// Secret is the name of the secret field the value belongs to, empty if it isn't secret
+func toMap(val reflect.Value, secret string) any {
+	switch {
+	case val.Type() == durationType:
+		return time.Duration(val.Int()).String()
+	case val.Kind() == reflect.Struct:
+		out := make(map[string]any, val.NumField())
+		for i := 0; i < val.NumField(); i++ {
+			field := val.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			secret := ""
+			if _, ok := secretFields[field.Name]; ok {
+				secret = field.Name
+			}
+			if _, ok := secretTypeFields[val.Type().Name()+"."+field.Name]; ok {
+				secret = field.Name
+			}
+			out[field.Name] = toMap(val.Field(i), secret)
+		}
+		return out
+	case val.Kind() == reflect.Slice:
+		out := make([]any, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			out = append(out, toMap(val.Index(i), secret))
+		}
+		return out
+	case val.Kind() == reflect.String && secret == "Keys":
+		// Signer keys are in format name:type:key, only the key is secret
+		if parts := strings.SplitN(val.String(), ":", 3); len(parts) == 3 {
+			return parts[0] + ":" + parts[1] + ":" + redact(parts[2])
+		}
+		return redact(val.String())
+	case val.Kind() == reflect.String && secret != "":
+		return redact(val.String())
+	default:
+		return val.Interface()
+	}
+}
+
+func redact(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, prefix := range secretReferencePrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return s
+		}
+	}
+	return redacted
+}
+
+// JSON schema (draft 2020-12) of the configuration.
+// Durations are strings parsed by time.ParseDuration
+func Schema() map[string]any {
+	schema := schemaOf(reflect.TypeOf(Config{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Syncer configuration"
+	return schema
+}
+
+func schemaOf(t reflect.Type) (out map[string]any) {
+	out = make(map[string]any)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			properties[field.Name] = schemaOf(field.Type)
+		}
+		out["type"] = "object"
+		out["properties"] = properties
+		return
+	case reflect.Slice:
+		out["type"] = "array"
+		out["items"] = schemaOf(t.Elem())
+	case reflect.Bool:
+		out["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == durationType {
+			// e.g. "1m30s", plain numbers are nanoseconds
+			out["type"] = []string{"string", "integer"}
+			out["format"] = "duration"
+		} else {
+			out["type"] = "integer"
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		out["type"] = "integer"
+		out["minimum"] = 0
+	case reflect.Float32, reflect.Float64:
+		out["type"] = "number"
+	default:
+		out["type"] = "string"
+	}
+	return
+}
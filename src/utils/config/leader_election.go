@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Active/standby replicas of block-following modes (sync, contract, relay).
+// Only the replica holding the lease runs the watched pipeline
+type LeaderElection struct {
+	// If false every replica runs the pipeline
+	Enabled bool
+
+	// Lease expires if the leader doesn't renew it for this long, then a standby takes over.
+	// Leader that fails to renew steps down RenewInterval + StopTimeout before the expiration,
+	// so its pipeline is stopped before the standby starts. Needs to be longer than that
+	LeaseDuration time.Duration
+
+	// How often the leader renews the lease and standbys try to acquire it
+	RenewInterval time.Duration
+
+	// Identity of this replica stored in the lease. Empty means hostname and pid
+	Holder string
+}
+
+func setLeaderElectionDefaults() {
+	viper.SetDefault("LeaderElection.Enabled", "false")
+	viper.SetDefault("LeaderElection.LeaseDuration", "45s")
+	viper.SetDefault("LeaderElection.RenewInterval", "3s")
+	viper.SetDefault("LeaderElection.Holder", "")
+}
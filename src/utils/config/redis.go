@@ -37,6 +37,16 @@ type Redis struct {
 
 	// Max num of requests in worker's queue
 	MaxQueueSize int
+
+	// If true, messages are added to a stream named after the channel (XADD) instead of being published.
+	// Entry ids are derived from sort keys, so consumer groups can resume after a restart
+	StreamEnabled bool
+
+	// Approximate max number of entries kept in the stream, 0 is no limit
+	StreamMaxLen int64
+
+	// Forwarded interactions published to this instance, reloaded upon configuration file change
+	Filter Filter
 }
 
 func unmarshalRedis(config *Config) error {
@@ -60,6 +70,8 @@ func unmarshalRedis(config *Config) error {
 			MaxInterval:     60 * time.Second,
 			MaxWorkers:      15,
 			MaxQueueSize:    1,
+			StreamEnabled:   false,
+			StreamMaxLen:    1000000,
 		}
 
 		decoder, err := mapstructure.NewDecoder(defaultDecoderConfig(&data))
@@ -94,4 +106,6 @@ func setRedisDefaults() {
 	viper.SetDefault("Redis[0].MaxInterval", "60s")
 	viper.SetDefault("Redis[0].MaxWorkers", "15")
 	viper.SetDefault("Redis[0].MaxQueueSize", "10")
+	viper.SetDefault("Redis[0].StreamEnabled", "false")
+	viper.SetDefault("Redis[0].StreamMaxLen", "1000000")
 }
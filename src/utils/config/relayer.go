@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/spf13/viper"
@@ -10,8 +12,18 @@ type Relayer struct {
 	// Where is the relayer started (dev, main, test)
 	Environment string
 
-	// Websocket url of the Warp's sequencer
-	SequencerUrl string
+	// Urls of Warp's sequencer nodes. Websocket subscription follows the best one, historical blocks are fetched from all of them.
+	// Deprecated Relayer.SequencerUrl is still accepted as a single url
+	SequencerUrls []string
+
+	// How often sequencer nodes are checked
+	SequencerHealthCheckInterval time.Duration
+
+	// Max time for the health check request
+	SequencerHealthCheckTimeout time.Duration
+
+	// Node that's more blocks behind the highest one is considered unhealthy
+	SequencerMaxHeightLag int64
 
 	// How many incomming events should be stored in channel
 	SequencerQueueSize int
@@ -50,11 +62,80 @@ type Relayer struct {
 
 	// Max time between failed retries to save data.
 	StoreMaxBackoffInterval time.Duration
+
+	// Max time of retries to save data. Then interactions are saved in smaller batches
+	// and the ones that still fail go to the dead letter sink. 0 means no limit.
+	StoreMaxBackoffElapsedTime time.Duration
+
+	// Verify sequencer's blocks with CometBFT light client before decoding them
+	LightClientEnabled bool
+
+	// Chain id of the sequencer. Taken from the node's status if empty
+	LightClientChainId string
+
+	// Trusted checkpoint. Blocks below it are verified backwards, through the chain of hashes
+	LightClientTrustedHeight int64
+
+	// Hex encoded hash of the block at the trusted height
+	LightClientTrustedHash string
+
+	// How long a verified header can be trusted, should be shorter than the unbonding period.
+	// Relayer that stays down longer than this needs a newer checkpoint
+	LightClientTrustingPeriod time.Duration
+
+	// Max time a block can be ahead of the local clock
+	LightClientMaxClockDrift time.Duration
+
+	// Fraction of the trusted validator set that has to sign a non-adjacent block
+	LightClientTrustLevel string
+
+	// How often the last trusted header is saved to the database, verification resumes from it after a restart
+	LightClientSaveInterval time.Duration
+
+	// Number of payloads in one archive segment file
+	ArchiveSegmentSize int
+
+	// Run the whole pipeline, but only compare the output with rows saved by the production relayer.
+	// Connects to the ReadOnlyDatabase, nothing is ever written
+	ShadowEnabled bool
+
+	// How often to check if the production relayer already saved the compared block
+	ShadowPollInterval time.Duration
+
+	// Meta info data items are searched around the time L1 interactions were saved by the production relayer
+	ShadowMetaInfoWindow time.Duration
+}
+
+var defaultSequencerUrls = []string{"tcp://127.0.0.1:26657"}
+
+// Relayer.SequencerUrl was replaced by Relayer.SequencerUrls, it isn't a field anymore so its ENV needs to be bound explicitly
+func bindRelayerDeprecatedEnv() {
+	bindEnvKey([]string{"Relayer", "SequencerUrl"})
+}
+
+// Deprecated Relayer.SequencerUrl is used as the only sequencer url.
+// Setting both keys to different urls is an error, it's not clear which nodes should be used.
+func unmarshalRelayer(config *Config) error {
+	url := viper.GetString("Relayer.SequencerUrl")
+	if url == "" {
+		return nil
+	}
+
+	if !slices.Equal(config.Relayer.SequencerUrls, defaultSequencerUrls) &&
+		!slices.Equal(config.Relayer.SequencerUrls, []string{url}) {
+		return errors.New("Relayer.SequencerUrl is deprecated and conflicts with Relayer.SequencerUrls, set only Relayer.SequencerUrls")
+	}
+
+	config.Relayer.SequencerUrls = []string{url}
+	return nil
 }
 
 func setRelayerDefaults() {
 	viper.SetDefault("Relayer.Environment", "dev")
-	viper.SetDefault("Relayer.SequencerUrl", "tcp://127.0.0.1:26657")
+	viper.SetDefault("Relayer.SequencerUrls", defaultSequencerUrls)
+	viper.SetDefault("Relayer.SequencerHealthCheckInterval", "5s")
+	viper.SetDefault("Relayer.SequencerHealthCheckTimeout", "3s")
+	viper.SetDefault("Relayer.SequencerMaxHeightLag", "2")
 	viper.SetDefault("Relayer.ArweaveBlockDownloadTimeout", "45s")
 	viper.SetDefault("Relayer.ArweaveBlockDownloadMaxElapsedTime", "0s")
 	viper.SetDefault("Relayer.ArweaveBlockDownloadMaxInterval", "5s")
@@ -67,4 +148,17 @@ func setRelayerDefaults() {
 	viper.SetDefault("Relayer.StoreBatchSize", "100")
 	viper.SetDefault("Relayer.StoreMaxTimeInQueue", "10s")
 	viper.SetDefault("Relayer.StoreMaxBackoffInterval", "10s")
+	viper.SetDefault("Relayer.StoreMaxBackoffElapsedTime", "5m")
+	viper.SetDefault("Relayer.LightClientEnabled", "false")
+	viper.SetDefault("Relayer.LightClientChainId", "")
+	viper.SetDefault("Relayer.LightClientTrustedHeight", "0")
+	viper.SetDefault("Relayer.LightClientTrustedHash", "")
+	viper.SetDefault("Relayer.LightClientTrustingPeriod", "336h")
+	viper.SetDefault("Relayer.LightClientMaxClockDrift", "10s")
+	viper.SetDefault("Relayer.LightClientTrustLevel", "1/3")
+	viper.SetDefault("Relayer.LightClientSaveInterval", "30s")
+	viper.SetDefault("Relayer.ArchiveSegmentSize", "1000")
+	viper.SetDefault("Relayer.ShadowEnabled", "false")
+	viper.SetDefault("Relayer.ShadowPollInterval", "1s")
+	viper.SetDefault("Relayer.ShadowMetaInfoWindow", "1m")
 }
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Streaming changes through Postgres logical replication (pgoutput).
+// Database user needs the REPLICATION attribute and the server needs wal_level=logical.
+// Server keeps WAL for every slot until its consumer confirms it, so a slot that's abandoned fills up the disk.
+// Set max_slot_wal_keep_size on the server to limit that, slots that fall behind it are invalidated.
+type Replication struct {
+	// If true, bundler, sender and forwarder get changes through logical replication instead of LISTEN/NOTIFY
+	Enabled bool
+
+	// Publication with the streamed tables, created by migrations
+	PublicationName string
+
+	// Prefix of the replication slots, each consumer has its own persistent slot
+	SlotPrefix string
+
+	// Appended to the slot names, so each replica has its own slots. Should be stable across restarts, e.g. a StatefulSet pod name.
+	// Empty means replicas share the slots, only one of them streams at a time and others wait for the slot
+	SlotId string
+
+	// Drop the slots when stopping. Meant for replicas that won't come back with the same SlotId,
+	// changes made while the replica is down are lost
+	DropSlotOnStop bool
+
+	// Set REPLICA IDENTITY FULL on the streamed tables, so that updates have unchanged TOASTed values and deletes have all columns.
+	// Every update and delete writes the whole old row to the WAL. It isn't reverted when disabled
+	FullReplicaIdentity bool
+
+	// How often the position of handled changes is confirmed to the server
+	StatusInterval time.Duration
+
+	// How long to wait before reconnecting after a failure
+	ReconnectInterval time.Duration
+}
+
+func setReplicationDefaults() {
+	viper.SetDefault("Replication.Enabled", "false")
+	viper.SetDefault("Replication.PublicationName", "syncer_changes")
+	viper.SetDefault("Replication.SlotPrefix", "syncer_")
+	viper.SetDefault("Replication.SlotId", "")
+	viper.SetDefault("Replication.DropSlotOnStop", "false")
+	viper.SetDefault("Replication.FullReplicaIdentity", "false")
+	viper.SetDefault("Replication.StatusInterval", "10s")
+	viper.SetDefault("Replication.ReconnectInterval", "1s")
+}
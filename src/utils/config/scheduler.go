@@ -0,0 +1,43 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Divides items waiting for upload into priority lanes and schedules them fairly between contracts
+type Scheduler struct {
+	// Items of those contracts always go to the high priority lane
+	PriorityContracts []string
+
+	// Items with any of those tags (in format Name=Value) go to the high priority lane
+	PriorityTags []string
+
+	// Relative share of the throughput given to the high priority lane
+	HighLaneWeight int
+
+	// Relative share of the throughput given to the normal lane
+	NormalLaneWeight int
+
+	// How many of the oldest pending items are considered when the poller picks a fair batch
+	PollerFairnessWindow int
+
+	// Max items per second uploaded for one contract. 0 means no limit
+	ContractRateLimit float64
+
+	// Max burst of items uploaded for one contract
+	ContractRateBurst int
+
+	// Max number of items waiting in the scheduler
+	QueueSize int
+}
+
+func setSchedulerDefaults(prefix string) {
+	viper.SetDefault(prefix+".Scheduler.PriorityContracts", []string{})
+	viper.SetDefault(prefix+".Scheduler.PriorityTags", []string{})
+	viper.SetDefault(prefix+".Scheduler.HighLaneWeight", "4")
+	viper.SetDefault(prefix+".Scheduler.NormalLaneWeight", "1")
+	viper.SetDefault(prefix+".Scheduler.PollerFairnessWindow", "1000")
+	viper.SetDefault(prefix+".Scheduler.ContractRateLimit", "0")
+	viper.SetDefault(prefix+".Scheduler.ContractRateBurst", "10")
+	viper.SetDefault(prefix+".Scheduler.QueueSize", "500")
+}
@@ -59,6 +59,9 @@ type Sender struct {
 
 	// Number of workers that send bundles in parallel
 	BundlerNumBundlingWorkers int
+
+	// Priority lanes and fair scheduling between contracts
+	Scheduler Scheduler
 }
 
 func setSenderDefaults() {
@@ -79,4 +82,6 @@ func setSenderDefaults() {
 	viper.SetDefault("Sender.StoreInterval", "1s")
 	viper.SetDefault("Sender.StoreBackoffMaxElapsedTime", "0")
 	viper.SetDefault("Sender.StoreBackoffMaxInterval", "8s")
+
+	setSchedulerDefaults("Sender")
 }
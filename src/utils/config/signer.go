@@ -0,0 +1,31 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Reference external signer daemon, holds private keys outside of the other processes
+type Signer struct {
+	// Address the signer listens on. Either host:port or unix:///path/to/signer.sock
+	ListenAddress string
+
+	// Keys served by the signer in format name:type:key, e.g. default:arweave:file:///run/secrets/wallet.json
+	// Type is one of arweave, ethereum, typed-ethereum, ed25519, solana, aptos.
+	// Key may be passed inline or as a file:// or env:// reference
+	Keys []string
+
+	// Optional bearer token clients need to present. May be a file:// or env:// reference
+	AuthToken string
+
+	// Max time of processing one request
+	RequestTimeout time.Duration
+}
+
+func setSignerDefaults() {
+	viper.SetDefault("Signer.ListenAddress", "unix:///tmp/syncer-signer.sock")
+	viper.SetDefault("Signer.Keys", []string{})
+	viper.SetDefault("Signer.AuthToken", "")
+	viper.SetDefault("Signer.RequestTimeout", "5s")
+}
@@ -32,6 +32,10 @@ type Syncer struct {
 
 	// Max time between failed retries to save data.
 	StoreMaxBackoffInterval time.Duration
+
+	// Max time of retries to save data. Then interactions are saved in smaller batches
+	// and the ones that still fail go to the dead letter sink. 0 means no limit.
+	StoreMaxBackoffElapsedTime time.Duration
 }
 
 func setSyncerDefaults() {
@@ -43,4 +47,5 @@ func setSyncerDefaults() {
 	viper.SetDefault("Syncer.StoreBatchSize", "500")
 	viper.SetDefault("Syncer.StoreMaxTimeInQueue", "1s")
 	viper.SetDefault("Syncer.StoreMaxBackoffInterval", "30s")
+	viper.SetDefault("Syncer.StoreMaxBackoffElapsedTime", "5m")
 }
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// OpenTelemetry tracing of the pipelines, spans are exported over OTLP/HTTP.
+// Headers (e.g. authorization) are taken from the standard OTEL_EXPORTER_OTLP_HEADERS variable
+type Tracing struct {
+	// If false spans aren't recorded nor exported
+	Enabled bool
+
+	// Collector's address, host:port
+	Endpoint string
+
+	// URL path of the collector's traces endpoint
+	UrlPath string
+
+	// Use plain HTTP to connect to the collector
+	Insecure bool
+
+	// Name of the service reported with the spans
+	ServiceName string
+
+	// Fraction of traces that are recorded, 1 means all
+	SampleRatio float64
+
+	// Max time of sending a batch of spans
+	ExportTimeout time.Duration
+}
+
+func setTracingDefaults() {
+	viper.SetDefault("Tracing.Enabled", "false")
+	viper.SetDefault("Tracing.Endpoint", "localhost:4318")
+	viper.SetDefault("Tracing.UrlPath", "/v1/traces")
+	viper.SetDefault("Tracing.Insecure", "true")
+	viper.SetDefault("Tracing.ServiceName", "syncer")
+	viper.SetDefault("Tracing.SampleRatio", "1.0")
+	viper.SetDefault("Tracing.ExportTimeout", "10s")
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Configuration sections used by each mode (command), besides the common ones
+var modeSections = map[string][]string{
+	"sync":       {"Arweave", "PeerMonitor", "TransactionDownloader", "NetworkMonitor", "Syncer", "Database", "DeadLetter", "LeaderElection", "Freshness"},
+	"contract":   {"Arweave", "PeerMonitor", "TransactionDownloader", "NetworkMonitor", "Contract", "Database", "Redis", "AppSync", "DeadLetter", "LeaderElection"},
+	"bundle":     {"Bundler", "Bundlr", "Database", "Replication", "Freshness"},
+	"check":      {"Checker", "Bundlr", "Database", "Freshness"},
+	"send":       {"Sender", "Bundlr", "Database", "Replication"},
+	"forward":    {"Forwarder", "Database", "Redis", "Webhook", "AppSync", "Replication", "Freshness", "DeadLetter"},
+	"relay":      {"Relayer", "Sequencer", "Arweave", "Database", "DeadLetter", "LeaderElection", "Freshness"},
+	"evolve":     {"Evolver", "Database"},
+	"gateway":    {"Gateway", "ReadOnlyDatabase"},
+	"interact":   {"Interactor", "Sequencer", "Database"},
+	"load":       {"Database"},
+	"warpy_sync": {"WarpySyncer", "Sequencer", "Database"},
+	"signer":     {"Signer"},
+}
+
+// Sections checked regardless of the mode
+var commonSections = []string{"Watchdog", "Tracing", "Profiler", "Alerting"}
+
+// Integer fields with these suffixes need to be positive
+var positiveSuffixes = []string{"BatchSize", "NumWorkers", "MaxWorkers", "WorkerPoolSize"}
+
+var alertOperators = []string{">", ">=", "<", "<=", "==", "!="}
+
+var alertSinkTypes = []string{"log", "webhook", "slack"}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+func GetModes() (out []string) {
+	for mode := range modeSections {
+		out = append(out, mode)
+	}
+	slices.Sort(out)
+	return
+}
+
+// Checks ranges and relations between fields used by the mode. Empty mode validates only the common sections.
+// All problems are returned at once
+func (self *Config) Validate(mode string) error {
+	sections := slices.Clone(commonSections)
+	if mode != "" {
+		modeSpecific, ok := modeSections[mode]
+		if !ok {
+			return fmt.Errorf("unknown mode %s, expected one of: %s", mode, strings.Join(GetModes(), ", "))
+		}
+		sections = append(sections, modeSpecific...)
+	}
+
+	var errs []error
+	_, err := logrus.ParseLevel(self.LogLevel)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("LogLevel: %w", err))
+	}
+
+	val := reflect.ValueOf(*self)
+	for _, section := range sections {
+		field := val.FieldByName(section)
+		if !field.IsValid() {
+			continue
+		}
+		errs = append(errs, validateRanges([]string{section}, field)...)
+		errs = append(errs, self.validateSection(section)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Durations can't be negative, sizes of batches and worker pools need to be positive
+func validateRanges(path []string, val reflect.Value) (errs []error) {
+	switch {
+	case val.Type() == durationType:
+		if val.Int() < 0 {
+			errs = append(errs, fmt.Errorf("%s: must not be negative", strings.Join(path, ".")))
+		}
+	case val.Kind() == reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			errs = append(errs, validateRanges(append(path[:len(path):len(path)], val.Type().Field(i).Name), val.Field(i))...)
+		}
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Struct:
+		for i := 0; i < val.Len(); i++ {
+			errs = append(errs, validateRanges(append(path[:len(path):len(path)], fmt.Sprintf("%d", i)), val.Index(i))...)
+		}
+	case val.Kind() == reflect.Int:
+		name := path[len(path)-1]
+		for _, suffix := range positiveSuffixes {
+			if strings.HasSuffix(name, suffix) && val.Int() <= 0 {
+				errs = append(errs, fmt.Errorf("%s: must be positive", strings.Join(path, ".")))
+			}
+		}
+	}
+	return
+}
+
+// Relations between fields of a section
+func (self *Config) validateSection(section string) (errs []error) {
+	check := func(isOK bool, format string, args ...any) {
+		if !isOK {
+			errs = append(errs, fmt.Errorf(section+": "+format, args...))
+		}
+	}
+
+	switch section {
+	case "Database", "ReadOnlyDatabase":
+		db := self.Database
+		if section == "ReadOnlyDatabase" {
+			db = self.ReadOnlyDatabase
+		}
+		check(db.Port > 0, "Port must be set")
+		check(db.Host != "", "Host must be set")
+		check(db.Name != "", "Name must be set")
+		check(slices.Contains(sslModes, db.SslMode), "SslMode must be one of: %s", strings.Join(sslModes, ", "))
+		check(db.MaxIdleConns <= db.MaxOpenConns, "MaxIdleConns (%d) can't exceed MaxOpenConns (%d)", db.MaxIdleConns, db.MaxOpenConns)
+	case "PeerMonitor":
+		check(self.PeerMonitor.MaxPeers > 0, "MaxPeers must be positive")
+		check(self.PeerMonitor.Period > 0, "Period must be positive")
+	case "Syncer":
+		check(self.Syncer.StoreMaxTimeInQueue > 0, "StoreMaxTimeInQueue must be positive")
+		check(self.Syncer.BlockMaxElapsedTime == 0 || self.Syncer.BlockMaxElapsedTime >= self.Syncer.BlockMaxInterval,
+			"BlockMaxElapsedTime must be 0 (no limit) or at least BlockMaxInterval")
+		check(self.Syncer.TransactionMaxElapsedTime == 0 || self.Syncer.TransactionMaxElapsedTime >= self.Syncer.TransactionMaxInterval,
+			"TransactionMaxElapsedTime must be 0 (no limit) or at least TransactionMaxInterval")
+	case "Contract":
+		check(self.Contract.StoreInterval > 0, "StoreInterval must be positive")
+		check(self.Contract.StoreBackoffMaxElapsedTime == 0 || self.Contract.StoreBackoffMaxElapsedTime >= self.Contract.StoreBackoffMaxInterval,
+			"StoreBackoffMaxElapsedTime must be 0 (no limit) or at least StoreBackoffMaxInterval")
+	case "Relayer":
+		check(self.Relayer.StoreMaxTimeInQueue > 0, "StoreMaxTimeInQueue must be positive")
+	case "Redis":
+		for i, redis := range self.Redis {
+			check(redis.Port > 0, "[%d].Port must be set", i)
+			check(redis.Host != "", "[%d].Host must be set", i)
+		}
+	case "Webhook":
+		for i, webhook := range self.Webhook {
+			check(strings.HasPrefix(webhook.Url, "http://") || strings.HasPrefix(webhook.Url, "https://"), "[%d].Url must be a http(s) URL", i)
+			check(webhook.RetryQueueMaxAttempts >= 0, "[%d].RetryQueueMaxAttempts must not be negative", i)
+		}
+	case "Replication":
+		if self.Replication.Enabled {
+			check(self.Replication.PublicationName != "", "PublicationName must be set")
+			check(self.Replication.SlotPrefix != "", "SlotPrefix must be set")
+			check(isSlotName(self.Replication.SlotPrefix+self.Replication.SlotId), "SlotPrefix and SlotId may only contain lower case letters, digits and underscores")
+		}
+	case "Watchdog":
+		check(self.Watchdog.CheckInterval > 0, "CheckInterval must be positive")
+		check(self.Watchdog.BackoffMultiplier >= 1, "BackoffMultiplier must be at least 1")
+		check(self.Watchdog.BackoffMaxInterval >= self.Watchdog.BackoffInitialInterval, "BackoffMaxInterval can't be shorter than BackoffInitialInterval")
+		check(self.Watchdog.MaxRestarts >= 0, "MaxRestarts must not be negative")
+		check(self.Watchdog.MaxRestarts == 0 || self.Watchdog.RestartWindow > 0, "RestartWindow must be positive if MaxRestarts is set")
+	case "Tracing":
+		check(self.Tracing.SampleRatio >= 0 && self.Tracing.SampleRatio <= 1, "SampleRatio must be between 0 and 1")
+		check(!self.Tracing.Enabled || self.Tracing.Endpoint != "", "Endpoint must be set if tracing is enabled")
+	case "LeaderElection":
+		if self.LeaderElection.Enabled {
+			check(self.LeaderElection.RenewInterval > 0, "RenewInterval must be positive")
+			check(self.LeaderElection.RenewInterval < self.LeaderElection.LeaseDuration, "RenewInterval must be shorter than LeaseDuration")
+			check(self.LeaderElection.RenewInterval+self.StopTimeout < self.LeaderElection.LeaseDuration, "LeaseDuration must be longer than RenewInterval and StopTimeout together")
+		}
+	case "Alerting":
+		if !self.Alerting.Enabled {
+			break
+		}
+		check(self.Alerting.Interval > 0, "Interval must be positive")
+		names := make(map[string]struct{}, len(self.Alerting.Rules))
+		for i, rule := range self.Alerting.Rules {
+			_, duplicate := names[rule.Name]
+			check(rule.Name != "" && !duplicate, "Rules[%d].Name must be set and unique", i)
+			names[rule.Name] = struct{}{}
+			check(rule.Value != "", "Rules[%d].Value must be set", i)
+			check(slices.Contains(alertOperators, rule.Operator), "Rules[%d].Operator must be one of: %s", i, strings.Join(alertOperators, " "))
+		}
+		for i, sink := range self.Alerting.Sinks {
+			check(slices.Contains(alertSinkTypes, sink.Type), "Sinks[%d].Type must be one of: %s", i, strings.Join(alertSinkTypes, ", "))
+			check(sink.Type == "log" || strings.HasPrefix(sink.Url, "http://") || strings.HasPrefix(sink.Url, "https://"), "Sinks[%d].Url must be a http(s) URL", i)
+		}
+	case "Freshness":
+		if self.Freshness.Enabled {
+			check(self.Freshness.SampleRatio > 0 && self.Freshness.SampleRatio <= 1, "SampleRatio must be above 0 and at most 1")
+			check(self.Freshness.Objective > 0 && self.Freshness.Objective < 1, "Objective must be between 0 and 1")
+			check(self.Freshness.L1Target > 0 && self.Freshness.L2Target > 0, "L1Target and L2Target must be positive")
+			check(self.Freshness.CatchUpThreshold == 0 ||
+				(self.Freshness.CatchUpThreshold > self.Freshness.L1Target && self.Freshness.CatchUpThreshold > self.Freshness.L2Target),
+				"CatchUpThreshold must be 0 or longer than L1Target and L2Target")
+			check(self.Freshness.FlushInterval > 0, "FlushInterval must be positive")
+			check(self.Freshness.MaxBufferSize > 0, "MaxBufferSize must be positive")
+		}
+	case "DeadLetter":
+		check(self.DeadLetter.Sink == "db" || self.DeadLetter.Sink == "file", "Sink must be db or file")
+		check(self.DeadLetter.Sink != "file" || self.DeadLetter.FilePath != "", "FilePath must be set for the file sink")
+	}
+	return
+}
+
+// Postgres allows only those characters in replication slot names
+func isSlotName(name string) bool {
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
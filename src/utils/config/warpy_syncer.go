@@ -59,7 +59,7 @@ type WarpySyncer struct {
 	// Warpy admin id
 	SyncerInteractionAdminId string
 
-	// Signer for the Warpy interactions
+	// Signer for the Warpy interactions. Inline JWK or a file://, env:// or signer:// reference
 	SyncerSigner string
 
 	// Max time between failed retries to sync transaction
@@ -137,6 +137,9 @@ type WarpySyncer struct {
 	// Max time between failed retries to save last block synced
 	StoreMaxBackoffInterval time.Duration
 
+	// Max time of retries to save last block synced, then it's saved upon the next flush. 0 means no limit
+	StoreMaxBackoffElapsedTime time.Duration
+
 	// Maximum length of the channel buffer
 	PollerDepositChannelBufferLength int
 
@@ -199,6 +202,7 @@ func setWarpySyncerDefaults() {
 	viper.SetDefault("WarpySyncer.SyncerDeltaWorkerQueueSize", "10")
 	viper.SetDefault("WarpySyncer.SyncerDepositContractIds", []string{"0x766f21277087E18967c1b10bF602d8Fe56d0c671"})
 	viper.SetDefault("WarpySyncer.SyncerDepositBackoffInterval", "3s")
+	viper.SetDefault("WarpySyncer.SyncerDepositNumWorkers", "1")
 	viper.SetDefault("WarpySyncer.SyncerDepositFunctions", []string{"supply", "withdraw"})
 	viper.SetDefault("WarpySyncer.SyncerDepositMarkets", []string{
 		// wETH
@@ -219,6 +223,7 @@ func setWarpySyncerDefaults() {
 	viper.SetDefault("WarpySyncer.StoreBatchSize", "500")
 	viper.SetDefault("WarpySyncer.StoreInterval", "2s")
 	viper.SetDefault("WarpySyncer.StoreMaxBackoffInterval", "30s")
+	viper.SetDefault("WarpySyncer.StoreMaxBackoffElapsedTime", "5m")
 	viper.SetDefault("WarpySyncer.AssetsCalculatorDepositAssetsNames", []string{"amount"})
 	viper.SetDefault("WarpySyncer.AssetsCalculatorDepositLogAssetsNames", []string{"amount"})
 	viper.SetDefault("WarpySyncer.AssetsCalculatorWithdrawAssetsNames", []string{})
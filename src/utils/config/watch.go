@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var (
+	watchOnce           sync.Once
+	watchMtx            sync.Mutex
+	watchListeners      = make(map[uint64]func(*Config))
+	watchLastListenerId uint64
+	watchModes          []string
+)
+
+// Modes the reloaded configuration is validated for, before it's passed to listeners.
+// Common sections are always validated, unknown modes are skipped
+func SetReloadModes(modes ...string) {
+	watchMtx.Lock()
+	defer watchMtx.Unlock()
+	watchModes = make([]string, 0, len(modes))
+	for _, mode := range modes {
+		if _, ok := modeSections[mode]; ok {
+			watchModes = append(watchModes, mode)
+		}
+	}
+}
+
+func validateReloaded(config *Config, modes []string) error {
+	if len(modes) == 0 {
+		return config.Validate("")
+	}
+	errs := make([]error, 0, len(modes))
+	for _, mode := range modes {
+		errs = append(errs, config.Validate(mode))
+	}
+	return errors.Join(errs...)
+}
+
+// Calls f with the new configuration every time the configuration file changes.
+// Env variables and defaults are applied like in Load. Does nothing if configuration wasn't loaded from a file.
+// Configuration that doesn't pass validation is ignored.
+// Callers decide which values they take from the new configuration, everything else requires a restart.
+// Returned function removes the listener.
+func OnChange(f func(*Config)) (cancel func()) {
+	if viper.ConfigFileUsed() == "" {
+		return func() {}
+	}
+
+	watchMtx.Lock()
+	watchLastListenerId++
+	id := watchLastListenerId
+	watchListeners[id] = f
+	watchMtx.Unlock()
+
+	cancel = func() {
+		watchMtx.Lock()
+		defer watchMtx.Unlock()
+		delete(watchListeners, id)
+	}
+
+	watchOnce.Do(func() {
+		viper.OnConfigChange(func(event fsnotify.Event) {
+			config, err := unmarshal()
+			if err != nil {
+				logrus.WithError(err).WithField("file", event.Name).Error("Failed to reload configuration, keeping the previous one")
+				return
+			}
+
+			watchMtx.Lock()
+			listeners := make([]func(*Config), 0, len(watchListeners))
+			for _, listener := range watchListeners {
+				listeners = append(listeners, listener)
+			}
+			modes := watchModes
+			watchMtx.Unlock()
+
+			err = validateReloaded(config, modes)
+			if err != nil {
+				logrus.WithError(err).WithField("file", event.Name).Error("Reloaded configuration is invalid, keeping the previous one")
+				return
+			}
+
+			for _, listener := range listeners {
+				listener(config)
+			}
+		})
+		viper.WatchConfig()
+	})
+
+	return
+}
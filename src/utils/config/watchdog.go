@@ -0,0 +1,41 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Restart policy of the watched pipelines, shared by all modes
+type Watchdog struct {
+	// How often the health of the watched task is checked
+	CheckInterval time.Duration
+
+	// Min time between two consecutive restarts, doubled (see BackoffMultiplier) upon every next restart
+	BackoffInitialInterval time.Duration
+
+	// Max time between two consecutive restarts
+	BackoffMaxInterval time.Duration
+
+	// Factor the time between restarts grows by
+	BackoffMultiplier float64
+
+	// Task healthy for this long after a restart resets the backoff
+	StableInterval time.Duration
+
+	// Max number of restarts within RestartWindow, after that the process exits. 0 means no limit
+	MaxRestarts int
+
+	// Period in which restarts are counted
+	RestartWindow time.Duration
+}
+
+func setWatchdogDefaults() {
+	viper.SetDefault("Watchdog.CheckInterval", "30s")
+	viper.SetDefault("Watchdog.BackoffInitialInterval", "30s")
+	viper.SetDefault("Watchdog.BackoffMaxInterval", "10m")
+	viper.SetDefault("Watchdog.BackoffMultiplier", "2")
+	viper.SetDefault("Watchdog.StableInterval", "10m")
+	viper.SetDefault("Watchdog.MaxRestarts", "0")
+	viper.SetDefault("Watchdog.RestartWindow", "1h")
+}
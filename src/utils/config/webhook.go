@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+type Webhook struct {
+	// Endpoint that receives batches of notifications in a POST request
+	Url string
+
+	// Key used to sign the payload with HMAC-SHA256, signing is disabled if empty
+	Secret string
+
+	// Timeout of a single request
+	Timeout time.Duration
+
+	// Max number of notifications sent in one request
+	BatchSize int
+
+	// Max time notifications wait for the batch to fill up
+	BatchMaxInterval time.Duration
+
+	// Delivery backoff configuration, 0 is no limit
+	MaxElapsedTime time.Duration
+	MaxInterval    time.Duration
+
+	// How often batches that failed to be delivered are retried from the database
+	RetryQueueInterval time.Duration
+
+	// Max number of batches redelivered from the database at once
+	RetryQueueBatchSize int
+
+	// Batch that failed this many redelivery attempts is moved to dead letters, so it doesn't block the queue. 0 is no limit.
+	// Batches rejected by the endpoint (4xx) are moved there right away
+	RetryQueueMaxAttempts int
+
+	// Forwarded interactions delivered to this webhook, reloaded upon configuration file change
+	Filter Filter
+}
+
+func unmarshalWebhook(config *Config) error {
+	config.Webhook = make([]Webhook, getSliceLength("webhook"))
+
+	settings := viper.AllSettings()
+	for i := range config.Webhook {
+
+		data := Webhook{
+			Timeout:               10 * time.Second,
+			BatchSize:             100,
+			BatchMaxInterval:      time.Second,
+			MaxElapsedTime:        time.Minute,
+			MaxInterval:           10 * time.Second,
+			RetryQueueInterval:    30 * time.Second,
+			RetryQueueBatchSize:   10,
+			RetryQueueMaxAttempts: 100,
+		}
+
+		decoder, err := mapstructure.NewDecoder(defaultDecoderConfig(&data))
+		if err != nil {
+			return err
+		}
+		err = decoder.Decode(settings[fmt.Sprintf("webhook[%d]", i)])
+		if err != nil {
+			return err
+		}
+
+		config.Webhook[i] = data
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Saves failed items in the dead_letters table
+type DbSink struct {
+	log    *logrus.Entry
+	db     *gorm.DB
+	source string
+}
+
+func NewDbSink(db *gorm.DB, source string) (self *DbSink) {
+	self = new(DbSink)
+	self.log = logger.NewSublogger("dead-letter-db")
+	self.db = db
+	self.source = source
+	return
+}
+
+func (self *DbSink) OnError(stage string, item any, err error) {
+	deadLetter := &model.DeadLetter{
+		Source: self.source,
+		Stage:  stage,
+		Error:  err.Error(),
+	}
+
+	errSet := deadLetter.Payload.Set(marshal(item))
+	if errSet != nil {
+		self.log.WithError(errSet).WithField("stage", stage).Error("Failed to set dead letter payload")
+		return
+	}
+	deadLetter.ReplayedAt.Status = pgtype.Null
+
+	// Stage may be stopping, its context can't be used
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	errSave := self.db.WithContext(ctx).
+		Create(deadLetter).
+		Error
+	if errSave != nil {
+		self.log.WithError(errSave).WithField("stage", stage).WithField("item", item).Error("Failed to save dead letter")
+	}
+}
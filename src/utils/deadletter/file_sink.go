@@ -0,0 +1,103 @@
+package deadletter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Appends failed items to a NDJSON file, one dead letter per line
+type FileSink struct {
+	log    *logrus.Entry
+	mtx    sync.Mutex
+	path   string
+	source string
+}
+
+func NewFileSink(path, source string) (self *FileSink) {
+	self = new(FileSink)
+	self.log = logger.NewSublogger("dead-letter-file")
+	self.path = path
+	self.source = source
+	return
+}
+
+func (self *FileSink) OnError(stage string, item any, err error) {
+	deadLetter := &model.DeadLetter{
+		Source:    self.source,
+		Stage:     stage,
+		Error:     err.Error(),
+		CreatedAt: time.Now(),
+	}
+	deadLetter.ReplayedAt.Status = pgtype.Null
+
+	errWrite := self.write(deadLetter, item)
+	if errWrite != nil {
+		self.log.WithError(errWrite).WithField("stage", stage).WithField("item", item).Error("Failed to write dead letter")
+	}
+}
+
+func (self *FileSink) write(deadLetter *model.DeadLetter, item any) (err error) {
+	err = deadLetter.Payload.Set(marshal(item))
+	if err != nil {
+		return
+	}
+
+	line, err := json.Marshal(deadLetter)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	// File is opened for every item, failures are rare
+	file, err := os.OpenFile(self.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, err = file.Write(line)
+	return
+}
+
+// Reads dead letters written by the FileSink, ids are line numbers starting from 1
+func ReadFile(path string) (out []*model.DeadLetter, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	var lineNumber int64
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		deadLetter := new(model.DeadLetter)
+		err = json.Unmarshal(scanner.Bytes(), deadLetter)
+		if err != nil {
+			return
+		}
+		deadLetter.Id = lineNumber
+		out = append(out, deadLetter)
+	}
+
+	err = scanner.Err()
+	return
+}
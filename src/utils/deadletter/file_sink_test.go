@@ -0,0 +1,38 @@
+package deadletter
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name  string
+	Value int
+}
+
+func TestFileSinkRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letters.ndjson")
+	sink := NewFileSink(path, "syncer")
+
+	sink.OnError("store", &testItem{Name: "a", Value: 1}, errors.New("first"))
+	sink.OnError("store", make(chan int), errors.New("second"))
+
+	deadLetters, err := ReadFile(path)
+	require.Nil(t, err)
+	require.Len(t, deadLetters, 2)
+
+	assert.Equal(t, int64(1), deadLetters[0].Id)
+	assert.Equal(t, "syncer", deadLetters[0].Source)
+	assert.Equal(t, "store", deadLetters[0].Stage)
+	assert.Equal(t, "first", deadLetters[0].Error)
+	assert.JSONEq(t, `{"Name":"a","Value":1}`, string(deadLetters[0].Payload.Bytes))
+
+	// Items that can't be marshaled are kept as text
+	assert.Equal(t, int64(2), deadLetters[1].Id)
+	assert.Equal(t, "second", deadLetters[1].Error)
+	assert.Equal(t, byte('"'), deadLetters[1].Payload.Bytes[0])
+}
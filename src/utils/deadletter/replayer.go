@@ -0,0 +1,110 @@
+package deadletter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Handles a single dead letter payload, the same way the failed stage would
+type ReplayFunc func(ctx context.Context, db *gorm.DB, payload []byte) error
+
+// Re-runs dead letters through handlers registered per mode and stage
+type Replayer struct {
+	log      *logrus.Entry
+	db       *gorm.DB
+	handlers map[string]ReplayFunc
+}
+
+func NewReplayer(db *gorm.DB) (self *Replayer) {
+	self = new(Replayer)
+	self.log = logger.NewSublogger("dead-letter-replayer")
+	self.db = db
+	self.handlers = make(map[string]ReplayFunc)
+	return
+}
+
+func (self *Replayer) WithHandler(source, stage string, f ReplayFunc) *Replayer {
+	self.handlers[handlerKey(source, stage)] = f
+	return self
+}
+
+func handlerKey(source, stage string) string {
+	return source + "/" + stage
+}
+
+// Gets dead letters from the table, optionally filtered by mode and stage, newest first
+func (self *Replayer) List(ctx context.Context, source, stage string, includeReplayed bool, limit int) (out []*model.DeadLetter, err error) {
+	query := self.db.WithContext(ctx).
+		Model(&model.DeadLetter{})
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if stage != "" {
+		query = query.Where("stage = ?", stage)
+	}
+	if !includeReplayed {
+		query = query.Where("replayed_at IS NULL")
+	}
+	err = query.
+		Order("id DESC").
+		Limit(limit).
+		Find(&out).
+		Error
+	return
+}
+
+// Replays dead letters with given ids from the table, successfully replayed ones are marked
+func (self *Replayer) ReplayIds(ctx context.Context, ids []int64) (err error) {
+	var deadLetters []*model.DeadLetter
+	err = self.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&deadLetters).
+		Error
+	if err != nil {
+		return
+	}
+	if len(deadLetters) != len(ids) {
+		return fmt.Errorf("found %d of %d dead letters", len(deadLetters), len(ids))
+	}
+
+	for _, deadLetter := range deadLetters {
+		err = self.Replay(ctx, deadLetter)
+		if err != nil {
+			return
+		}
+
+		err = self.db.WithContext(ctx).
+			Model(deadLetter).
+			Update("replayed_at", time.Now()).
+			Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Passes the payload to the handler of its mode and stage
+func (self *Replayer) Replay(ctx context.Context, deadLetter *model.DeadLetter) (err error) {
+	handler, ok := self.handlers[handlerKey(deadLetter.Source, deadLetter.Stage)]
+	if !ok {
+		return fmt.Errorf("stage %s of %s can't be replayed", deadLetter.Stage, deadLetter.Source)
+	}
+
+	err = handler(ctx, self.db, deadLetter.Payload.Bytes)
+	if err != nil {
+		self.log.WithError(err).WithField("id", deadLetter.Id).Error("Failed to replay dead letter")
+		return
+	}
+
+	self.log.WithField("id", deadLetter.Id).WithField("stage", deadLetter.Stage).Info("Replayed dead letter")
+	return
+}
@@ -0,0 +1,37 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+)
+
+// Sink configured in the DeadLetter section, nil if dead letters are disabled.
+// Source is the name of the mode, stored along with every item.
+func NewSink(config *config.Config, db *gorm.DB, source string) task.ErrorSink {
+	if !config.DeadLetter.Enabled {
+		return nil
+	}
+
+	switch config.DeadLetter.Sink {
+	case "file":
+		return NewFileSink(config.DeadLetter.FilePath, source)
+	default:
+		return NewDbSink(db, source)
+	}
+}
+
+// Items are stored as JSON, the ones that can't be marshaled are kept as their text representation
+func marshal(item any) []byte {
+	buf, err := json.Marshal(item)
+	if err == nil {
+		return buf
+	}
+
+	buf, _ = json.Marshal(fmt.Sprintf("%+v", item))
+	return buf
+}
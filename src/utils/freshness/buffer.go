@@ -0,0 +1,40 @@
+package freshness
+
+import (
+	"sync"
+
+	"github.com/warp-contracts/syncer/src/utils/model"
+)
+
+// Samples waiting to be saved by the Recorder
+type sampleBuffer struct {
+	mtx     sync.Mutex
+	maxSize int
+	samples []model.FreshnessSample
+}
+
+var buffer = &sampleBuffer{}
+
+func (self *sampleBuffer) setMaxSize(v int) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.maxSize = v
+}
+
+// Drops the sample if the buffer is full, e.g. when the database is down
+func (self *sampleBuffer) add(sample model.FreshnessSample) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	if len(self.samples) >= self.maxSize {
+		return
+	}
+	self.samples = append(self.samples, sample)
+}
+
+func (self *sampleBuffer) drain() (out []model.FreshnessSample) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	out = self.samples
+	self.samples = nil
+	return
+}
@@ -0,0 +1,122 @@
+package freshness
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Burn rate is reported over these windows, e.g. to alert on fast and slow burns separately
+var burnRateWindows = []time.Duration{5 * time.Minute, time.Hour}
+
+// Latency histograms and SLO burn rates of all stages running in the process
+type Metrics struct {
+	Latency        *prometheus.HistogramVec
+	CatchUpSkipped *prometheus.CounterVec
+	BurnRate       *prometheus.Desc
+
+	mtx     sync.Mutex
+	windows map[[2]string]*window
+}
+
+var metrics = &Metrics{
+	Latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "freshness_latency_seconds",
+		Help:    "Time from the origin of the pipeline (Arweave or sequencer block) to the stage handling the interaction",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 15),
+	}, []string{"pipeline", "stage"}),
+	CatchUpSkipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "freshness_catch_up_skipped",
+		Help: "Number of sampled interactions older than the catch-up threshold, not included in the latency",
+	}, []string{"pipeline", "stage"}),
+	BurnRate: prometheus.NewDesc("freshness_slo_burn_rate",
+		"Share of sampled interactions above the latency target divided by the error budget, 1 means the budget is used up exactly in time",
+		[]string{"pipeline", "stage", "window"}, nil),
+	windows: make(map[[2]string]*window),
+}
+
+// Collector for the freshness metrics, register it once per process
+func GetPrometheusCollector() prometheus.Collector {
+	return metrics
+}
+
+func (self *Metrics) getWindow(pipeline, stage string, objective float64) *window {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	key := [2]string{pipeline, stage}
+	w, ok := self.windows[key]
+	if !ok {
+		w = &window{objective: objective}
+		self.windows[key] = w
+	}
+	return w
+}
+
+func (self *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	self.Latency.Describe(ch)
+	self.CatchUpSkipped.Describe(ch)
+	ch <- self.BurnRate
+}
+
+func (self *Metrics) Collect(ch chan<- prometheus.Metric) {
+	self.Latency.Collect(ch)
+	self.CatchUpSkipped.Collect(ch)
+
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	now := time.Now()
+	for key, w := range self.windows {
+		for _, d := range burnRateWindows {
+			ch <- prometheus.MustNewConstMetric(self.BurnRate, prometheus.GaugeValue, w.getBurnRate(now, d), key[0], key[1], d.String())
+		}
+	}
+}
+
+// Counts of sampled interactions within and above the target, in one minute buckets for the last hour
+type window struct {
+	mtx       sync.Mutex
+	objective float64
+	minutes   [60]int64
+	total     [60]uint64
+	bad       [60]uint64
+}
+
+func (self *window) add(now time.Time, isGood bool) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	minute := now.Unix() / 60
+	i := minute % 60
+	if self.minutes[i] != minute {
+		// Bucket of an older hour
+		self.minutes[i] = minute
+		self.total[i] = 0
+		self.bad[i] = 0
+	}
+	self.total[i]++
+	if !isGood {
+		self.bad[i]++
+	}
+}
+
+// 0 if nothing was sampled within the window
+func (self *window) getBurnRate(now time.Time, d time.Duration) float64 {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	var total, bad uint64
+	current := now.Unix() / 60
+	for i := range self.minutes {
+		if current-self.minutes[i] < int64(d/time.Minute) {
+			total += self.total[i]
+			bad += self.bad[i]
+		}
+	}
+	if total == 0 || self.objective >= 1 {
+		return 0
+	}
+	return float64(bad) / float64(total) / (1 - self.objective)
+}
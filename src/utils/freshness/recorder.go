@@ -0,0 +1,67 @@
+package freshness
+
+import (
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+)
+
+// Periodically saves sampled latencies, so that the slo command can aggregate them across modes.
+// Deletes samples older than the retention period
+type Recorder struct {
+	*task.Task
+
+	db        *gorm.DB
+	retention time.Duration
+}
+
+func NewRecorder(config *config.Config) (self *Recorder) {
+	self = new(Recorder)
+	self.retention = config.Freshness.Retention
+
+	self.Task = task.NewTask(config, "freshness-recorder").
+		WithPeriodicSubtaskFunc(config.Freshness.FlushInterval, self.flush).
+		WithPeriodicSubtaskFunc(time.Hour, self.cleanup).
+		// Save what's left
+		WithOnAfterStop(func() {
+			_ = self.flush()
+		})
+
+	return
+}
+
+func (self *Recorder) WithDB(db *gorm.DB) *Recorder {
+	self.db = db
+	return self
+}
+
+func (self *Recorder) flush() error {
+	samples := buffer.drain()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	err := self.db.Session(&gorm.Session{CreateBatchSize: 500}).
+		Create(&samples).
+		Error
+	if err != nil {
+		// Samples are best effort, they're still in the metrics
+		self.Log.WithError(err).WithField("count", len(samples)).Error("Failed to save freshness samples")
+	}
+	return nil
+}
+
+func (self *Recorder) cleanup() error {
+	err := self.db.WithContext(self.Ctx).
+		Where("observed_at < ?", time.Now().Add(-self.retention)).
+		Delete(&model.FreshnessSample{}).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to delete old freshness samples")
+	}
+	return nil
+}
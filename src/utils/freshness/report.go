@@ -0,0 +1,145 @@
+package freshness
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"gorm.io/gorm"
+)
+
+// Latencies of one stage aggregated from saved samples
+type Summary struct {
+	Pipeline string `json:"pipeline"`
+	Stage    string `json:"stage"`
+	Count    int64  `json:"count"`
+
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+
+	// Share of samples within the pipeline's target
+	WithinTarget float64 `json:"within_target"`
+
+	// Share of samples above the target divided by the error budget
+	BurnRate float64 `json:"burn_rate"`
+
+	// Time since the previous stage, from interactions sampled by both. Zero for the first stage
+	SincePreviousP50 time.Duration `json:"since_previous_p50"`
+	SincePreviousP99 time.Duration `json:"since_previous_p99"`
+}
+
+// Aggregates samples saved by all modes since the given time, ordered by pipeline and stage
+func GetSummaries(ctx context.Context, db *gorm.DB, config *config.Config, since time.Time) (out []*Summary, err error) {
+	type row struct {
+		Pipeline     string
+		Stage        string
+		Count        int64
+		P50          float64
+		P90          float64
+		P99          float64
+		WithinTarget float64
+	}
+	var rows []row
+
+	err = db.WithContext(ctx).
+		Table(model.TableFreshnessSample).
+		Select(`pipeline, stage, COUNT(*) AS count,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms) AS p50,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY latency_ms) AS p90,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms) AS p99,
+			AVG(CASE WHEN latency_ms <= (CASE WHEN pipeline = ? THEN ? ELSE ? END) THEN 1.0 ELSE 0.0 END)::float8 AS within_target`,
+			PipelineL1, config.Freshness.L1Target.Milliseconds(), config.Freshness.L2Target.Milliseconds()).
+		Where("observed_at >= ?", since).
+		Group("pipeline, stage").
+		Scan(&rows).
+		Error
+	if err != nil {
+		return
+	}
+
+	sincePrevious, err := getSincePrevious(ctx, db, since)
+	if err != nil {
+		return
+	}
+
+	objective := config.Freshness.Objective
+	for _, r := range rows {
+		summary := &Summary{
+			Pipeline:     r.Pipeline,
+			Stage:        r.Stage,
+			Count:        r.Count,
+			P50:          time.Duration(r.P50) * time.Millisecond,
+			P90:          time.Duration(r.P90) * time.Millisecond,
+			P99:          time.Duration(r.P99) * time.Millisecond,
+			WithinTarget: r.WithinTarget,
+		}
+		if objective < 1 {
+			summary.BurnRate = (1 - r.WithinTarget) / (1 - objective)
+		}
+		if p, ok := sincePrevious[[2]string{r.Pipeline, r.Stage}]; ok {
+			summary.SincePreviousP50 = time.Duration(p[0]) * time.Millisecond
+			summary.SincePreviousP99 = time.Duration(p[1]) * time.Millisecond
+		}
+		out = append(out, summary)
+	}
+
+	slices.SortFunc(out, func(a, b *Summary) int {
+		if a.Pipeline != b.Pipeline {
+			if a.Pipeline < b.Pipeline {
+				return -1
+			}
+			return 1
+		}
+		return getStageIndex(a.Pipeline, a.Stage) - getStageIndex(b.Pipeline, b.Stage)
+	})
+	return
+}
+
+// Percentiles (p50, p99) of the time between consecutive stages, by pipeline and stage.
+// Samples of one interaction are joined by the key, stages follow each other so they're ordered by latency
+func getSincePrevious(ctx context.Context, db *gorm.DB, since time.Time) (out map[[2]string][2]float64, err error) {
+	type row struct {
+		Pipeline string
+		Stage    string
+		P50      float64
+		P99      float64
+	}
+	var rows []row
+
+	err = db.WithContext(ctx).
+		Raw(`SELECT pipeline, stage,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY since_previous_ms) AS p50,
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY since_previous_ms) AS p99
+		FROM (
+			SELECT pipeline, stage,
+				latency_ms - LAG(latency_ms) OVER (PARTITION BY pipeline, interaction_key ORDER BY latency_ms) AS since_previous_ms
+			FROM `+model.TableFreshnessSample+`
+			WHERE observed_at >= ? AND interaction_key <> ''
+		) AS joined
+		WHERE since_previous_ms IS NOT NULL
+		GROUP BY pipeline, stage`, since).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return
+	}
+
+	out = make(map[[2]string][2]float64, len(rows))
+	for _, r := range rows {
+		out[[2]string{r.Pipeline, r.Stage}] = [2]float64{r.P50, r.P99}
+	}
+	return
+}
+
+// Unknown stages go last
+func getStageIndex(pipeline, stage string) int {
+	idx := slices.Index(stages[pipeline], stage)
+	if idx < 0 {
+		return len(stages[pipeline])
+	}
+	return idx
+}
@@ -0,0 +1,135 @@
+package freshness
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Pipelines, each with its own origin of the latency
+const (
+	// From the Arweave block timestamp
+	PipelineL1 = "l1"
+
+	// From the sequencer block timestamp
+	PipelineL2 = "l2"
+)
+
+// Stages in the order interactions pass them
+const (
+	// L1 interaction saved by the syncer
+	StageSync = "sync"
+
+	// L1 interaction passed to publishers by the forwarder
+	StageForward = "forward"
+
+	// L2 interaction saved by the relayer
+	StageRelay = "relay"
+
+	// L2 interaction uploaded by the bundler
+	StageBundle = "bundle"
+
+	// L2 interaction confirmed on Arweave by the checker
+	StageOnArweave = "on_arweave"
+)
+
+var stages = map[string][]string{
+	PipelineL1: {StageSync, StageForward},
+	PipelineL2: {StageRelay, StageBundle, StageOnArweave},
+}
+
+// Measures latency of interactions handled by a stage, counted from the origin of the pipeline.
+// Sampled latencies go to the histogram, SLO burn rate windows and the database (see Recorder).
+// Interactions are identified by a key that's the same in all stages of the pipeline:
+// Arweave transaction id for L1, id in the interactions table for L2.
+type Stage struct {
+	isEnabled        bool
+	pipeline         string
+	name             string
+	sampleRatio      float64
+	target           time.Duration
+	catchUpThreshold time.Duration
+
+	histogram      prometheus.Observer
+	catchUpSkipped prometheus.Counter
+	window         *window
+}
+
+func NewStage(config *config.Config, pipeline, name string) (self *Stage) {
+	self = new(Stage)
+	self.isEnabled = config.Freshness.Enabled
+	self.pipeline = pipeline
+	self.name = name
+	self.sampleRatio = config.Freshness.SampleRatio
+	self.target = getTarget(config, pipeline)
+	self.catchUpThreshold = config.Freshness.CatchUpThreshold
+
+	self.histogram = metrics.Latency.WithLabelValues(pipeline, name)
+	self.catchUpSkipped = metrics.CatchUpSkipped.WithLabelValues(pipeline, name)
+	self.window = metrics.getWindow(pipeline, name, config.Freshness.Objective)
+
+	buffer.setMaxSize(config.Freshness.MaxBufferSize)
+	return
+}
+
+func getTarget(config *config.Config, pipeline string) time.Duration {
+	if pipeline == PipelineL1 {
+		return config.Freshness.L1Target
+	}
+	return config.Freshness.L2Target
+}
+
+// Decides if the interaction's latency should be measured.
+// Decision depends only on the key, so all stages sample the same interactions
+func (self *Stage) IsSampled(key string) bool {
+	if !self.isEnabled {
+		return false
+	}
+	// Ids are often sequential, the hash spreads them evenly
+	h := sha256.Sum256([]byte(key))
+	return float64(binary.BigEndian.Uint64(h[:8]))/math.MaxUint64 < self.sampleRatio
+}
+
+// Measures latency from the origin till now, if the interaction gets sampled
+func (self *Stage) Observe(key string, origin time.Time) {
+	if !self.IsSampled(key) {
+		return
+	}
+	self.Record(key, origin)
+}
+
+// Measures latency from the origin till now, for interactions already picked with IsSampled
+func (self *Stage) Record(key string, origin time.Time) {
+	if !self.isEnabled || origin.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	latency := now.Sub(origin)
+	if latency < 0 {
+		// Clock skew
+		latency = 0
+	}
+
+	if self.catchUpThreshold > 0 && latency > self.catchUpThreshold {
+		// Old interactions handled after a downtime or replay would burn the budget of the current period
+		self.catchUpSkipped.Inc()
+		return
+	}
+
+	self.histogram.Observe(latency.Seconds())
+	self.window.add(now, latency <= self.target)
+	buffer.add(model.FreshnessSample{
+		Pipeline:       self.pipeline,
+		Stage:          self.name,
+		InteractionKey: key,
+		LatencyMs:      latency.Milliseconds(),
+		ObservedAt:     now,
+	})
+}
@@ -0,0 +1,46 @@
+package freshness
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+func newTestStage(name string) *Stage {
+	c := config.Default()
+	c.Freshness.Enabled = true
+	c.Freshness.SampleRatio = 0.5
+	c.Freshness.CatchUpThreshold = time.Hour
+	return NewStage(c, PipelineL2, name)
+}
+
+func TestSameInteractionsSampledByAllStages(t *testing.T) {
+	relay := newTestStage(StageRelay)
+	bundle := newTestStage(StageBundle)
+
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("%d", i)
+		assert.Equal(t, relay.IsSampled(key), bundle.IsSampled(key), key)
+		if relay.IsSampled(key) {
+			sampled++
+		}
+	}
+	assert.InDelta(t, 500, sampled, 100)
+}
+
+func TestCatchUpSkipped(t *testing.T) {
+	stage := newTestStage(StageOnArweave)
+	buffer.drain()
+
+	stage.Record("1", time.Now().Add(-2*time.Hour))
+	assert.Empty(t, buffer.drain())
+
+	stage.Record("2", time.Now().Add(-time.Minute))
+	samples := buffer.drain()
+	assert.Len(t, samples, 1)
+	assert.Equal(t, "2", samples[0].InteractionKey)
+}
@@ -0,0 +1,202 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/monitoring/report"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+)
+
+// Elects one of the replicas running the same mode against the same database.
+// Leader holds a row in the leader_leases table and renews it periodically,
+// a standby takes over after the lease expires or gets released.
+type Elector struct {
+	*task.Task
+
+	db     *gorm.DB
+	name   string
+	report *report.LeaderReport
+
+	leaseDuration time.Duration
+	renewInterval time.Duration
+
+	// Leader steps down this long before the lease expires, so the pipeline stops before a standby takes over
+	stepDownMargin time.Duration
+
+	// Start of the last successful renewal, leader steps down if it can't renew before the lease expires
+	lastRenewal time.Time
+
+	// Callbacks run upon gaining or losing leadership
+	mtx      sync.Mutex
+	onChange []func(isLeader bool)
+}
+
+// Takes the lease if it's free, expired or already held by this replica
+const acquireQuery = `
+INSERT INTO leader_leases (name, holder, expires_at, acquired_at)
+VALUES (@name, @holder, NOW() + make_interval(secs => @lease), NOW())
+ON CONFLICT (name) DO UPDATE SET
+	holder = EXCLUDED.holder,
+	expires_at = EXCLUDED.expires_at,
+	acquired_at = CASE WHEN leader_leases.holder = EXCLUDED.holder THEN leader_leases.acquired_at ELSE EXCLUDED.acquired_at END
+WHERE leader_leases.holder = EXCLUDED.holder OR leader_leases.expires_at < NOW()`
+
+// Name identifies the elected component, replicas of the same mode need to use the same name
+func NewElector(config *config.Config, name string) (self *Elector) {
+	self = new(Elector)
+	self.name = name
+	self.leaseDuration = config.LeaderElection.LeaseDuration
+	self.renewInterval = config.LeaderElection.RenewInterval
+
+	// Failure is noticed upon the next renewal, stopping the pipeline may take up to StopTimeout
+	self.stepDownMargin = self.renewInterval + config.StopTimeout
+
+	self.report = &report.LeaderReport{
+		Self: config.LeaderElection.Holder,
+	}
+	if self.report.Self == "" {
+		hostname, _ := os.Hostname()
+		self.report.Self = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	self.Task = task.NewTask(config, "leader-elector").
+		WithPeriodicSubtaskFunc(self.renewInterval, self.renew)
+
+	return
+}
+
+// Watchdog runs its tasks only while this replica is the leader, a standby waits for the lease.
+// Returned elector needs to run along with the watchdog and be released after the watchdog stops
+func WatchStandby(config *config.Config, name string, db *gorm.DB, monitor monitoring.Monitor, watchdog *task.Watchdog) (self *Elector) {
+	self = NewElector(config, name).
+		WithDB(db).
+		WithMonitor(monitor).
+		WithOnChange(func(isLeader bool) {
+			monitor.Clear()
+			watchdog.SetActive(isLeader)
+		})
+
+	watchdog.WithStandby()
+
+	return
+}
+
+func (self *Elector) WithDB(db *gorm.DB) *Elector {
+	self.db = db
+	return self
+}
+
+// Leadership is reported in the monitor's state
+func (self *Elector) WithMonitor(monitor monitoring.Monitor) *Elector {
+	monitor.GetReport().Leader = self.report
+	return self
+}
+
+func (self *Elector) WithOnChange(f func(isLeader bool)) *Elector {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.onChange = append(self.onChange, f)
+	return self
+}
+
+func (self *Elector) IsLeader() bool {
+	return self.report.State.IsLeader.Load()
+}
+
+func (self *Elector) renew() error {
+	ctx, cancel := context.WithTimeout(self.Ctx, self.renewInterval)
+	defer cancel()
+
+	// Lease is extended from the database's time during the request, not later than now
+	start := time.Now()
+	lease, err := self.acquire(ctx)
+	if err != nil {
+		self.report.Errors.LeaseErrors.Inc()
+		self.Log.WithError(err).Error("Failed to renew leader lease")
+
+		// Other replicas may take over soon, step down before the lease expires
+		if self.IsLeader() && time.Since(self.lastRenewal) >= self.leaseDuration-self.stepDownMargin {
+			self.setLeader(false)
+		}
+		return nil
+	}
+
+	self.report.State.Holder.Store(lease.Holder)
+
+	isLeader := lease.Holder == self.report.Self
+	if isLeader {
+		self.lastRenewal = start
+	}
+	self.setLeader(isLeader)
+	return nil
+}
+
+func (self *Elector) acquire(ctx context.Context) (lease model.LeaderLease, err error) {
+	err = self.db.WithContext(ctx).
+		Exec(acquireQuery, map[string]any{
+			"name":   self.name,
+			"holder": self.report.Self,
+			"lease":  self.leaseDuration.Seconds(),
+		}).Error
+	if err != nil {
+		return
+	}
+
+	err = self.db.WithContext(ctx).
+		First(&lease, "name = ?", self.name).Error
+	return
+}
+
+func (self *Elector) setLeader(isLeader bool) {
+	if self.report.State.IsLeader.Swap(isLeader) == isLeader {
+		return
+	}
+
+	self.report.State.NumTransitions.Inc()
+	if isLeader {
+		self.report.State.LeaderSince.Store(time.Now().Unix())
+		self.Log.WithField("name", self.name).WithField("holder", self.report.Self).Info("Elected the leader")
+	} else {
+		self.report.State.LeaderSince.Store(0)
+		self.Log.WithField("name", self.name).WithField("holder", self.report.Self).Warn("Lost leadership, becoming a standby")
+	}
+
+	self.mtx.Lock()
+	callbacks := self.onChange
+	self.mtx.Unlock()
+
+	for _, f := range callbacks {
+		f(isLeader)
+	}
+}
+
+// Gives up the lease so that a standby takes over without waiting for the expiration.
+// Call only after everything that requires leadership has stopped
+func (self *Elector) Release() {
+	if !self.IsLeader() {
+		return
+	}
+	self.report.State.IsLeader.Store(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), self.renewInterval)
+	defer cancel()
+
+	err := self.db.WithContext(ctx).
+		Where("name = ?", self.name).
+		Where("holder = ?", self.report.Self).
+		Delete(&model.LeaderLease{}).Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to release leader lease")
+		return
+	}
+	self.Log.WithField("name", self.name).Info("Released leader lease")
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
@@ -181,6 +184,11 @@ func (self *BlockDownloader) run() error {
 
 			self.Log.WithField("height", height).Trace("Downloading block")
 
+			// Block's path through the pipeline starts here
+			ctx, span := tracing.Start(self.Ctx, "block-downloader",
+				trace.WithNewRoot(),
+				trace.WithAttributes(attribute.Int64("height", int64(height))))
+
 			var block *arweave.Block
 			err := task.NewRetry().
 				WithContext(self.Ctx).
@@ -204,7 +212,7 @@ func (self *BlockDownloader) run() error {
 					return err
 				}).
 				Run(func() (err error) {
-					blocks, err := self.downloadBlocks(height, lastProcessedBlockHash)
+					blocks, err := self.downloadBlocks(ctx, height, lastProcessedBlockHash)
 					if err != nil {
 						return err
 					}
@@ -215,10 +223,15 @@ func (self *BlockDownloader) run() error {
 				})
 
 			if err != nil {
+				tracing.End(span, err)
 				self.Log.WithError(err).WithField("height", height).Error("Failed to download block, stop retrying")
 				return err
 			}
 
+			block.Trace.SetFromContext(ctx)
+			span.SetAttributes(attribute.Int("txs", len(block.Txs)))
+			span.End()
+
 			self.Log.
 				WithField("height", height).
 				WithField("len", len(block.Txs)).
@@ -239,7 +252,7 @@ func (self *BlockDownloader) run() error {
 	return nil
 }
 
-func (self *BlockDownloader) downloadBlocks(height uint64, lastProcessedBlockHash arweave.Base64String) (out []*arweave.Block, err error) {
+func (self *BlockDownloader) downloadBlocks(ctx context.Context, height uint64, lastProcessedBlockHash arweave.Base64String) (out []*arweave.Block, err error) {
 	var mtx sync.Mutex
 	var wg sync.WaitGroup
 
@@ -251,7 +264,7 @@ func (self *BlockDownloader) downloadBlocks(height uint64, lastProcessedBlockHas
 	for _, peer := range peers {
 		peer := peer
 		self.SubmitToWorker(func() {
-			block, err := self.downloadOneBlock(height, lastProcessedBlockHash, peer)
+			block, err := self.downloadOneBlock(ctx, height, lastProcessedBlockHash, peer)
 			if err != nil {
 				block = nil
 			}
@@ -270,8 +283,8 @@ func (self *BlockDownloader) downloadBlocks(height uint64, lastProcessedBlockHas
 	return
 }
 
-func (self *BlockDownloader) downloadOneBlock(height uint64, lastProcessedBlockHash arweave.Base64String, peer string) (block *arweave.Block, err error) {
-	ctx := context.WithValue(self.Ctx, arweave.ContextDisablePeers, true)
+func (self *BlockDownloader) downloadOneBlock(ctx context.Context, height uint64, lastProcessedBlockHash arweave.Base64String, peer string) (block *arweave.Block, err error) {
+	ctx = context.WithValue(ctx, arweave.ContextDisablePeers, true)
 	if len(peer) > 0 {
 		// Force using this peer if set
 		// Otherwise use arweave.net
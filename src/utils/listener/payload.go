@@ -2,6 +2,7 @@ package listener
 
 import (
 	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 )
 
 type Payload struct {
@@ -9,4 +10,6 @@ type Payload struct {
 	BlockHeight    int64
 	BlockTimestamp int64
 	Transactions   []*arweave.Transaction
+
+	Trace tracing.Carrier
 }
@@ -12,8 +12,11 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/smartweave"
 	"github.com/warp-contracts/syncer/src/utils/task"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Fills in transactions for a given block
@@ -121,7 +124,10 @@ func (self *TransactionDownloader) run() error {
 	// Listen for new blocks (blocks)
 	// Finishes when Listener is stopping
 	for block := range self.input {
-		transactions, err := self.downloadTransactions(block)
+		ctx, span := block.Trace.StartSpan(self.Ctx, "transaction-downloader",
+			trace.WithAttributes(attribute.Int64("height", block.Height)))
+		transactions, err := self.downloadTransactions(ctx, block)
+		tracing.End(span, err)
 		if self.IsStopping.Load() {
 			// Neglect trhose transactions
 			return nil
@@ -134,19 +140,21 @@ func (self *TransactionDownloader) run() error {
 
 		// Blocks until a monitorTranactions is ready to receive
 		// or Listener is stopped
-		self.Output <- &Payload{
+		payload := &Payload{
 			BlockHash:      block.IndepHash.Bytes(),
 			BlockHeight:    block.Height,
 			BlockTimestamp: block.Timestamp,
 			Transactions:   transactions,
 		}
+		payload.Trace.InheritFrom(&block.Trace)
+		self.Output <- payload
 
 	}
 
 	return nil
 }
 
-func (self *TransactionDownloader) downloadTransactions(block *arweave.Block) (out []*arweave.Transaction, err error) {
+func (self *TransactionDownloader) downloadTransactions(ctx context.Context, block *arweave.Block) (out []*arweave.Transaction, err error) {
 	if len(block.Txs) == 0 {
 		//Skip
 		return
@@ -206,7 +214,7 @@ func (self *TransactionDownloader) downloadTransactions(block *arweave.Block) (o
 					return err
 				}).
 				Run(func() error {
-					tx, err = self.client.GetTransactionById(self.Ctx, txId)
+					tx, err = self.client.GetTransactionById(ctx, txId)
 					if err != nil {
 						self.Log.WithField("tx", txId).Error("Failed to download transaction")
 						return err
@@ -230,7 +238,7 @@ func (self *TransactionDownloader) downloadTransactions(block *arweave.Block) (o
 					// }
 
 					if self.isGetTransactionData(tx) {
-						tx.Data, err = self.downloadTransactionData(tx)
+						tx.Data, err = self.downloadTransactionData(ctx, tx)
 						if err != nil {
 							self.monitor.GetReport().TransactionDownloader.Errors.DataDownload.Inc()
 							self.Log.WithField("tx", txId).Error("Failed to download transaction data, retry downloading...")
@@ -271,8 +279,8 @@ func (self *TransactionDownloader) downloadTransactions(block *arweave.Block) (o
 	return
 }
 
-func (self *TransactionDownloader) downloadTransactionData(tx *arweave.Transaction) (arweave.Base64String, error) {
-	buf, err := self.client.GetTransactionDataById(self.Ctx, tx)
+func (self *TransactionDownloader) downloadTransactionData(ctx context.Context, tx *arweave.Transaction) (arweave.Base64String, error) {
+	buf, err := self.client.GetTransactionDataById(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +38,27 @@ func Init(config *config.Config) (err error) {
 	l.SetFormatter(formatter)
 
 	logger = l
+
+	// Log level can be changed without a restart
+	watchLevel(l)
+
 	return nil
 }
 
+func watchLevel(l *logrus.Logger) {
+	config.OnChange(func(newConfig *config.Config) {
+		level, err := logrus.ParseLevel(newConfig.LogLevel)
+		if err != nil {
+			l.WithError(err).Error("Invalid log level in the new configuration, keeping the previous one")
+			return
+		}
+		if level != l.GetLevel() {
+			l.SetLevel(level)
+			l.WithField("level", level).Info("Log level changed")
+		}
+	})
+}
+
 func InitWithLogger(l logrus.FieldLogger) {
 	logger = l
 }
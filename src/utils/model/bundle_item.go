@@ -2,8 +2,12 @@ package model
 
 import (
 	"database/sql"
+	"encoding/json"
+	"strconv"
 	"time"
 
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+
 	"github.com/jackc/pgtype"
 )
 
@@ -32,6 +36,14 @@ type BundleItem struct {
 	BlockHeight sql.NullInt64
 	// Response from bundlr.network
 	BundlrResponse pgtype.JSONB
+	// Size of the uploaded data item in bytes
+	Size sql.NullInt64
+	// Price of the upload in winc
+	Price sql.NullInt64
+	// Explicit priority class, items with priority > 0 are uploaded first
+	Priority int16
+	// Contract of the interaction, not stored in this table. Filled by the poller or from tags
+	ContractId string `gorm:"->"`
 	// Time of the last update to this row
 	UpdatedAt time.Time
 }
@@ -39,3 +51,59 @@ type BundleItem struct {
 func (BundleItem) TableName() string {
 	return "bundle_items"
 }
+
+// Tags set by the sequencer
+func (self *BundleItem) GetTags() (tags bundlr.Tags, err error) {
+	if self.Tags.Status != pgtype.Present {
+		return
+	}
+
+	// Accept {} as empty tags
+	if string(self.Tags.Bytes) == "{}" {
+		return
+	}
+
+	err = json.Unmarshal(self.Tags.Bytes, &tags)
+	return
+}
+
+// Id of the contract this interaction belongs to.
+// Falls back to the tags set by the sequencer if it wasn't fetched from the database.
+func (self *BundleItem) GetContractId() string {
+	if self.ContractId != "" {
+		return self.ContractId
+	}
+
+	tags, err := self.GetTags()
+	if err != nil {
+		return ""
+	}
+
+	for _, tag := range tags {
+		if tag.Name == "Contract-Tx-Id" || tag.Name == "Contract" {
+			self.ContractId = tag.Value
+			break
+		}
+	}
+	return self.ContractId
+}
+
+// Time of the sequencer block with this interaction, taken from the tags set by the sequencer.
+// Zero if the tag is missing.
+func (self *BundleItem) GetSequencerTimestamp() time.Time {
+	tags, err := self.GetTags()
+	if err != nil {
+		return time.Time{}
+	}
+
+	value, ok := tags.Get("Sequencer-Timestamp")
+	if !ok {
+		return time.Time{}
+	}
+
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -10,4 +10,5 @@ type BundleItemNotification struct {
 	Transaction   *pgtype.JSONB `json:"tx"`
 	Tags          *pgtype.JSONB `json:"tg"`
 	DataItem      *string       `json:"di"`
+	Priority      int16         `json:"pr"`
 }
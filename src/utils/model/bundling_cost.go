@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	TableBundlingCost = "bundling_costs"
+)
+
+// Daily aggregate of upload costs, per bundling service and per contract
+type BundlingCost struct {
+	// Day of the upload
+	Day time.Time `gorm:"primaryKey;type:date"`
+
+	// Service used to bundle the data items
+	Service BundlingService `gorm:"primaryKey"`
+
+	// Contract the interactions belong to. Empty for plain data items
+	ContractId string `gorm:"primaryKey"`
+
+	// Number of uploads
+	Uploads int64
+
+	// Sum of uploaded bytes
+	Size int64
+
+	// Sum of prices in winc
+	Price int64
+}
+
+func (BundlingCost) TableName() string {
+	return TableBundlingCost
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/jackc/pgtype"
@@ -29,6 +30,18 @@ type DataItem struct {
 	// Response from bundlr.network
 	Response pgtype.JSONB
 
+	// Size of the uploaded data item in bytes
+	Size pgtype.Int8
+
+	// Price of the upload in winc
+	Price pgtype.Int8
+
+	// Explicit priority class, items with priority > 0 are uploaded first
+	Priority int16
+
+	// Contract this data item relates to, used for fair scheduling. Optional
+	ContractId sql.NullString
+
 	// Time of the last update to this row
 	UpdatedAt time.Time
 
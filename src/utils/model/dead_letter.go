@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+const (
+	TableDeadLetter = "dead_letters"
+)
+
+// Item that a pipeline stage failed to process or save
+type DeadLetter struct {
+	Id int64 `gorm:"primaryKey" json:"id"`
+
+	// Mode that produced the item, e.g. syncer
+	Source string `json:"source"`
+
+	// Name of the stage (task) that failed
+	Stage string `json:"stage"`
+
+	// Error returned by the stage
+	Error string `json:"error"`
+
+	// Failed item serialized to JSON
+	Payload pgtype.JSONB `json:"payload"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Set after the item was successfully replayed
+	ReplayedAt pgtype.Timestamptz `json:"replayed_at"`
+}
+
+func (DeadLetter) TableName() string {
+	return TableDeadLetter
+}
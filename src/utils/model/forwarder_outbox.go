@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	TableForwarderOutbox       = "forwarder_outbox"
+	TableForwarderOutboxFailed = "forwarder_outbox_failed"
+)
+
+// Sort key of the last interaction delivered by one of the forwarder's publishers
+type ForwarderOutbox struct {
+	// Name of the publisher
+	Publisher string `gorm:"primaryKey"`
+
+	// Source of the interactions, same as interactions.source
+	Source string `gorm:"primaryKey"`
+
+	// Interactions up to this sort key were delivered
+	SortKey string
+
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (ForwarderOutbox) TableName() string {
+	return TableForwarderOutbox
+}
+
+// Interaction one of the forwarder's publishers failed to deliver, replayed after the restart
+type ForwarderOutboxFailed struct {
+	Publisher string `gorm:"primaryKey"`
+	Source    string `gorm:"primaryKey"`
+	SortKey   string `gorm:"primaryKey"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (ForwarderOutboxFailed) TableName() string {
+	return TableForwarderOutboxFailed
+}
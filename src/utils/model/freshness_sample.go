@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	TableFreshnessSample = "freshness_samples"
+)
+
+// Sampled latency of an interaction, from the origin of the pipeline to the stage that handled it
+type FreshnessSample struct {
+	Id int64 `gorm:"primaryKey" json:"id"`
+
+	// l1 (from the Arweave block timestamp) or l2 (from the sequencer block timestamp)
+	Pipeline string `json:"pipeline"`
+
+	// Stage that handled the interaction, e.g. sync
+	Stage string `json:"stage"`
+
+	// Same for all stages of the pipeline: Arweave transaction id for l1, id in the interactions table for l2
+	InteractionKey string `json:"interaction_key"`
+
+	LatencyMs int64 `json:"latency_ms"`
+
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+func (FreshnessSample) TableName() string {
+	return TableFreshnessSample
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	TableLeaderLease = "leader_leases"
+)
+
+// Lease of an active/standby replica, only the holder of a valid lease runs the pipeline
+type LeaderLease struct {
+	// Elected component, e.g. syncer
+	Name string `gorm:"primaryKey" json:"name"`
+
+	// Identity of the replica holding the lease
+	Holder string `json:"holder"`
+
+	// Lease is taken over by another replica after it expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// When the current holder acquired the lease
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+func (LeaderLease) TableName() string {
+	return TableLeaderLease
+}
@@ -27,12 +27,26 @@ type InteractionNotification struct {
 	Source       string `json:"source"`
 	Interaction  string `json:"interaction"`
 	SrcTxId      string `json:"srcTxId"`
+
+	// Not sent, used to derive the id of the entry in Redis Streams and to track delivery
+	SortKey string `json:"-"`
+
+	// Not sent, source of the interaction used to track delivery
+	InteractionSource string `json:"-"`
 }
 
 func (self *InteractionNotification) MarshalBinary() (data []byte, err error) {
 	return json.Marshal(self)
 }
 
+func (self *InteractionNotification) GetSortKey() string {
+	return self.SortKey
+}
+
+func (self *InteractionNotification) GetInteractionSource() string {
+	return self.InteractionSource
+}
+
 type AppSyncContractNotification struct {
 	ContractTxId   string `json:"contractTxId"`
 	Creator        string `json:"creator"`
@@ -0,0 +1,35 @@
+-- +migrate Down
+DROP TABLE IF EXISTS bundling_costs;
+ALTER TABLE data_items DROP COLUMN IF EXISTS size;
+ALTER TABLE data_items DROP COLUMN IF EXISTS price;
+ALTER TABLE bundle_items DROP COLUMN IF EXISTS size;
+ALTER TABLE bundle_items DROP COLUMN IF EXISTS price;
+
+-- +migrate Up
+-- Size of the uploaded data item in bytes and its price in winc (Arweave atomic units)
+ALTER TABLE bundle_items ADD COLUMN IF NOT EXISTS size bigint;
+ALTER TABLE bundle_items ADD COLUMN IF NOT EXISTS price bigint;
+ALTER TABLE data_items ADD COLUMN IF NOT EXISTS size bigint;
+ALTER TABLE data_items ADD COLUMN IF NOT EXISTS price bigint;
+
+CREATE TABLE IF NOT EXISTS bundling_costs (
+    -- Day of the upload
+    day date NOT NULL,
+
+    -- Service used to bundle the data items
+    service bundling_service NOT NULL,
+
+    -- Contract the interactions belong to. Empty for data items that aren't interactions
+    contract_id TEXT NOT NULL DEFAULT '',
+
+    -- Number of uploads
+    uploads bigint NOT NULL DEFAULT 0,
+
+    -- Sum of uploaded bytes
+    size bigint NOT NULL DEFAULT 0,
+
+    -- Sum of prices in winc
+    price bigint NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (day, service, contract_id)
+);
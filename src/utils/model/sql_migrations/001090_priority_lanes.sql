@@ -0,0 +1,17 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_data_items_pending_priority;
+DROP INDEX IF EXISTS idx_bundle_items_pending_priority;
+ALTER TABLE data_items DROP COLUMN IF EXISTS contract_id;
+ALTER TABLE data_items DROP COLUMN IF EXISTS priority;
+ALTER TABLE bundle_items DROP COLUMN IF EXISTS priority;
+
+-- +migrate Up
+-- Explicit priority class, items with priority > 0 go to the high priority lane
+ALTER TABLE bundle_items ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+ALTER TABLE data_items ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+
+-- Contract the data item relates to, used for fair scheduling. Optional
+ALTER TABLE data_items ADD COLUMN IF NOT EXISTS contract_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_bundle_items_pending_priority ON bundle_items USING btree (priority DESC, interaction_id ASC) WHERE state = 'PENDING'::bundle_state;
+CREATE INDEX IF NOT EXISTS idx_data_items_pending_priority ON data_items USING btree (priority DESC, updated_at ASC) WHERE state = 'PENDING'::bundle_state;
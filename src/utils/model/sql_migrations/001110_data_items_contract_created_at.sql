@@ -0,0 +1,6 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_data_items_contract_id_created_at;
+
+-- +migrate Up
+-- Finds meta info data items of L1 interactions, used when comparing relayer's output in the shadow mode
+CREATE INDEX IF NOT EXISTS idx_data_items_contract_id_created_at ON data_items USING btree (contract_id, created_at) WHERE contract_id IS NOT NULL;
@@ -0,0 +1,24 @@
+-- +migrate Down
+DROP TABLE IF EXISTS webhook_retries;
+
+-- +migrate Up
+-- Batches of notifications that webhook publishers failed to deliver, redelivered in order of id
+CREATE TABLE IF NOT EXISTS webhook_retries (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Name of the publisher, one per configured endpoint
+    publisher TEXT NOT NULL,
+
+    -- JSON array of notifications, sent as is
+    payload jsonb NOT NULL,
+
+    -- Number of failed redelivery attempts
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    -- Error from the last attempt
+    last_error TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_retries_publisher_id ON webhook_retries USING btree (publisher, id);
@@ -0,0 +1,16 @@
+-- +migrate Down
+DROP PUBLICATION IF EXISTS syncer_changes;
+
+-- +migrate Up
+-- Changes streamed to the bundler, sender and forwarder through logical replication.
+-- Consumers create their own replication slots, nothing is retained until they do.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = 'syncer_changes') THEN
+		CREATE PUBLICATION syncer_changes FOR TABLE interactions, bundle_items, data_items, sync_state;
+	END IF;
+END
+$$;
+-- +migrate StatementEnd
+
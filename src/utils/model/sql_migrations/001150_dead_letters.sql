@@ -0,0 +1,27 @@
+-- +migrate Down
+DROP TABLE IF EXISTS dead_letters;
+
+-- +migrate Up
+-- Items that pipeline stages failed to process or save, kept for inspection and replay
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Mode that produced the item, e.g. syncer
+    source TEXT NOT NULL,
+
+    -- Name of the stage (task) that failed
+    stage TEXT NOT NULL,
+
+    -- Error returned by the stage
+    error TEXT NOT NULL,
+
+    -- Failed item serialized to JSON
+    payload jsonb NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Set after the item was successfully replayed
+    replayed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_source_stage_id ON dead_letters USING btree (source, stage, id);
@@ -0,0 +1,18 @@
+-- +migrate Down
+DROP TABLE IF EXISTS leader_leases;
+
+-- +migrate Up
+-- Leases of active/standby replicas, only the holder of a valid lease runs the pipeline
+CREATE TABLE IF NOT EXISTS leader_leases (
+    -- Elected component, e.g. syncer
+    name TEXT PRIMARY KEY,
+
+    -- Identity of the replica holding the lease
+    holder TEXT NOT NULL,
+
+    -- Lease is taken over by another replica after it expires
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- When the current holder acquired the lease
+    acquired_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
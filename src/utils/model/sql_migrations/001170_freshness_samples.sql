@@ -0,0 +1,23 @@
+-- +migrate Down
+DROP TABLE IF EXISTS freshness_samples;
+
+-- +migrate Up
+-- Sampled latencies of interactions, from the origin of the pipeline to the stage that handled them
+CREATE TABLE IF NOT EXISTS freshness_samples (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- l1 (from the Arweave block timestamp) or l2 (from the sequencer block timestamp)
+    pipeline TEXT NOT NULL,
+
+    -- Stage that handled the interaction, e.g. sync
+    stage TEXT NOT NULL,
+
+    -- Same for all stages of the pipeline, samples of one interaction are joined by it
+    interaction_key TEXT NOT NULL DEFAULT '',
+
+    latency_ms BIGINT NOT NULL,
+
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_freshness_samples_observed_at ON freshness_samples USING btree (observed_at);
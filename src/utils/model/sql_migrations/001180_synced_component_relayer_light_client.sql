@@ -0,0 +1,4 @@
+-- +migrate Down
+
+-- +migrate Up
+ALTER TYPE synced_component ADD VALUE IF NOT EXISTS 'RelayerLightClient';
\ No newline at end of file
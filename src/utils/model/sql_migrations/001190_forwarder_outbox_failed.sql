@@ -0,0 +1,18 @@
+-- +migrate Down
+DROP TABLE IF EXISTS forwarder_outbox_failed;
+
+-- +migrate Up
+-- Interactions the forwarder's publishers failed to deliver. Saved sort key moves past them, they're replayed separately
+CREATE TABLE IF NOT EXISTS forwarder_outbox_failed (
+    -- Name of the publisher
+    publisher TEXT NOT NULL,
+
+    -- Source of the interactions, same as interactions.source
+    source TEXT NOT NULL,
+
+    sort_key TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (publisher, source, sort_key)
+);
@@ -8,6 +8,7 @@ const (
 	SyncedComponentForwarder           SyncedComponent = "Forwarder"
 	SyncedComponentSequencer           SyncedComponent = "Sequencer"
 	SyncedComponentRelayer             SyncedComponent = "Relayer"
+	SyncedComponentRelayerLightClient  SyncedComponent = "RelayerLightClient"
 	SyncedComponentWarpySyncerAvax     SyncedComponent = "WarpySyncerAvax"
 	SyncedComponentWarpySyncerArbitrum SyncedComponent = "WarpySyncerArbitrum"
 	SyncedComponentWarpySyncerMode     SyncedComponent = "WarpySyncerMode"
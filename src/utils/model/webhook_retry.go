@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+const (
+	TableWebhookRetry = "webhook_retries"
+)
+
+// Batch of notifications that a webhook publisher failed to deliver
+type WebhookRetry struct {
+	Id int64 `gorm:"primaryKey"`
+
+	// Name of the publisher, one per configured endpoint
+	Publisher string
+
+	// JSON array of notifications, sent as is
+	Payload pgtype.JSONB
+
+	// Number of failed redelivery attempts
+	Attempts int
+
+	// Error from the last attempt
+	LastError pgtype.Text
+
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (WebhookRetry) TableName() string {
+	return TableWebhookRetry
+}
@@ -11,6 +11,10 @@ type Collector struct {
 	UpForSeconds *prometheus.Desc
 
 	PendingBundleItems          *prometheus.Desc
+	PendingBundleItemsAge       *prometheus.Desc
+	HighLaneQueueSize           *prometheus.Desc
+	NormalLaneQueueSize         *prometheus.Desc
+	PriorityItems               *prometheus.Desc
 	BundlesFromNotifications    *prometheus.Desc
 	AdditionalFetches           *prometheus.Desc
 	BundlesFromSelects          *prometheus.Desc
@@ -27,12 +31,22 @@ type Collector struct {
 	ConfirmationsSavedToDbError *prometheus.Desc
 	AdditionalFetchError        *prometheus.Desc
 	PollerFetchError            *prometheus.Desc
+
+	// Balance
+	IrysBalance        *prometheus.Desc
+	TurboBalance       *prometheus.Desc
+	IrysBalanceErrors  *prometheus.Desc
+	TurboBalanceErrors *prometheus.Desc
 }
 
 func NewCollector() *Collector {
 	return &Collector{
 		UpForSeconds:                prometheus.NewDesc("up_for_seconds", "", nil, nil),
 		PendingBundleItems:          prometheus.NewDesc("pending_bundle_items", "", nil, nil),
+		PendingBundleItemsAge:       prometheus.NewDesc("pending_bundle_items_age", "", nil, nil),
+		HighLaneQueueSize:           prometheus.NewDesc("high_lane_queue_size", "Items waiting in the high priority lane", nil, nil),
+		NormalLaneQueueSize:         prometheus.NewDesc("normal_lane_queue_size", "Items waiting in the normal lane", nil, nil),
+		PriorityItems:               prometheus.NewDesc("priority_items", "Items scheduled in the high priority lane", nil, nil),
 		BundlesFromNotifications:    prometheus.NewDesc("bundles_from_notifications", "", nil, nil),
 		AdditionalFetches:           prometheus.NewDesc("additional_fetches", "", nil, nil),
 		BundlesFromSelects:          prometheus.NewDesc("bundles_from_selects", "", nil, nil),
@@ -49,6 +63,12 @@ func NewCollector() *Collector {
 		ConfirmationsSavedToDbError: prometheus.NewDesc("confirmations_saved_to_db_error", "", nil, nil),
 		AdditionalFetchError:        prometheus.NewDesc("additional_fetch_error", "", nil, nil),
 		PollerFetchError:            prometheus.NewDesc("poller_fetch_error", "", nil, nil),
+
+		// Balance
+		IrysBalance:        prometheus.NewDesc("irys_balance", "Balance of the bundling wallet in Irys (winc)", nil, nil),
+		TurboBalance:       prometheus.NewDesc("turbo_balance", "Balance of the bundling wallet in Turbo (winc)", nil, nil),
+		IrysBalanceErrors:  prometheus.NewDesc("irys_balance_errors", "", nil, nil),
+		TurboBalanceErrors: prometheus.NewDesc("turbo_balance_errors", "", nil, nil),
 	}
 }
 
@@ -62,6 +82,10 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- self.UpForSeconds
 
 	ch <- self.PendingBundleItems
+	ch <- self.PendingBundleItemsAge
+	ch <- self.HighLaneQueueSize
+	ch <- self.NormalLaneQueueSize
+	ch <- self.PriorityItems
 	ch <- self.BundlesFromNotifications
 	ch <- self.BundlesFromSelects
 	ch <- self.RetriedBundlesFromSelects
@@ -80,6 +104,12 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- self.ConfirmationsSavedToDbError
 	ch <- self.AdditionalFetchError
 	ch <- self.PollerFetchError
+
+	// Balance
+	ch <- self.IrysBalance
+	ch <- self.TurboBalance
+	ch <- self.IrysBalanceErrors
+	ch <- self.TurboBalanceErrors
 }
 
 // Collect implements required collect function for all promehteus collectors
@@ -89,6 +119,10 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 
 	// Bundler
 	ch <- prometheus.MustNewConstMetric(self.PendingBundleItems, prometheus.GaugeValue, float64(self.monitor.Report.Bundler.State.PendingBundleItems.Load()))
+	ch <- prometheus.MustNewConstMetric(self.PendingBundleItemsAge, prometheus.GaugeValue, float64(self.monitor.Report.Bundler.State.PendingBundleItemsAge.Load()))
+	ch <- prometheus.MustNewConstMetric(self.HighLaneQueueSize, prometheus.GaugeValue, float64(self.monitor.Report.Bundler.State.HighLaneQueueSize.Load()))
+	ch <- prometheus.MustNewConstMetric(self.NormalLaneQueueSize, prometheus.GaugeValue, float64(self.monitor.Report.Bundler.State.NormalLaneQueueSize.Load()))
+	ch <- prometheus.MustNewConstMetric(self.PriorityItems, prometheus.CounterValue, float64(self.monitor.Report.Bundler.State.PriorityItems.Load()))
 	ch <- prometheus.MustNewConstMetric(self.BundlesFromNotifications, prometheus.CounterValue, float64(self.monitor.Report.Bundler.State.BundlesFromNotifications.Load()))
 	ch <- prometheus.MustNewConstMetric(self.AdditionalFetches, prometheus.CounterValue, float64(self.monitor.Report.Bundler.State.AdditionalFetches.Load()))
 	ch <- prometheus.MustNewConstMetric(self.BundlesFromSelects, prometheus.CounterValue, float64(self.monitor.Report.Bundler.State.BundlesFromSelects.Load()))
@@ -105,4 +139,10 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(self.ConfirmationsSavedToDbError, prometheus.CounterValue, float64(self.monitor.Report.Bundler.Errors.ConfirmationsSavedToDbError.Load()))
 	ch <- prometheus.MustNewConstMetric(self.AdditionalFetchError, prometheus.CounterValue, float64(self.monitor.Report.Bundler.Errors.AdditionalFetchError.Load()))
 	ch <- prometheus.MustNewConstMetric(self.PollerFetchError, prometheus.CounterValue, float64(self.monitor.Report.Bundler.Errors.PollerFetchError.Load()))
+
+	// Balance
+	ch <- prometheus.MustNewConstMetric(self.IrysBalance, prometheus.GaugeValue, float64(self.monitor.Report.Balance.State.IrysBalance.Load()))
+	ch <- prometheus.MustNewConstMetric(self.TurboBalance, prometheus.GaugeValue, float64(self.monitor.Report.Balance.State.TurboBalance.Load()))
+	ch <- prometheus.MustNewConstMetric(self.IrysBalanceErrors, prometheus.CounterValue, float64(self.monitor.Report.Balance.Errors.IrysBalanceErrors.Load()))
+	ch <- prometheus.MustNewConstMetric(self.TurboBalanceErrors, prometheus.CounterValue, float64(self.monitor.Report.Balance.Errors.TurboBalanceErrors.Load()))
 }
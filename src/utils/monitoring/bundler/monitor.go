@@ -30,6 +30,7 @@ func NewMonitor() (self *Monitor) {
 		Run:         &report.RunReport{},
 		Bundler:     &report.BundlerReport{},
 		NetworkInfo: &report.NetworkInfoReport{},
+		Balance:     &report.BalanceReport{},
 	}
 
 	// Initialization
@@ -60,6 +61,10 @@ func (self *Monitor) SetPermanentError(err error) {
 }
 
 func (self *Monitor) IsOK() bool {
+	if self.Report.Balance.State.IsIrysBalanceLow.Load() || self.Report.Balance.State.IsTurboBalanceLow.Load() {
+		// Uploads will fail soon
+		return false
+	}
 	return !self.IsFatalError.Load()
 }
 
@@ -57,6 +57,8 @@ type Collector struct {
 	RedisPublishErrors     []*prometheus.Desc
 	RedisPersistentErrors  []*prometheus.Desc
 	RedisMessagesPublished []*prometheus.Desc
+	RedisDuplicatesSkipped []*prometheus.Desc
+	RedisOutOfOrder        []*prometheus.Desc
 	RedisPoolHits          []*prometheus.Desc
 	RedisPoolIdleConns     []*prometheus.Desc
 	RedisPoolMisses        []*prometheus.Desc
@@ -64,6 +66,18 @@ type Collector struct {
 	RedisPoolTimeouts      []*prometheus.Desc
 	RedisPoolTotalConns    []*prometheus.Desc
 
+	// Webhook publisher
+	WebhookDeliveryErrors      []*prometheus.Desc
+	WebhookPersistentErrors    []*prometheus.Desc
+	WebhookRetryQueueErrors    []*prometheus.Desc
+	WebhookIsFailing           []*prometheus.Desc
+	WebhookMessagesDelivered   []*prometheus.Desc
+	WebhookBatchesDelivered    []*prometheus.Desc
+	WebhookBatchesQueued       []*prometheus.Desc
+	WebhookBatchesRedelivered  []*prometheus.Desc
+	WebhookBatchesDeadLettered []*prometheus.Desc
+	WebhookRetryQueueSize      []*prometheus.Desc
+
 	// App sync publisher
 	AppSyncPublishErrors     *prometheus.Desc
 	AppSyncPersistentErrors  *prometheus.Desc
@@ -121,6 +135,8 @@ func NewCollector(config *config.Config) *Collector {
 		RedisPublishErrors:     make([]*prometheus.Desc, len(config.Redis)),
 		RedisPersistentErrors:  make([]*prometheus.Desc, len(config.Redis)),
 		RedisMessagesPublished: make([]*prometheus.Desc, len(config.Redis)),
+		RedisDuplicatesSkipped: make([]*prometheus.Desc, len(config.Redis)),
+		RedisOutOfOrder:        make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolHits:          make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolIdleConns:     make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolMisses:        make([]*prometheus.Desc, len(config.Redis)),
@@ -128,6 +144,18 @@ func NewCollector(config *config.Config) *Collector {
 		RedisPoolTimeouts:      make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolTotalConns:    make([]*prometheus.Desc, len(config.Redis)),
 
+		// Webhook publisher
+		WebhookDeliveryErrors:      make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookPersistentErrors:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookRetryQueueErrors:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookIsFailing:           make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookMessagesDelivered:   make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesDelivered:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesQueued:       make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesRedelivered:  make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesDeadLettered: make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookRetryQueueSize:      make([]*prometheus.Desc, len(config.Webhook)),
+
 		// App sync publisher
 		AppSyncPublishErrors:     prometheus.NewDesc("error_app_sync_publish", "", nil, labels),
 		AppSyncPersistentErrors:  prometheus.NewDesc("error_app_sync_persistent", "", nil, labels),
@@ -139,6 +167,8 @@ func NewCollector(config *config.Config) *Collector {
 		collector.RedisPublishErrors[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_publish_errors", i), "", nil, nil)
 		collector.RedisPersistentErrors[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_persistent_errors", i), "", nil, nil)
 		collector.RedisMessagesPublished[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_messages_published", i), "", nil, nil)
+		collector.RedisDuplicatesSkipped[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_duplicates_skipped", i), "", nil, nil)
+		collector.RedisOutOfOrder[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_out_of_order", i), "", nil, nil)
 		collector.RedisPoolHits[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_hits", i), "", nil, nil)
 		collector.RedisPoolIdleConns[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_idle_conns", i), "", nil, nil)
 		collector.RedisPoolMisses[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_misses", i), "", nil, nil)
@@ -147,6 +177,20 @@ func NewCollector(config *config.Config) *Collector {
 		collector.RedisPoolTotalConns[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_total_conns", i), "", nil, nil)
 	}
 
+	for i := range config.Webhook {
+		// Webhook publisher
+		collector.WebhookDeliveryErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_delivery_errors", i), "", nil, nil)
+		collector.WebhookPersistentErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_persistent_errors", i), "", nil, nil)
+		collector.WebhookRetryQueueErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_retry_queue_errors", i), "", nil, nil)
+		collector.WebhookIsFailing[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_is_failing", i), "", nil, nil)
+		collector.WebhookMessagesDelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_messages_delivered", i), "", nil, nil)
+		collector.WebhookBatchesDelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_delivered", i), "", nil, nil)
+		collector.WebhookBatchesQueued[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_queued", i), "", nil, nil)
+		collector.WebhookBatchesRedelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_redelivered", i), "", nil, nil)
+		collector.WebhookBatchesDeadLettered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_dead_lettered", i), "", nil, nil)
+		collector.WebhookRetryQueueSize[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_retry_queue_size", i), "", nil, nil)
+	}
+
 	return collector
 }
 
@@ -195,6 +239,8 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- self.RedisPublishErrors[i]
 		ch <- self.RedisPersistentErrors[i]
 		ch <- self.RedisMessagesPublished[i]
+		ch <- self.RedisDuplicatesSkipped[i]
+		ch <- self.RedisOutOfOrder[i]
 		ch <- self.RedisPoolHits[i]
 		ch <- self.RedisPoolIdleConns[i]
 		ch <- self.RedisPoolMisses[i]
@@ -202,6 +248,20 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- self.RedisPoolTimeouts[i]
 		ch <- self.RedisPoolTotalConns[i]
 	}
+
+	// Webhook publisher
+	for i := range self.monitor.Report.WebhookPublishers {
+		ch <- self.WebhookDeliveryErrors[i]
+		ch <- self.WebhookPersistentErrors[i]
+		ch <- self.WebhookRetryQueueErrors[i]
+		ch <- self.WebhookIsFailing[i]
+		ch <- self.WebhookMessagesDelivered[i]
+		ch <- self.WebhookBatchesDelivered[i]
+		ch <- self.WebhookBatchesQueued[i]
+		ch <- self.WebhookBatchesRedelivered[i]
+		ch <- self.WebhookBatchesDeadLettered[i]
+		ch <- self.WebhookRetryQueueSize[i]
+	}
 	// App sync publisher
 	ch <- self.AppSyncPublishErrors
 	ch <- self.AppSyncPersistentErrors
@@ -254,6 +314,8 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(self.RedisPublishErrors[i], prometheus.CounterValue, float64(redisPublisher.Errors.Publish.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPersistentErrors[i], prometheus.CounterValue, float64(redisPublisher.Errors.PersistentFailure.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisMessagesPublished[i], prometheus.CounterValue, float64(redisPublisher.State.MessagesPublished.Load()))
+		ch <- prometheus.MustNewConstMetric(self.RedisDuplicatesSkipped[i], prometheus.CounterValue, float64(redisPublisher.State.DuplicatesSkipped.Load()))
+		ch <- prometheus.MustNewConstMetric(self.RedisOutOfOrder[i], prometheus.CounterValue, float64(redisPublisher.State.OutOfOrder.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolHits[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolHits.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolIdleConns[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolIdleConns.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolMisses[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolMisses.Load()))
@@ -261,6 +323,21 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolTimeouts[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolTimeouts.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolTotalConns[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolTotalConns.Load()))
 	}
+
+	// Webhook publisher
+	for i := range self.monitor.Report.WebhookPublishers {
+		webhookPublisher := &self.monitor.Report.WebhookPublishers[i]
+		ch <- prometheus.MustNewConstMetric(self.WebhookDeliveryErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.Delivery.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookPersistentErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.PersistentFailure.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookRetryQueueErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.RetryQueue.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookIsFailing[i], prometheus.GaugeValue, float64(webhookPublisher.State.IsFailing.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookMessagesDelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.MessagesDelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesDelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesDelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesQueued[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesQueued.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesRedelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesRedelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesDeadLettered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesDeadLettered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookRetryQueueSize[i], prometheus.GaugeValue, float64(webhookPublisher.State.RetryQueueSize.Load()))
+	}
 	// App sync publisher
 	ch <- prometheus.MustNewConstMetric(self.AppSyncPublishErrors, prometheus.CounterValue, float64(self.monitor.Report.AppSyncPublisher.Errors.Publish.Load()))
 	ch <- prometheus.MustNewConstMetric(self.AppSyncPersistentErrors, prometheus.CounterValue, float64(self.monitor.Report.AppSyncPublisher.Errors.PersistentFailure.Load()))
@@ -44,6 +44,7 @@ func NewMonitor(config *config.Config) (self *Monitor) {
 		Run:                   &report.RunReport{},
 		Contractor:            &report.ContractorReport{},
 		RedisPublishers:       make([]report.RedisPublisherReport, len(config.Redis)),
+		WebhookPublishers:     make([]report.WebhookPublisherReport, len(config.Webhook)),
 		AppSyncPublisher:      &report.AppSyncPublisherReport{},
 		NetworkInfo:           &report.NetworkInfoReport{},
 		BlockDownloader:       &report.BlockDownloaderReport{},
@@ -14,15 +14,21 @@ type Collector struct {
 	UpForSeconds *prometheus.Desc
 
 	// Forwarder
-	FinishedHeight     *prometheus.Desc
-	L1Interactions     *prometheus.Desc
-	L2Interactions     *prometheus.Desc
-	BlocksBehindSyncer *prometheus.Desc
+	FinishedHeight       *prometheus.Desc
+	L1Interactions       *prometheus.Desc
+	L2Interactions       *prometheus.Desc
+	BlocksBehindSyncer   *prometheus.Desc
+	ReplayedInteractions *prometheus.Desc
+	FilteredInteractions *prometheus.Desc
+	DbOutboxErrors       *prometheus.Desc
+	DbReplayErrors       *prometheus.Desc
 
 	// Redis publisher
 	RedisPublishErrors     []*prometheus.Desc
 	RedisPersistentErrors  []*prometheus.Desc
 	RedisMessagesPublished []*prometheus.Desc
+	RedisDuplicatesSkipped []*prometheus.Desc
+	RedisOutOfOrder        []*prometheus.Desc
 	RedisPoolHits          []*prometheus.Desc
 	RedisPoolIdleConns     []*prometheus.Desc
 	RedisPoolMisses        []*prometheus.Desc
@@ -30,6 +36,18 @@ type Collector struct {
 	RedisPoolTimeouts      []*prometheus.Desc
 	RedisPoolTotalConns    []*prometheus.Desc
 
+	// Webhook publisher
+	WebhookDeliveryErrors      []*prometheus.Desc
+	WebhookPersistentErrors    []*prometheus.Desc
+	WebhookRetryQueueErrors    []*prometheus.Desc
+	WebhookIsFailing           []*prometheus.Desc
+	WebhookMessagesDelivered   []*prometheus.Desc
+	WebhookBatchesDelivered    []*prometheus.Desc
+	WebhookBatchesQueued       []*prometheus.Desc
+	WebhookBatchesRedelivered  []*prometheus.Desc
+	WebhookBatchesDeadLettered []*prometheus.Desc
+	WebhookRetryQueueSize      []*prometheus.Desc
+
 	// App sync publisher
 	AppSyncPublishErrors     *prometheus.Desc
 	AppSyncPersistentErrors  *prometheus.Desc
@@ -46,15 +64,21 @@ func NewCollector(config *config.Config) *Collector {
 		UpForSeconds: prometheus.NewDesc("up_for_seconds", "", nil, nil),
 
 		// Forwarder
-		FinishedHeight:     prometheus.NewDesc("finished_height", "", nil, nil),
-		L1Interactions:     prometheus.NewDesc("l1_interactions", "", nil, nil),
-		L2Interactions:     prometheus.NewDesc("l2_interactions", "", nil, nil),
-		BlocksBehindSyncer: prometheus.NewDesc("blocks_behind_syncer", "", nil, nil),
+		FinishedHeight:       prometheus.NewDesc("finished_height", "", nil, nil),
+		L1Interactions:       prometheus.NewDesc("l1_interactions", "", nil, nil),
+		L2Interactions:       prometheus.NewDesc("l2_interactions", "", nil, nil),
+		BlocksBehindSyncer:   prometheus.NewDesc("blocks_behind_syncer", "", nil, nil),
+		ReplayedInteractions: prometheus.NewDesc("replayed_interactions", "", nil, nil),
+		FilteredInteractions: prometheus.NewDesc("filtered_interactions", "", nil, nil),
+		DbOutboxErrors:       prometheus.NewDesc("error_db_outbox", "", nil, nil),
+		DbReplayErrors:       prometheus.NewDesc("error_db_replay", "", nil, nil),
 
 		// Redis publisher
 		RedisPublishErrors:     make([]*prometheus.Desc, len(config.Redis)),
 		RedisPersistentErrors:  make([]*prometheus.Desc, len(config.Redis)),
 		RedisMessagesPublished: make([]*prometheus.Desc, len(config.Redis)),
+		RedisDuplicatesSkipped: make([]*prometheus.Desc, len(config.Redis)),
+		RedisOutOfOrder:        make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolHits:          make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolIdleConns:     make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolMisses:        make([]*prometheus.Desc, len(config.Redis)),
@@ -62,6 +86,18 @@ func NewCollector(config *config.Config) *Collector {
 		RedisPoolTimeouts:      make([]*prometheus.Desc, len(config.Redis)),
 		RedisPoolTotalConns:    make([]*prometheus.Desc, len(config.Redis)),
 
+		// Webhook publisher
+		WebhookDeliveryErrors:      make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookPersistentErrors:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookRetryQueueErrors:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookIsFailing:           make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookMessagesDelivered:   make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesDelivered:    make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesQueued:       make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesRedelivered:  make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookBatchesDeadLettered: make([]*prometheus.Desc, len(config.Webhook)),
+		WebhookRetryQueueSize:      make([]*prometheus.Desc, len(config.Webhook)),
+
 		// App sync publisher
 		AppSyncPublishErrors:     prometheus.NewDesc("error_app_sync_publish", "", nil, labels),
 		AppSyncPersistentErrors:  prometheus.NewDesc("error_app_sync_persistent", "", nil, labels),
@@ -73,6 +109,8 @@ func NewCollector(config *config.Config) *Collector {
 		collector.RedisPublishErrors[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_publish_errors", i), "", nil, nil)
 		collector.RedisPersistentErrors[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_persistent_errors", i), "", nil, nil)
 		collector.RedisMessagesPublished[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_messages_published", i), "", nil, nil)
+		collector.RedisDuplicatesSkipped[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_duplicates_skipped", i), "", nil, nil)
+		collector.RedisOutOfOrder[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_out_of_order", i), "", nil, nil)
 		collector.RedisPoolHits[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_hits", i), "", nil, nil)
 		collector.RedisPoolIdleConns[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_idle_conns", i), "", nil, nil)
 		collector.RedisPoolMisses[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_misses", i), "", nil, nil)
@@ -81,6 +119,20 @@ func NewCollector(config *config.Config) *Collector {
 		collector.RedisPoolTotalConns[i] = prometheus.NewDesc(fmt.Sprintf("redis_%d_pool_total_conns", i), "", nil, nil)
 	}
 
+	for i := range config.Webhook {
+		// Webhook publisher
+		collector.WebhookDeliveryErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_delivery_errors", i), "", nil, nil)
+		collector.WebhookPersistentErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_persistent_errors", i), "", nil, nil)
+		collector.WebhookRetryQueueErrors[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_retry_queue_errors", i), "", nil, nil)
+		collector.WebhookIsFailing[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_is_failing", i), "", nil, nil)
+		collector.WebhookMessagesDelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_messages_delivered", i), "", nil, nil)
+		collector.WebhookBatchesDelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_delivered", i), "", nil, nil)
+		collector.WebhookBatchesQueued[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_queued", i), "", nil, nil)
+		collector.WebhookBatchesRedelivered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_redelivered", i), "", nil, nil)
+		collector.WebhookBatchesDeadLettered[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_batches_dead_lettered", i), "", nil, nil)
+		collector.WebhookRetryQueueSize[i] = prometheus.NewDesc(fmt.Sprintf("webhook_%d_retry_queue_size", i), "", nil, nil)
+	}
+
 	return collector
 }
 
@@ -98,12 +150,18 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- self.L1Interactions
 	ch <- self.L2Interactions
 	ch <- self.BlocksBehindSyncer
+	ch <- self.ReplayedInteractions
+	ch <- self.FilteredInteractions
+	ch <- self.DbOutboxErrors
+	ch <- self.DbReplayErrors
 
 	// Redis publisher
 	for i := range self.monitor.Report.RedisPublishers {
 		ch <- self.RedisPublishErrors[i]
 		ch <- self.RedisPersistentErrors[i]
 		ch <- self.RedisMessagesPublished[i]
+		ch <- self.RedisDuplicatesSkipped[i]
+		ch <- self.RedisOutOfOrder[i]
 		ch <- self.RedisPoolHits[i]
 		ch <- self.RedisPoolIdleConns[i]
 		ch <- self.RedisPoolMisses[i]
@@ -112,6 +170,20 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 		ch <- self.RedisPoolTotalConns[i]
 	}
 
+	// Webhook publisher
+	for i := range self.monitor.Report.WebhookPublishers {
+		ch <- self.WebhookDeliveryErrors[i]
+		ch <- self.WebhookPersistentErrors[i]
+		ch <- self.WebhookRetryQueueErrors[i]
+		ch <- self.WebhookIsFailing[i]
+		ch <- self.WebhookMessagesDelivered[i]
+		ch <- self.WebhookBatchesDelivered[i]
+		ch <- self.WebhookBatchesQueued[i]
+		ch <- self.WebhookBatchesRedelivered[i]
+		ch <- self.WebhookBatchesDeadLettered[i]
+		ch <- self.WebhookRetryQueueSize[i]
+	}
+
 	// App sync publisher
 	ch <- self.AppSyncPublishErrors
 	ch <- self.AppSyncPersistentErrors
@@ -128,12 +200,18 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(self.L1Interactions, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.State.L1Interactions.Load()))
 	ch <- prometheus.MustNewConstMetric(self.L2Interactions, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.State.L2Interactions.Load()))
 	ch <- prometheus.MustNewConstMetric(self.BlocksBehindSyncer, prometheus.GaugeValue, float64(self.monitor.Report.Forwarder.State.BlocksBehindSyncer.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ReplayedInteractions, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.State.ReplayedInteractions.Load()))
+	ch <- prometheus.MustNewConstMetric(self.FilteredInteractions, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.State.FilteredInteractions.Load()))
+	ch <- prometheus.MustNewConstMetric(self.DbOutboxErrors, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.Errors.DbOutbox.Load()))
+	ch <- prometheus.MustNewConstMetric(self.DbReplayErrors, prometheus.CounterValue, float64(self.monitor.Report.Forwarder.Errors.DbReplay.Load()))
 
 	// Redis publisher
 	for i, redisPublisher := range self.monitor.Report.RedisPublishers {
 		ch <- prometheus.MustNewConstMetric(self.RedisPublishErrors[i], prometheus.CounterValue, float64(redisPublisher.Errors.Publish.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPersistentErrors[i], prometheus.CounterValue, float64(redisPublisher.Errors.PersistentFailure.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisMessagesPublished[i], prometheus.CounterValue, float64(redisPublisher.State.MessagesPublished.Load()))
+		ch <- prometheus.MustNewConstMetric(self.RedisDuplicatesSkipped[i], prometheus.CounterValue, float64(redisPublisher.State.DuplicatesSkipped.Load()))
+		ch <- prometheus.MustNewConstMetric(self.RedisOutOfOrder[i], prometheus.CounterValue, float64(redisPublisher.State.OutOfOrder.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolHits[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolHits.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolIdleConns[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolIdleConns.Load()))
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolMisses[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolMisses.Load()))
@@ -142,6 +220,21 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(self.RedisPoolTotalConns[i], prometheus.GaugeValue, float64(redisPublisher.State.PoolTotalConns.Load()))
 	}
 
+	// Webhook publisher
+	for i := range self.monitor.Report.WebhookPublishers {
+		webhookPublisher := &self.monitor.Report.WebhookPublishers[i]
+		ch <- prometheus.MustNewConstMetric(self.WebhookDeliveryErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.Delivery.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookPersistentErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.PersistentFailure.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookRetryQueueErrors[i], prometheus.CounterValue, float64(webhookPublisher.Errors.RetryQueue.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookIsFailing[i], prometheus.GaugeValue, float64(webhookPublisher.State.IsFailing.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookMessagesDelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.MessagesDelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesDelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesDelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesQueued[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesQueued.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesRedelivered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesRedelivered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookBatchesDeadLettered[i], prometheus.CounterValue, float64(webhookPublisher.State.BatchesDeadLettered.Load()))
+		ch <- prometheus.MustNewConstMetric(self.WebhookRetryQueueSize[i], prometheus.GaugeValue, float64(webhookPublisher.State.RetryQueueSize.Load()))
+	}
+
 	// App sync publisher
 	ch <- prometheus.MustNewConstMetric(self.AppSyncPublishErrors, prometheus.CounterValue, float64(self.monitor.Report.AppSyncPublisher.Errors.Publish.Load()))
 	ch <- prometheus.MustNewConstMetric(self.AppSyncPersistentErrors, prometheus.CounterValue, float64(self.monitor.Report.AppSyncPublisher.Errors.PersistentFailure.Load()))
@@ -28,10 +28,11 @@ func NewMonitor(config *config.Config) (self *Monitor) {
 	self = new(Monitor)
 
 	self.Report = report.Report{
-		Run:              &report.RunReport{},
-		RedisPublishers:  make([]report.RedisPublisherReport, len(config.Redis)),
-		Forwarder:        &report.ForwarderReport{},
-		AppSyncPublisher: &report.AppSyncPublisherReport{},
+		Run:               &report.RunReport{},
+		RedisPublishers:   make([]report.RedisPublisherReport, len(config.Redis)),
+		WebhookPublishers: make([]report.WebhookPublisherReport, len(config.Webhook)),
+		Forwarder:         &report.ForwarderReport{},
+		AppSyncPublisher:  &report.AppSyncPublisherReport{},
 	}
 
 	// Initialization
@@ -66,18 +67,24 @@ func (self *Monitor) IsOK() bool {
 		return false
 	}
 
+	startTimestamp := self.Report.Run.State.StartTimestamp.Load()
+	for i := range self.Report.RedisPublishers {
+		if !self.IsRedisPublisherOK(i, startTimestamp) {
+			return false
+		}
+	}
+	return true
+}
+
+// Redis publisher started at startTimestamp delivered something in the last 5 minutes
+func (self *Monitor) IsRedisPublisherOK(i int, startTimestamp int64) bool {
 	now := time.Now().Unix()
-	if now-self.Report.Run.State.StartTimestamp.Load() < 300 {
+	if now-startTimestamp < 300 {
 		// Give it 5 minutes to start
 		return true
 	}
 
-	for _, redisPublisher := range self.Report.RedisPublishers {
-		if now-redisPublisher.State.LastSuccessfulMessageTimestamp.Load() > 300 {
-			return false
-		}
-	}
-	return true
+	return now-self.Report.RedisPublishers[i].State.LastSuccessfulMessageTimestamp.Load() <= 300
 }
 
 func (self *Monitor) monitor() (err error) {
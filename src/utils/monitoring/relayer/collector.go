@@ -40,6 +40,9 @@ type Collector struct {
 	SequencerPermanentParsingError           *prometheus.Desc
 	SequencerBlockDownloadError              *prometheus.Desc
 	SequencerPermanentBlockDownloadError     *prometheus.Desc
+	SequencerBlockVerificationError          *prometheus.Desc
+	SequencerBlocksVerified                  *prometheus.Desc
+	LightClientTrustedHeight                 *prometheus.Desc
 	PersistentSequencerFailedParsing         *prometheus.Desc
 	PersistentArweaveFailedParsing           *prometheus.Desc
 	DbError                                  *prometheus.Desc
@@ -55,6 +58,22 @@ type Collector struct {
 	L1InteractionsSaved                      *prometheus.Desc
 	L2InteractionsSaved                      *prometheus.Desc
 	InteractionsSaved                        *prometheus.Desc
+
+	// Shadow
+	ShadowBlocksCompared         *prometheus.Desc
+	ShadowComparedHeight         *prometheus.Desc
+	ShadowMissingInteractions    *prometheus.Desc
+	ShadowSortKeyDifferences     *prometheus.Desc
+	ShadowLastSortKeyDifferences *prometheus.Desc
+	ShadowL1OrderDifferences     *prometheus.Desc
+	ShadowMissingBundleItems     *prometheus.Desc
+	ShadowMetaInfoDifferences    *prometheus.Desc
+
+	// Sequencer nodes
+	SequencerEndpointHealthy *prometheus.Desc
+	SequencerEndpointBest    *prometheus.Desc
+	SequencerEndpointHeight  *prometheus.Desc
+	SequencerEndpointErrors  *prometheus.Desc
 }
 
 func NewCollector(config *config.Config) *Collector {
@@ -89,6 +108,9 @@ func NewCollector(config *config.Config) *Collector {
 		SequencerPermanentParsingError:           prometheus.NewDesc("sequencer_permanent_parsing_error", "", nil, nil),
 		SequencerBlockDownloadError:              prometheus.NewDesc("sequencer_block_download_error", "", nil, nil),
 		SequencerPermanentBlockDownloadError:     prometheus.NewDesc("sequencer_permanent_block_download_error", "", nil, nil),
+		SequencerBlockVerificationError:          prometheus.NewDesc("sequencer_block_verification_error", "", nil, nil),
+		SequencerBlocksVerified:                  prometheus.NewDesc("sequencer_blocks_verified", "", nil, nil),
+		LightClientTrustedHeight:                 prometheus.NewDesc("light_client_trusted_height", "", nil, nil),
 		PersistentSequencerFailedParsing:         prometheus.NewDesc("persistent_sequencer_failed_parsing", "", nil, nil),
 		PersistentArweaveFailedParsing:           prometheus.NewDesc("persistent_arweave_failed_parsing", "", nil, nil),
 		DbError:                                  prometheus.NewDesc("db_error", "", nil, nil),
@@ -104,6 +126,22 @@ func NewCollector(config *config.Config) *Collector {
 		L1InteractionsSaved:                      prometheus.NewDesc("l1_interactions_saved", "", nil, nil),
 		L2InteractionsSaved:                      prometheus.NewDesc("l2_interactions_saved", "", nil, nil),
 		InteractionsSaved:                        prometheus.NewDesc("interactions_saved", "", nil, nil),
+
+		// Shadow
+		ShadowBlocksCompared:         prometheus.NewDesc("shadow_blocks_compared", "", nil, nil),
+		ShadowComparedHeight:         prometheus.NewDesc("shadow_compared_height", "", nil, nil),
+		ShadowMissingInteractions:    prometheus.NewDesc("shadow_missing_interactions", "", nil, nil),
+		ShadowSortKeyDifferences:     prometheus.NewDesc("shadow_sort_key_differences", "", nil, nil),
+		ShadowLastSortKeyDifferences: prometheus.NewDesc("shadow_last_sort_key_differences", "", nil, nil),
+		ShadowL1OrderDifferences:     prometheus.NewDesc("shadow_l1_order_differences", "", nil, nil),
+		ShadowMissingBundleItems:     prometheus.NewDesc("shadow_missing_bundle_items", "", nil, nil),
+		ShadowMetaInfoDifferences:    prometheus.NewDesc("shadow_meta_info_differences", "", nil, nil),
+
+		// Sequencer nodes
+		SequencerEndpointHealthy: prometheus.NewDesc("sequencer_endpoint_healthy", "", []string{"url"}, nil),
+		SequencerEndpointBest:    prometheus.NewDesc("sequencer_endpoint_best", "", []string{"url"}, nil),
+		SequencerEndpointHeight:  prometheus.NewDesc("sequencer_endpoint_height", "", []string{"url"}, nil),
+		SequencerEndpointErrors:  prometheus.NewDesc("sequencer_endpoint_errors", "", []string{"url"}, nil),
 	}
 
 	return collector
@@ -145,6 +183,9 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- self.SequencerPermanentParsingError
 	ch <- self.SequencerBlockDownloadError
 	ch <- self.SequencerPermanentBlockDownloadError
+	ch <- self.SequencerBlockVerificationError
+	ch <- self.SequencerBlocksVerified
+	ch <- self.LightClientTrustedHeight
 	ch <- self.PersistentSequencerFailedParsing
 	ch <- self.PersistentArweaveFailedParsing
 	ch <- self.DbError
@@ -160,6 +201,22 @@ func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- self.L1InteractionsSaved
 	ch <- self.L2InteractionsSaved
 	ch <- self.InteractionsSaved
+
+	// Shadow
+	ch <- self.ShadowBlocksCompared
+	ch <- self.ShadowComparedHeight
+	ch <- self.ShadowMissingInteractions
+	ch <- self.ShadowSortKeyDifferences
+	ch <- self.ShadowLastSortKeyDifferences
+	ch <- self.ShadowL1OrderDifferences
+	ch <- self.ShadowMissingBundleItems
+	ch <- self.ShadowMetaInfoDifferences
+
+	// Sequencer nodes
+	ch <- self.SequencerEndpointHealthy
+	ch <- self.SequencerEndpointBest
+	ch <- self.SequencerEndpointHeight
+	ch <- self.SequencerEndpointErrors
 }
 
 // Collect implements required collect function for all promehteus collectors
@@ -194,6 +251,9 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(self.SequencerPermanentParsingError, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.SequencerPermanentParsingError.Load()))
 	ch <- prometheus.MustNewConstMetric(self.SequencerBlockDownloadError, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.SequencerBlockDownloadError.Load()))
 	ch <- prometheus.MustNewConstMetric(self.SequencerPermanentBlockDownloadError, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.SequencerPermanentBlockDownloadError.Load()))
+	ch <- prometheus.MustNewConstMetric(self.SequencerBlockVerificationError, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.SequencerBlockVerificationError.Load()))
+	ch <- prometheus.MustNewConstMetric(self.SequencerBlocksVerified, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.SequencerBlocksVerified.Load()))
+	ch <- prometheus.MustNewConstMetric(self.LightClientTrustedHeight, prometheus.GaugeValue, float64(self.monitor.Report.Relayer.State.LightClientTrustedHeight.Load()))
 	ch <- prometheus.MustNewConstMetric(self.PersistentArweaveFailedParsing, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.PersistentArweaveFailedParsing.Load()))
 	ch <- prometheus.MustNewConstMetric(self.DbError, prometheus.CounterValue, float64(self.monitor.Report.Relayer.Errors.DbError.Load()))
 	ch <- prometheus.MustNewConstMetric(self.SequencerBlocksDownloaded, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.SequencerBlocksDownloaded.Load()))
@@ -209,4 +269,29 @@ func (self *Collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(self.L2InteractionsSaved, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.L2InteractionsSaved.Load()))
 	ch <- prometheus.MustNewConstMetric(self.InteractionsSaved, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.InteractionsSaved.Load()))
 
+	// Shadow
+	ch <- prometheus.MustNewConstMetric(self.ShadowBlocksCompared, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowBlocksCompared.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowComparedHeight, prometheus.GaugeValue, float64(self.monitor.Report.Relayer.State.ShadowComparedHeight.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowMissingInteractions, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowMissingInteractions.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowSortKeyDifferences, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowSortKeyDifferences.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowLastSortKeyDifferences, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowLastSortKeyDifferences.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowL1OrderDifferences, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowL1OrderDifferences.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowMissingBundleItems, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowMissingBundleItems.Load()))
+	ch <- prometheus.MustNewConstMetric(self.ShadowMetaInfoDifferences, prometheus.CounterValue, float64(self.monitor.Report.Relayer.State.ShadowMetaInfoDifferences.Load()))
+
+	// Sequencer nodes
+	for _, endpoint := range self.monitor.Report.Relayer.Sequencers {
+		ch <- prometheus.MustNewConstMetric(self.SequencerEndpointHealthy, prometheus.GaugeValue, boolToFloat(endpoint.IsHealthy.Load()), endpoint.Url)
+		ch <- prometheus.MustNewConstMetric(self.SequencerEndpointBest, prometheus.GaugeValue, boolToFloat(endpoint.IsBest.Load()), endpoint.Url)
+		ch <- prometheus.MustNewConstMetric(self.SequencerEndpointHeight, prometheus.GaugeValue, float64(endpoint.LatestHeight.Load()), endpoint.Url)
+		ch <- prometheus.MustNewConstMetric(self.SequencerEndpointErrors, prometheus.CounterValue, float64(endpoint.Errors.Load()), endpoint.Url)
+	}
+
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
 }
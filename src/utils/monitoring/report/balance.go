@@ -0,0 +1,27 @@
+package report
+
+import "go.uber.org/atomic"
+
+type BalanceErrors struct {
+	IrysBalanceErrors  atomic.Uint64 `json:"irys_balance_errors"`
+	TurboBalanceErrors atomic.Uint64 `json:"turbo_balance_errors"`
+}
+
+type BalanceState struct {
+	// Wallet balances in winc
+	IrysBalance  atomic.Int64 `json:"irys_balance"`
+	TurboBalance atomic.Int64 `json:"turbo_balance"`
+
+	// Last time balance was successfully checked
+	IrysLastCheckTimestamp  atomic.Int64 `json:"irys_last_check_timestamp"`
+	TurboLastCheckTimestamp atomic.Int64 `json:"turbo_last_check_timestamp"`
+
+	// Set when balance is below the configured threshold
+	IsIrysBalanceLow  atomic.Bool `json:"is_irys_balance_low"`
+	IsTurboBalanceLow atomic.Bool `json:"is_turbo_balance_low"`
+}
+
+type BalanceReport struct {
+	State  BalanceState  `json:"state"`
+	Errors BalanceErrors `json:"errors"`
+}
@@ -18,6 +18,14 @@ type BundlerState struct {
 	// Transactions that are pending, waiting to be bundled in the database
 	PendingBundleItems atomic.Int64 `json:"pending_bundle_items"`
 
+	// Seconds since the oldest pending transaction got into this state, 0 if there are none
+	PendingBundleItemsAge atomic.Int64 `json:"pending_bundle_items_age"`
+
+	// Items waiting in the scheduler, per priority lane
+	HighLaneQueueSize   atomic.Int64  `json:"high_lane_queue_size"`
+	NormalLaneQueueSize atomic.Int64  `json:"normal_lane_queue_size"`
+	PriorityItems       atomic.Uint64 `json:"priority_items"`
+
 	// Counting bundles that come from the database
 	BundlesFromNotifications  atomic.Uint64 `json:"bundles_from_notifications"`
 	AdditionalFetches         atomic.Uint64 `json:"additional_fetches"`
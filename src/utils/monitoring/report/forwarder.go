@@ -8,14 +8,18 @@ type ForwarderErrors struct {
 	DbLastTransactionBlockHeight atomic.Uint64 `json:"db_last_transaction_block_height"`
 	DbFetchL1Interactions        atomic.Uint64 `json:"db_getch_l1_interactions"`
 	DbFetchL2Interactions        atomic.Uint64 `json:"db_getch_l2_interactions"`
+	DbOutbox                     atomic.Uint64 `json:"db_outbox"`
+	DbReplay                     atomic.Uint64 `json:"db_replay"`
 }
 
 type ForwarderState struct {
-	FinishedHeight      atomic.Uint64 `json:"finished_height"`
-	L1Interactions      atomic.Uint64 `json:"l1_interactions"`
-	L2Interactions      atomic.Uint64 `json:"l2_interactions"`
-	CurrentSyncerHeight atomic.Uint64 `json:"current_syncer_height"`
-	BlocksBehindSyncer  atomic.Uint64 `json:"blocks_behind_syncer"`
+	FinishedHeight       atomic.Uint64 `json:"finished_height"`
+	L1Interactions       atomic.Uint64 `json:"l1_interactions"`
+	L2Interactions       atomic.Uint64 `json:"l2_interactions"`
+	CurrentSyncerHeight  atomic.Uint64 `json:"current_syncer_height"`
+	BlocksBehindSyncer   atomic.Uint64 `json:"blocks_behind_syncer"`
+	ReplayedInteractions atomic.Uint64 `json:"replayed_interactions"`
+	FilteredInteractions atomic.Uint64 `json:"filtered_interactions"`
 }
 
 type ForwarderReport struct {
@@ -0,0 +1,39 @@
+package report
+
+import "go.uber.org/atomic"
+
+type LeaderErrors struct {
+	LeaseErrors atomic.Uint64 `json:"lease"`
+}
+
+type LeaderState struct {
+	IsLeader atomic.Bool `json:"is_leader"`
+
+	// Replica currently holding the lease, may be another replica
+	Holder atomic.String `json:"holder"`
+
+	// Unix timestamp of becoming the leader, 0 for a standby
+	LeaderSince atomic.Int64 `json:"leader_since"`
+
+	// Number of times this replica gained or lost leadership
+	NumTransitions atomic.Uint64 `json:"num_transitions"`
+}
+
+type LeaderReport struct {
+	// Identity of this replica
+	Self string `json:"self"`
+
+	State  LeaderState  `json:"state"`
+	Errors LeaderErrors `json:"errors"`
+}
+
+// Role of this replica: leader or standby. Empty if leader election is disabled
+func (self *LeaderReport) GetRole() string {
+	if self == nil {
+		return ""
+	}
+	if self.State.IsLeader.Load() {
+		return "leader"
+	}
+	return "standby"
+}
@@ -13,6 +13,8 @@ type RedisPublisherState struct {
 	IsConnected                    atomic.Int64  `json:"is_connected"`
 	LastSuccessfulMessageTimestamp atomic.Int64  `json:"last_successful_message_timestamp"`
 	MessagesPublished              atomic.Uint64 `json:"messages_published"`
+	DuplicatesSkipped              atomic.Uint64 `json:"duplicates_skipped"`
+	OutOfOrder                     atomic.Uint64 `json:"out_of_order"`
 	PoolHits                       atomic.Uint32 `json:"pool_hits"`
 	PoolIdleConns                  atomic.Uint32 `json:"pool_idle_conns"`
 	PoolMisses                     atomic.Uint32 `json:"pool_misses"`
@@ -8,6 +8,7 @@ type RelayerErrors struct {
 	SequencerPermanentParsingError       atomic.Uint64 `json:"sequencer_permanent_parsing_error"`
 	SequencerBlockDownloadError          atomic.Uint64 `json:"sequencer_block_download_error"`
 	SequencerPermanentBlockDownloadError atomic.Uint64 `json:"sequencer_permanent_block_download_error"`
+	SequencerBlockVerificationError      atomic.Uint64 `json:"sequencer_block_verification_error"`
 
 	PersistentArweaveFailedParsing atomic.Uint64 `json:"persistent_arweave_failed_parsing"`
 	DbError                        atomic.Uint64 `json:"db_error"`
@@ -20,6 +21,10 @@ type RelayerState struct {
 	SequencerBlocksCatchedUp                 atomic.Uint64  `json:"sequencer_blocks_catched_up"`
 	AverageSequencerBlocksProcessedPerMinute atomic.Float64 `json:"average_sequencer_blocks_processed_per_minute"`
 
+	// Verifier
+	SequencerBlocksVerified  atomic.Uint64 `json:"sequencer_blocks_verified"`
+	LightClientTrustedHeight atomic.Int64  `json:"light_client_trusted_height"`
+
 	// Decoder
 	SequencerTransactionsDecoded atomic.Uint64 `json:"sequencer_transactions_decoded"`
 
@@ -35,9 +40,31 @@ type RelayerState struct {
 	L1InteractionsSaved               atomic.Uint64  `json:"l1_interactions_saved"`
 	L2InteractionsSaved               atomic.Uint64  `json:"l2_interactions_saved"`
 	InteractionsSaved                 atomic.Uint64  `json:"interactions_saved"`
+
+	// Shadow
+	ShadowBlocksCompared         atomic.Uint64 `json:"shadow_blocks_compared"`
+	ShadowComparedHeight         atomic.Int64  `json:"shadow_compared_height"`
+	ShadowMissingInteractions    atomic.Uint64 `json:"shadow_missing_interactions"`
+	ShadowSortKeyDifferences     atomic.Uint64 `json:"shadow_sort_key_differences"`
+	ShadowLastSortKeyDifferences atomic.Uint64 `json:"shadow_last_sort_key_differences"`
+	ShadowL1OrderDifferences     atomic.Uint64 `json:"shadow_l1_order_differences"`
+	ShadowMissingBundleItems     atomic.Uint64 `json:"shadow_missing_bundle_items"`
+	ShadowMetaInfoDifferences    atomic.Uint64 `json:"shadow_meta_info_differences"`
+}
+
+type SequencerEndpointState struct {
+	Url                string        `json:"url"`
+	IsHealthy          atomic.Bool   `json:"is_healthy"`
+	IsBest             atomic.Bool   `json:"is_best"`
+	LatestHeight       atomic.Int64  `json:"latest_height"`
+	LastCheckTimestamp atomic.Int64  `json:"last_check_timestamp"`
+	Errors             atomic.Uint64 `json:"errors"`
 }
 
 type RelayerReport struct {
 	State  RelayerState  `json:"state"`
 	Errors RelayerErrors `json:"errors"`
+
+	// One entry per sequencer node
+	Sequencers []*SequencerEndpointState `json:"sequencers"`
 }
@@ -3,6 +3,7 @@ package report
 type Report struct {
 	Run                   *RunReport                   `json:"run,omitempty"`
 	Peer                  *PeerReport                  `json:"peer,omitempty"`
+	Leader                *LeaderReport                `json:"leader,omitempty"`
 	Syncer                *SyncerReport                `json:"syncer,omitempty"`
 	Contractor            *ContractorReport            `json:"contractor,omitempty"`
 	Bundler               *BundlerReport               `json:"bundler,omitempty"`
@@ -17,7 +18,9 @@ type Report struct {
 	BlockDownloader       *BlockDownloaderReport       `json:"block_downloader,omitempty"`
 	TransactionDownloader *TransactionDownloaderReport `json:"transaction_downloader,omitempty"`
 	RedisPublishers       []RedisPublisherReport       `json:"redis_publishers,omitempty"`
+	WebhookPublishers     []WebhookPublisherReport     `json:"webhook_publishers,omitempty"`
 	AppSyncPublisher      *AppSyncPublisherReport      `json:"appsync_publisher,omitempty"`
 	Evolver               *EvolverReport               `json:"evolver,omitempty"`
 	WarpySyncer           *WarpySyncerReport           `json:"warpy_syncer,omitempty"`
+	Balance               *BalanceReport               `json:"balance,omitempty"`
 }
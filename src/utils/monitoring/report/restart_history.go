@@ -0,0 +1,53 @@
+package report
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Max number of restarts kept in the history
+const maxRestartHistorySize = 20
+
+type Restart struct {
+	// Unix timestamp of the restart
+	Timestamp int64 `json:"timestamp"`
+
+	// Name of the restarted task
+	Task string `json:"task"`
+
+	// Number of restarts in a row, without a stable period in between
+	Consecutive int `json:"consecutive"`
+
+	// Error of starting the task again, if any
+	Error string `json:"error,omitempty"`
+}
+
+// Last restarts performed by the watchdog, newest last
+type RestartHistory struct {
+	mtx      sync.RWMutex
+	restarts []Restart
+}
+
+func (self *RestartHistory) Add(restart Restart) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	self.restarts = append(self.restarts, restart)
+	if len(self.restarts) > maxRestartHistorySize {
+		self.restarts = self.restarts[len(self.restarts)-maxRestartHistorySize:]
+	}
+}
+
+func (self *RestartHistory) Get() (out []Restart) {
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+	return append(out, self.restarts...)
+}
+
+func (self *RestartHistory) MarshalJSON() ([]byte, error) {
+	restarts := self.Get()
+	if restarts == nil {
+		restarts = []Restart{}
+	}
+	return json.Marshal(restarts)
+}
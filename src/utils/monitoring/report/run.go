@@ -9,6 +9,12 @@ type RunErrors struct {
 type RunState struct {
 	StartTimestamp atomic.Int64  `json:"start_timestamp"`
 	UpForSeconds   atomic.Uint64 `json:"up_for_seconds"`
+
+	// Restarts of the watched tasks, reported by the watchdog
+	WatchdogRestarts RestartHistory `json:"watchdog_restarts"`
+
+	// Unix timestamp before which the watchdog won't restart, 0 if there's no backoff
+	WatchdogBackoffUntil atomic.Int64 `json:"watchdog_backoff_until"`
 }
 
 type RunReport struct {
@@ -0,0 +1,27 @@
+package report
+
+import (
+	"go.uber.org/atomic"
+)
+
+type WebhookPublisherErrors struct {
+	Delivery          atomic.Uint64 `json:"delivery"`
+	PersistentFailure atomic.Uint64 `json:"persistent"`
+	RetryQueue        atomic.Uint64 `json:"retry_queue"`
+}
+
+type WebhookPublisherState struct {
+	IsFailing                       atomic.Int64  `json:"is_failing"`
+	LastSuccessfulDeliveryTimestamp atomic.Int64  `json:"last_successful_delivery_timestamp"`
+	MessagesDelivered               atomic.Uint64 `json:"messages_delivered"`
+	BatchesDelivered                atomic.Uint64 `json:"batches_delivered"`
+	BatchesQueued                   atomic.Uint64 `json:"batches_queued"`
+	BatchesRedelivered              atomic.Uint64 `json:"batches_redelivered"`
+	BatchesDeadLettered             atomic.Uint64 `json:"batches_dead_lettered"`
+	RetryQueueSize                  atomic.Int64  `json:"retry_queue_size"`
+}
+
+type WebhookPublisherReport struct {
+	State  WebhookPublisherState  `json:"state"`
+	Errors WebhookPublisherErrors `json:"errors"`
+}
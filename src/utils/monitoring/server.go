@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"runtime"
 
+	"github.com/warp-contracts/syncer/src/utils/alerting"
 	"github.com/warp-contracts/syncer/src/utils/build_info"
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/freshness"
 	"github.com/warp-contracts/syncer/src/utils/task"
 
 	"github.com/gin-contrib/pprof"
@@ -25,14 +27,19 @@ type Server struct {
 	Router     *gin.Engine
 
 	monitor Monitor
+	alerter *alerting.Alerter
 }
 
 func NewServer(config *config.Config) (self *Server) {
 	self = new(Server)
 
+	// Alert rules are evaluated against reports of all monitors
+	self.alerter = alerting.NewAlerter(config)
+
 	self.Task = task.NewTask(config, "rest-server").
 		WithSubtaskFunc(self.run).
-		WithOnStop(self.stop)
+		WithOnStop(self.stop).
+		WithConditionalSubtask(config.Alerting.Enabled, self.alerter.Task)
 
 	self.Router = gin.New()
 
@@ -43,6 +50,8 @@ func NewServer(config *config.Config) (self *Server) {
 
 	self.registry = prometheus.NewRegistry()
 	self.registry.MustRegister(collectors.NewGoCollector())
+	self.registry.MustRegister(task.GetPrometheusCollector())
+	self.registry.MustRegister(freshness.GetPrometheusCollector())
 
 	return
 }
@@ -50,6 +59,15 @@ func NewServer(config *config.Config) (self *Server) {
 func (self *Server) WithMonitor(m Monitor) *Server {
 	self.monitor = m
 	self.registry.MustRegister(m.GetPrometheusCollector())
+	self.alerter.WithReport("", m.GetReport())
+	return self
+}
+
+// Adds monitor of one of the modes running in the same process, besides the main monitor.
+// Its metrics are prefixed with the mode's name
+func (self *Server) WithNamedMonitor(name string, m Monitor) *Server {
+	prometheus.WrapRegistererWithPrefix(name+"_", self.registry).MustRegister(m.GetPrometheusCollector())
+	self.alerter.WithReport(name, m.GetReport())
 	return self
 }
 
@@ -59,9 +77,11 @@ func (self *Server) run() (err error) {
 	v1 := self.Router.Group("v1")
 	{
 		v1.GET("state", self.monitor.OnGetState)
-		v1.GET("health", self.monitor.OnGetHealth)
+		v1.GET("health", self.onGetHealth)
 		v1.GET("monitor", self.handle())
 		v1.GET("version", self.onVersion)
+		v1.GET("tasks", self.onTasks)
+		v1.GET("alerts", self.onAlerts)
 	}
 
 	if self.Config.Profiler.Enabled {
@@ -85,6 +105,19 @@ func (self *Server) handle() gin.HandlerFunc {
 	}
 }
 
+// Standby replicas are healthy, their role is passed in a header
+func (self *Server) onGetHealth(c *gin.Context) {
+	if role := self.monitor.GetReport().Leader.GetRole(); role != "" {
+		c.Header("X-Leader-Role", role)
+	}
+	self.monitor.OnGetHealth(c)
+}
+
+// Pending and firing alerts, empty if alerting is disabled
+func (self *Server) onAlerts(c *gin.Context) {
+	c.JSON(http.StatusOK, self.alerter.GetAlerts())
+}
+
 func (self *Server) onVersion(c *gin.Context) {
 	c.JSON(http.StatusOK, map[string]string{
 		"version":    build_info.Version,
@@ -92,6 +125,23 @@ func (self *Server) onVersion(c *gin.Context) {
 	})
 }
 
+// Task tree with states, worker queues and channel occupancy.
+// Use ?format=dot to get it in the Graphviz format
+func (self *Server) onTasks(c *gin.Context) {
+	registry := task.GetRegistry()
+	if c.Query("format") != "dot" {
+		c.JSON(http.StatusOK, registry.GetTasks())
+		return
+	}
+
+	c.Header("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	c.Status(http.StatusOK)
+	err := registry.WriteDot(c.Writer)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to write task tree")
+	}
+}
+
 func (self *Server) stop() {
 	ctx, cancel := context.WithTimeout(context.Background(), self.Config.StopTimeout)
 	defer cancel()
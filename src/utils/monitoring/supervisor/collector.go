@@ -0,0 +1,73 @@
+package monitor_supervisor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics of the parts shared between modes.
+// Metrics of modes are registered separately, prefixed with the mode's name
+type Collector struct {
+	monitor *Monitor
+
+	// Run
+	UpForSeconds *prometheus.Desc
+	IsOK         *prometheus.Desc
+
+	// Peers
+	PeersBlacklisted   *prometheus.Desc
+	NumPeers           *prometheus.Desc
+	PeerDownloadErrors *prometheus.Desc
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		// Run
+		UpForSeconds: prometheus.NewDesc("up_for_seconds", "", nil, nil),
+		IsOK:         prometheus.NewDesc("is_ok", "1 if the mode is healthy", []string{"mode"}, nil),
+
+		// Peers
+		PeersBlacklisted:   prometheus.NewDesc("peers_blacklisted", "", nil, nil),
+		NumPeers:           prometheus.NewDesc("num_peers", "", nil, nil),
+		PeerDownloadErrors: prometheus.NewDesc("peer_download_errors", "", nil, nil),
+	}
+}
+
+func (self *Collector) WithMonitor(m *Monitor) *Collector {
+	self.monitor = m
+	return self
+}
+
+func (self *Collector) Describe(ch chan<- *prometheus.Desc) {
+	// Run
+	ch <- self.UpForSeconds
+	ch <- self.IsOK
+
+	// Peers
+	ch <- self.PeersBlacklisted
+	ch <- self.NumPeers
+	ch <- self.PeerDownloadErrors
+}
+
+// Collect implements required collect function for all promehteus collectors
+func (self *Collector) Collect(ch chan<- prometheus.Metric) {
+	// Run
+	ch <- prometheus.MustNewConstMetric(self.UpForSeconds, prometheus.GaugeValue, float64(self.monitor.Report.Run.State.UpForSeconds.Load()))
+
+	self.monitor.mtx.RLock()
+	for name, m := range self.monitor.monitors {
+		ch <- prometheus.MustNewConstMetric(self.IsOK, prometheus.GaugeValue, boolToFloat(m.IsOK()), name)
+	}
+	self.monitor.mtx.RUnlock()
+
+	// Peers
+	ch <- prometheus.MustNewConstMetric(self.PeersBlacklisted, prometheus.GaugeValue, float64(self.monitor.Report.Peer.State.PeersBlacklisted.Load()))
+	ch <- prometheus.MustNewConstMetric(self.NumPeers, prometheus.GaugeValue, float64(self.monitor.Report.Peer.State.NumPeers.Load()))
+	ch <- prometheus.MustNewConstMetric(self.PeerDownloadErrors, prometheus.CounterValue, float64(self.monitor.Report.Peer.Errors.PeerDownloadErrors.Load()))
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
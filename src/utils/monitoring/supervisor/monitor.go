@@ -0,0 +1,144 @@
+package monitor_supervisor
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/monitoring/report"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Monitor of several modes running in one process.
+// Healthy only if all modes are healthy, state contains reports of all modes.
+// Own report holds the parts shared between modes (e.g. peers)
+type Monitor struct {
+	*task.Task
+
+	Report    report.Report
+	collector *Collector
+
+	mtx      sync.RWMutex
+	names    []string
+	monitors map[string]monitoring.Monitor
+
+	// Params
+	IsFatalError atomic.Bool
+}
+
+func NewMonitor() (self *Monitor) {
+	self = new(Monitor)
+
+	self.Report = report.Report{
+		Run:  &report.RunReport{},
+		Peer: &report.PeerReport{},
+	}
+
+	self.monitors = make(map[string]monitoring.Monitor)
+
+	// Initialization
+	self.Report.Run.State.StartTimestamp.Store(time.Now().Unix())
+
+	self.collector = NewCollector().WithMonitor(self)
+
+	self.Task = task.NewTask(nil, "monitor").
+		WithPeriodicSubtaskFunc(30*time.Second, self.monitor)
+	return
+}
+
+// Adds monitor of one of the modes
+func (self *Monitor) WithMonitor(name string, m monitoring.Monitor) *Monitor {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	self.names = append(self.names, name)
+	self.monitors[name] = m
+	return self
+}
+
+func (self *Monitor) Clear() {
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+
+	for _, m := range self.monitors {
+		m.Clear()
+	}
+}
+
+func (self *Monitor) GetReport() *report.Report {
+	return &self.Report
+}
+
+func (self *Monitor) GetPrometheusCollector() (collector prometheus.Collector) {
+	return self.collector
+}
+
+func (self *Monitor) SetPermanentError(err error) {
+	self.IsFatalError.Store(true)
+	self.Log.WithError(err).Error("Unrecoverable, permanent error. Monitor will ask for a restart. It may take few minutes.")
+}
+
+func (self *Monitor) IsOK() bool {
+	if self.IsFatalError.Load() {
+		return false
+	}
+
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+
+	for _, m := range self.monitors {
+		if !m.IsOK() {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *Monitor) monitor() (err error) {
+	self.Report.Run.State.UpForSeconds.Store(uint64(time.Now().Unix() - self.Report.Run.State.StartTimestamp.Load()))
+	return nil
+}
+
+// Shared report under "supervisor", reports of modes under their names
+func (self *Monitor) OnGetState(c *gin.Context) {
+	self.mtx.RLock()
+	defer self.mtx.RUnlock()
+
+	out := make(map[string]*report.Report, len(self.monitors)+1)
+	out["supervisor"] = &self.Report
+	for name, m := range self.monitors {
+		out[name] = m.GetReport()
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+type modeHealth struct {
+	IsOK bool `json:"is_ok"`
+
+	// Leader or standby, omitted if leader election is disabled
+	Role string `json:"role,omitempty"`
+}
+
+// Lists health of every mode, responds with an error if any of them isn't healthy
+func (self *Monitor) OnGetHealth(c *gin.Context) {
+	self.mtx.RLock()
+	modes := make(map[string]modeHealth, len(self.monitors))
+	for name, m := range self.monitors {
+		modes[name] = modeHealth{
+			IsOK: m.IsOK(),
+			Role: m.GetReport().Leader.GetRole(),
+		}
+	}
+	self.mtx.RUnlock()
+
+	if self.IsOK() {
+		c.JSON(http.StatusOK, modes)
+	} else {
+		c.JSON(http.StatusServiceUnavailable, modes)
+	}
+}
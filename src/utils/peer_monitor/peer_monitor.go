@@ -6,6 +6,7 @@ import (
 	"net/netip"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -24,6 +25,10 @@ type PeerMonitor struct {
 	// State
 	blacklist Blacklist
 
+	// Limits, reloaded upon configuration file change
+	maxPeers                     atomic.Int64
+	maxPeersRemovedFromBlacklist atomic.Int64
+
 	monitor monitoring.Monitor
 }
 
@@ -41,14 +46,21 @@ func (self *metric) String() string {
 
 func NewPeerMonitor(config *config.Config) (self *PeerMonitor) {
 	self = new(PeerMonitor)
+	self.setLimits(config)
 
 	self.Task = task.NewTask(config, "peer-monitor").
 		WithPeriodicSubtaskFunc(config.PeerMonitor.Period, self.runPeriodically).
-		WithWorkerPool(config.PeerMonitor.NumWorkers, config.PeerMonitor.WorkerQueueSize)
+		WithWorkerPool(config.PeerMonitor.NumWorkers, config.PeerMonitor.WorkerQueueSize).
+		WithOnConfigChange(self.setLimits)
 
 	return
 }
 
+func (self *PeerMonitor) setLimits(config *config.Config) {
+	self.maxPeers.Store(int64(config.PeerMonitor.MaxPeers))
+	self.maxPeersRemovedFromBlacklist.Store(int64(config.PeerMonitor.MaxPeersRemovedFromBlacklist))
+}
+
 func (self *PeerMonitor) WithClient(client *arweave.Client) *PeerMonitor {
 	self.client = client
 	return self
@@ -76,8 +88,8 @@ func (self *PeerMonitor) runPeriodically() (err error) {
 	peers = self.sortPeersByMetrics(height, peers)
 
 	numPeers := len(peers)
-	if numPeers > self.Config.PeerMonitor.MaxPeers {
-		numPeers = self.Config.PeerMonitor.MaxPeers
+	if maxPeers := int(self.maxPeers.Load()); numPeers > maxPeers {
+		numPeers = maxPeers
 	}
 
 	self.client.SetPeers(peers[:numPeers])
@@ -86,7 +98,7 @@ func (self *PeerMonitor) runPeriodically() (err error) {
 	self.monitor.GetReport().Peer.State.PeersBlacklisted.Store(uint64(self.blacklist.Size.Load()))
 
 	self.Log.WithField("numBlacklisted", self.blacklist.Size.Load()).Trace("Set new peers")
-	self.blacklist.RemoveOldest(self.Config.PeerMonitor.MaxPeersRemovedFromBlacklist)
+	self.blacklist.RemoveOldest(int(self.maxPeersRemovedFromBlacklist.Load()))
 
 	return nil
 }
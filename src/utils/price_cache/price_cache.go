@@ -0,0 +1,104 @@
+package price_cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/task"
+)
+
+// Arweave charges for whole chunks, so does Irys
+const chunkSize = 256 * 1024
+
+// Caches Irys upload prices by the number of chunks and periodically refreshes them in the background.
+// Only the first upload with a new number of chunks waits for the price, most items fit in one chunk.
+type PriceCache struct {
+	*task.Task
+
+	irysClient *bundlr.Client
+
+	mtx sync.RWMutex
+
+	// Price (in winc) by the number of chunks
+	prices map[int]int64
+}
+
+func NewPriceCache(config *config.Config) (self *PriceCache) {
+	self = new(PriceCache)
+
+	self.prices = make(map[int]int64)
+
+	self.Task = task.NewTask(config, "price-cache").
+		WithPeriodicSubtaskFunc(config.Bundlr.IrysPriceCacheTTL, self.refresh)
+
+	return
+}
+
+func (self *PriceCache) WithIrysClient(client *bundlr.Client) *PriceCache {
+	self.irysClient = client
+	return self
+}
+
+func chunks(size int) int {
+	if size <= 0 {
+		return 1
+	}
+	return (size + chunkSize - 1) / chunkSize
+}
+
+// Price (in winc) of uploading a data item of the given size to Irys
+func (self *PriceCache) GetIrysPrice(ctx context.Context, size int) (out int64, err error) {
+	n := chunks(size)
+
+	self.mtx.RLock()
+	out, ok := self.prices[n]
+	self.mtx.RUnlock()
+	if ok {
+		return
+	}
+
+	out, err = self.irysClient.GetPrice(ctx, n*chunkSize)
+	if err != nil {
+		return
+	}
+
+	self.mtx.Lock()
+	self.prices[n] = out
+	self.mtx.Unlock()
+
+	return
+}
+
+// Gets the current price of every cached size. Old price is kept if Irys doesn't respond
+func (self *PriceCache) refresh() error {
+	self.mtx.RLock()
+	sizes := make([]int, 0, len(self.prices)+1)
+	for n := range self.prices {
+		sizes = append(sizes, n)
+	}
+	self.mtx.RUnlock()
+
+	if len(sizes) == 0 {
+		// Most items fit in one chunk
+		sizes = append(sizes, 1)
+	}
+
+	for _, n := range sizes {
+		price, err := self.irysClient.GetPrice(self.Ctx, n*chunkSize)
+		if err != nil {
+			if self.IsStopping.Load() {
+				return nil
+			}
+			self.Log.WithError(err).WithField("chunks", n).Warn("Failed to refresh Irys price")
+			continue
+		}
+
+		self.mtx.Lock()
+		self.prices[n] = price
+		self.mtx.Unlock()
+	}
+
+	return nil
+}
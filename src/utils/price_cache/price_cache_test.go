@@ -0,0 +1,86 @@
+package price_cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"github.com/warp-contracts/syncer/src/utils/config"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPriceCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(PriceCacheTestSuite))
+}
+
+type PriceCacheTestSuite struct {
+	suite.Suite
+	ctx      context.Context
+	cancel   context.CancelFunc
+	server   *httptest.Server
+	requests atomic.Int64
+	paths    chan string
+	cache    *PriceCache
+}
+
+func (s *PriceCacheTestSuite) SetupTest() {
+	s.requests.Store(0)
+	s.paths = make(chan string, 100)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		s.paths <- r.URL.Path
+		_, _ = w.Write([]byte("1000"))
+	}))
+
+	config := config.Default()
+	config.Bundlr.Urls = []string{s.server.URL}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.cache = NewPriceCache(config).
+		WithIrysClient(bundlr.NewClient(s.ctx, &config.Bundlr))
+}
+
+func (s *PriceCacheTestSuite) TearDownTest() {
+	s.cancel()
+	s.server.Close()
+}
+
+func (s *PriceCacheTestSuite) TestSameChunkIsCached() {
+	price, err := s.cache.GetIrysPrice(s.ctx, 100)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), int64(1000), price)
+
+	price, err = s.cache.GetIrysPrice(s.ctx, chunkSize)
+	require.Nil(s.T(), err)
+	require.Equal(s.T(), int64(1000), price)
+
+	require.Equal(s.T(), int64(1), s.requests.Load())
+	require.True(s.T(), strings.HasSuffix(<-s.paths, "/262144"))
+}
+
+func (s *PriceCacheTestSuite) TestNextChunkIsFetched() {
+	_, err := s.cache.GetIrysPrice(s.ctx, 1)
+	require.Nil(s.T(), err)
+	_, err = s.cache.GetIrysPrice(s.ctx, chunkSize+1)
+	require.Nil(s.T(), err)
+
+	require.Equal(s.T(), int64(2), s.requests.Load())
+	<-s.paths
+	require.True(s.T(), strings.HasSuffix(<-s.paths, "/524288"))
+}
+
+func (s *PriceCacheTestSuite) TestRefreshKeepsCachedSizes() {
+	_, err := s.cache.GetIrysPrice(s.ctx, 3*chunkSize)
+	require.Nil(s.T(), err)
+
+	s.cache.Ctx = s.ctx
+	require.Nil(s.T(), s.cache.refresh())
+	require.Equal(s.T(), int64(2), s.requests.Load())
+	require.Len(s.T(), s.cache.prices, 1)
+}
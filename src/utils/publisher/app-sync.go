@@ -37,6 +37,12 @@ type AppSyncPublisher[In encoding.BinaryMarshaler] struct {
 	client      *appsync.Client
 	channelName string
 	input       chan *AppSyncPayload[In]
+
+	// Messages published before the input, optional
+	replay chan *AppSyncPayload[In]
+
+	// Informed about published messages, optional
+	tracker DeliveryTracker
 }
 
 type Args struct {
@@ -67,6 +73,16 @@ func (self *AppSyncPublisher[In]) WithInputChannel(v chan *AppSyncPayload[In]) *
 	return self
 }
 
+func (self *AppSyncPublisher[In]) WithReplayChannel(v chan *AppSyncPayload[In]) *AppSyncPublisher[In] {
+	self.replay = v
+	return self
+}
+
+func (self *AppSyncPublisher[In]) WithDeliveryTracker(v DeliveryTracker) *AppSyncPublisher[In] {
+	self.tracker = v
+	return self
+}
+
 func (self *AppSyncPublisher[In]) WithMonitor(monitor monitoring.Monitor) *AppSyncPublisher[In] {
 	self.monitor = monitor
 	return self
@@ -107,42 +123,66 @@ func (self *AppSyncPublisher[In]) publish(data []byte) (err error) {
 }
 
 func (self *AppSyncPublisher[In]) run() (err error) {
+	// Replayed messages go before the live ones
+	if self.replay != nil {
+		for data := range self.replay {
+			self.submit(data)
+		}
+		self.replay = nil
+	}
+
 	for data := range self.input {
-		data := data
-		self.SubmitToWorker(func() {
-			self.Log.Debug("App sync publish...")
-			defer self.Log.Debug("...App sync publish done")
-
-			// Serialize to JSON
-			jsonData, err := data.In.MarshalBinary()
-			if err != nil {
-				self.Log.WithError(err).Error("Failed to marshal to json")
-				return
-			}
+		self.submit(data)
+	}
+	return nil
+}
 
-			self.channelName = data.ChannelName
-			// Retry on failure with exponential backoff
-			err = task.NewRetry().
-				WithContext(self.Ctx).
-				WithMaxElapsedTime(self.Config.AppSync.BackoffMaxElapsedTime).
-				WithMaxInterval(self.Config.AppSync.BackoffMaxInterval).
-				WithOnError(func(err error, isDurationeAcceptable bool) error {
-					self.Log.WithError(err).Error("Appsync publish failed, retrying")
-					self.monitor.GetReport().AppSyncPublisher.Errors.Publish.Inc()
-					return err
-				}).
-				Run(func() error {
-					return self.publish(jsonData)
-				})
-
-			if err != nil {
-				self.Log.WithError(err).Error("Failed to publish to appsync after retries")
-				self.monitor.GetReport().AppSyncPublisher.Errors.PersistentFailure.Inc()
-				return
+func (self *AppSyncPublisher[In]) submit(data *AppSyncPayload[In]) {
+	trackDispatched(self.tracker, self.Name, data.In)
+	self.SubmitToWorker(func() {
+		var err error
+		defer func() {
+			switch {
+			case err == nil:
+				trackDelivered(self.tracker, self.Name, data.In)
+			case !self.IsStopping.Load():
+				trackFailed(self.tracker, self.Name, data.In)
 			}
+			// Messages interrupted by stopping get replayed after the restart
+		}()
+
+		self.Log.Debug("App sync publish...")
+		defer self.Log.Debug("...App sync publish done")
+
+		// Serialize to JSON
+		var jsonData []byte
+		jsonData, err = data.In.MarshalBinary()
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to marshal to json")
+			return
+		}
 
-			self.monitor.GetReport().AppSyncPublisher.State.MessagesPublished.Inc()
-		})
-	}
-	return nil
+		self.channelName = data.ChannelName
+		// Retry on failure with exponential backoff
+		err = task.NewRetry().
+			WithContext(self.Ctx).
+			WithMaxElapsedTime(self.Config.AppSync.BackoffMaxElapsedTime).
+			WithMaxInterval(self.Config.AppSync.BackoffMaxInterval).
+			WithOnError(func(err error, isDurationeAcceptable bool) error {
+				self.Log.WithError(err).Error("Appsync publish failed, retrying")
+				self.monitor.GetReport().AppSyncPublisher.Errors.Publish.Inc()
+				return err
+			}).
+			Run(func() error {
+				return self.publish(jsonData)
+			})
+
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to publish to appsync after retries")
+			self.monitor.GetReport().AppSyncPublisher.Errors.PersistentFailure.Inc()
+			return
+		}
+
+		self.monitor.GetReport().AppSyncPublisher.State.MessagesPublished.Inc()
+	})
 }
@@ -8,12 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/task"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -27,10 +29,20 @@ type RedisPublisher[In encoding.BinaryMarshaler] struct {
 	channelName string
 	input       chan In
 
+	// Messages published before the input, optional
+	replay chan In
+
+	// Informed about published messages, optional
+	tracker DeliveryTracker
+
+	// Num of messages published, for logs
+	i int
+
 	// Monitor index
 	monitorIdx int
 
-	// If true, messages will be discarded when there's no redis connection
+	// If true, messages will be discarded when there's no redis connection.
+	// Doesn't apply to streams, messages wait for the connection
 	isDiscardWhenDisconnected bool
 
 	// One of the connection in pool is valid
@@ -58,6 +70,16 @@ func (self *RedisPublisher[In]) WithInputChannel(v chan In) *RedisPublisher[In]
 	return self
 }
 
+func (self *RedisPublisher[In]) WithReplayChannel(v chan In) *RedisPublisher[In] {
+	self.replay = v
+	return self
+}
+
+func (self *RedisPublisher[In]) WithDeliveryTracker(v DeliveryTracker) *RedisPublisher[In] {
+	self.tracker = v
+	return self
+}
+
 func (self *RedisPublisher[In]) WithChannelName(v string) *RedisPublisher[In] {
 	self.channelName = v
 	return self
@@ -198,53 +220,191 @@ func (self *RedisPublisher[In]) ping() (err error) {
 }
 
 func (self *RedisPublisher[In]) run() (err error) {
-	i := 0
+	// Replayed messages go before the live ones
+	if self.replay != nil {
+		for payload := range self.replay {
+			err = self.publish(payload)
+			if err != nil {
+				return
+			}
+		}
+		self.replay = nil
+	}
+
 	for payload := range self.input {
-		if self.isDiscardWhenDisconnected && !self.isConnected {
-			// Discard incomming payloads
-			continue
+		err = self.publish(payload)
+		if err != nil {
+			return
 		}
+	}
+	return nil
+}
+
+func (self *RedisPublisher[In]) publish(payload In) (err error) {
+	trackDispatched(self.tracker, self.Name, payload)
+	isDiscarded := false
+	defer func() {
+		switch {
+		case isDiscarded || (err != nil && !self.IsStopping.Load()):
+			trackFailed(self.tracker, self.Name, payload)
+		case err == nil:
+			trackDelivered(self.tracker, self.Name, payload)
+		}
+		// Messages interrupted by stopping get replayed after the restart
+	}()
+
+	if self.isDiscardWhenDisconnected && !self.isConnected && !self.redisConfig.StreamEnabled {
+		// Discard incomming payloads
+		isDiscarded = true
+		return
+	}
+
+	self.i++
+	i := self.i
+
+	self.Log.WithField("i", i).Trace("Redis publish...")
+
+	err = task.NewRetry().
+		WithContext(self.Ctx).
+		WithMaxElapsedTime(self.redisConfig.MaxElapsedTime).
+		WithMaxInterval(self.redisConfig.MaxInterval).
+		WithOnError(func(err error, isDurationAcceptable bool) error {
+			if errors.Is(err, ErrStreamEntryExists) {
+				return backoff.Permanent(err)
+			}
+			self.Log.WithError(err).Warn("Failed to publish message, retrying")
+			self.monitor.GetReport().RedisPublishers[self.monitorIdx].Errors.Publish.Inc()
+			return err
+		}).
+		Run(func() (err error) {
+			// self.Log.WithField("i", i).Debug("-> Publish message to Redis")
+			// defer self.Log.WithField("i", i).Debug("<- Publish message to Redis")
+			if self.redisConfig.StreamEnabled {
+				return self.add(payload)
+			}
+			return self.client.Publish(self.Ctx, self.channelName, payload).Err()
+		})
+	if errors.Is(err, ErrStreamEntryExists) {
+		// Message was added before a restart
+		self.Log.WithField("i", i).Debug("Message already in the stream, skipping")
+		self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.DuplicatesSkipped.Inc()
+		return nil
+	}
+	if err != nil {
+		self.Log.WithField("i", i).WithError(err).Error("Persistant error to publish message, giving up")
+		self.monitor.GetReport().RedisPublishers[self.monitorIdx].Errors.PersistentFailure.Inc()
+
+		// Mark the connection as disconnected if it's a network error
+		if errors.Is(err, &net.OpError{}) ||
+			errors.Is(err, net.ErrClosed) ||
+			errors.Is(err, &net.DNSError{}) ||
+			errors.Is(err, &net.AddrError{}) ||
+			errors.Is(err, &net.ParseError{}) {
+			self.Log.WithField("i", i).WithError(err).Error("Mark redis connection as disconnected")
+			self.setConnected(false)
+		}
+		return
+	}
+
+	self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.MessagesPublished.Inc()
+	self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.LastSuccessfulMessageTimestamp.Store(time.Now().Unix())
+
+	self.Log.WithField("i", i).Trace("Published message to Redis")
 
-		i++
-
-		self.Log.WithField("i", i).Trace("Redis publish...")
-
-		err = task.NewRetry().
-			WithContext(self.Ctx).
-			WithMaxElapsedTime(self.redisConfig.MaxElapsedTime).
-			WithMaxInterval(self.redisConfig.MaxInterval).
-			WithOnError(func(err error, isDurationAcceptable bool) error {
-				self.Log.WithError(err).Warn("Failed to publish message, retrying")
-				self.monitor.GetReport().RedisPublishers[self.monitorIdx].Errors.Publish.Inc()
-				return err
-			}).
-			Run(func() (err error) {
-				// self.Log.WithField("i", i).Debug("-> Publish message to Redis")
-				// defer self.Log.WithField("i", i).Debug("<- Publish message to Redis")
-				return self.client.Publish(self.Ctx, self.channelName, payload).Err()
-			})
+	return
+}
+
+// Adds the message to the stream named after the channel.
+// Messages with sort keys get ids derived from them. Generated ids ("*") are wall-clock milliseconds,
+// greater than any id derived from a sort key, so they're never used in those streams.
+func (self *RedisPublisher[In]) add(payload In) (err error) {
+	args := &redis.XAddArgs{
+		Stream: self.channelName,
+		MaxLen: self.redisConfig.StreamMaxLen,
+		Approx: true,
+		ID:     "*",
+		Values: []interface{}{"data", payload},
+	}
+
+	message, ok := any(payload).(SortKeyGetter)
+	if !ok {
+		return self.client.XAdd(self.Ctx, args).Err()
+	}
+
+	sortKey := message.GetSortKey()
+	args.ID, err = StreamId(sortKey)
+	if err != nil {
+		if sortKey != "" {
+			self.Log.WithError(err).WithField("sort_key", sortKey).Warn("Failed to derive stream entry id, adding after the last stream entry")
+		}
+		return self.addAfterLast(args)
+	}
+	args.Values = []interface{}{"data", payload, "sort_key", sortKey}
+
+	err = self.client.XAdd(self.Ctx, args).Err()
+	if err == nil || !strings.Contains(err.Error(), "equal or smaller than the target stream top item") {
+		return
+	}
+
+	// Skip only if this very message was added before a restart, possibly out of order
+	isAdded, err := self.isInStream(args.ID, sortKey)
+	if err != nil {
+		return
+	}
+	if isAdded {
+		return ErrStreamEntryExists
+	}
+
+	// Message came after another one with a greater sort key (e.g. L1 after L2 from the same or later block),
+	// or its id collides with another message's. It's added right after the last entry, sort key is kept in the fields.
+	self.Log.WithField("id", args.ID).WithField("sort_key", sortKey).Warn("Message out of order, adding after the last stream entry")
+	self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.OutOfOrder.Inc()
+
+	return self.addAfterLast(args)
+}
+
+// Adds the message with the id following the stream's top item
+func (self *RedisPublisher[In]) addAfterLast(args *redis.XAddArgs) (err error) {
+	lastId := "0-0"
+	info, err := self.client.XInfoStream(self.Ctx, self.channelName).Result()
+	if err == nil {
+		lastId = info.LastGeneratedID
+	} else if !strings.Contains(err.Error(), "no such key") {
+		return
+	}
+
+	args.ID, err = NextStreamId(lastId)
+	if err != nil {
+		return
+	}
+
+	return self.client.XAdd(self.Ctx, args).Err()
+}
+
+// Checks if the message with this sort key is in the stream. It's either at the id derived from the sort key
+// or it was added out of order after it, so entries from that id to the top are searched.
+func (self *RedisPublisher[In]) isInStream(id, sortKey string) (bool, error) {
+	const pageSize = 1000
+	start := id
+	for {
+		messages, err := self.client.XRangeN(self.Ctx, self.channelName, start, "+", pageSize).Result()
 		if err != nil {
-			self.Log.WithField("i", i).WithError(err).Error("Persistant error to publish message, giving up")
-			self.monitor.GetReport().RedisPublishers[self.monitorIdx].Errors.PersistentFailure.Inc()
-
-			// Mark the connection as disconnected if it's a network error
-			if errors.Is(err, &net.OpError{}) ||
-				errors.Is(err, net.ErrClosed) ||
-				errors.Is(err, &net.DNSError{}) ||
-				errors.Is(err, &net.AddrError{}) ||
-				errors.Is(err, &net.ParseError{}) {
-				self.Log.WithField("i", i).WithError(err).Error("Mark redis connection as disconnected")
-				self.setConnected(false)
+			return false, err
+		}
+
+		for _, message := range messages {
+			if message.Values["sort_key"] == sortKey {
+				return true, nil
 			}
-			return
 		}
 
-		self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.MessagesPublished.Inc()
-		self.monitor.GetReport().RedisPublishers[self.monitorIdx].State.LastSuccessfulMessageTimestamp.Store(time.Now().Unix())
+		if len(messages) < pageSize {
+			return false, nil
+		}
 
-		self.Log.WithField("i", i).Trace("Published message to Redis")
+		// Next page starts right after the last entry
+		start = "(" + messages[len(messages)-1].ID
 	}
-	return nil
 }
 
 func (self *RedisPublisher[In]) setConnected(v bool) {
@@ -0,0 +1,121 @@
+package publisher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidSortKey = errors.New("invalid sort key")
+
+	// The same message is already in the stream
+	ErrStreamEntryExists = errors.New("stream entry already exists")
+)
+
+// Stream entry's id is derived from the sort key of a message implementing this interface.
+// Other messages get ids generated by Redis
+type SortKeyGetter interface {
+	GetSortKey() string
+}
+
+// Number of bits of the sequence part of L2 ids used for the last segment of the sort key
+const streamIdCounterBits = 22
+
+// Maps a sort key to a stream entry id <ms>-<seq>, order of sort keys is kept.
+//
+// Sort key is <block height, 12 digits>,<13 digits>,<8 digits counter or 64 chars hash>.
+// L1 interactions have zeros in the middle segment and go before L2 interactions from the same block:
+//   - L1: <2 * height>-<first 63 bits of the hash>
+//   - L2: <2 * height + 1>-<middle segment << 22 | counter or first 22 bits of the hash>
+func StreamId(sortKey string) (id string, err error) {
+	parts := strings.Split(sortKey, ",")
+	if len(parts) != 3 || len(parts[0]) != 12 || len(parts[1]) != 13 {
+		err = ErrInvalidSortKey
+		return
+	}
+
+	height, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	middle, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return
+	}
+
+	var (
+		last      uint64
+		isCounter bool
+	)
+	switch len(parts[2]) {
+	case 8:
+		isCounter = true
+		last, err = strconv.ParseUint(parts[2], 10, 64)
+	case 64:
+		last, err = strconv.ParseUint(parts[2][:16], 16, 64)
+	default:
+		err = ErrInvalidSortKey
+	}
+	if err != nil {
+		return
+	}
+
+	var ms, seq uint64
+	if middle == 0 {
+		// L1
+		ms = 2 * height
+		if isCounter {
+			seq = last
+		} else {
+			seq = last >> 1
+		}
+	} else {
+		// L2
+		if middle >= 1<<(64-streamIdCounterBits) {
+			err = fmt.Errorf("%w: middle segment too big", ErrInvalidSortKey)
+			return
+		}
+
+		ms = 2*height + 1
+		seq = middle << streamIdCounterBits
+		if isCounter {
+			if last >= 1<<streamIdCounterBits {
+				err = fmt.Errorf("%w: counter too big", ErrInvalidSortKey)
+				return
+			}
+			seq |= last
+		} else {
+			seq |= last >> (64 - streamIdCounterBits)
+		}
+	}
+
+	return fmt.Sprintf("%d-%d", ms, seq), nil
+}
+
+// Smallest stream entry id greater than the given one
+func NextStreamId(id string) (next string, err error) {
+	msPart, seqPart, ok := strings.Cut(id, "-")
+	if !ok {
+		err = fmt.Errorf("invalid stream entry id: %s", id)
+		return
+	}
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return
+	}
+
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return
+	}
+
+	if seq == math.MaxUint64 {
+		return fmt.Sprintf("%d-0", ms+1), nil
+	}
+	return fmt.Sprintf("%d-%d", ms, seq+1), nil
+}
@@ -0,0 +1,159 @@
+package publisher
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hashA = "0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+	hashB = "f0e1d2c3b4a5968778695a4b3c2d1e0ff0e1d2c3b4a5968778695a4b3c2d1e0f"
+)
+
+func parseStreamId(t *testing.T, id string) (ms, seq uint64) {
+	msPart, seqPart, ok := strings.Cut(id, "-")
+	require.True(t, ok)
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	require.Nil(t, err)
+
+	seq, err = strconv.ParseUint(seqPart, 10, 64)
+	require.Nil(t, err)
+	return
+}
+
+func TestStreamIdOrdering(t *testing.T) {
+	tests := []struct {
+		name     string
+		sortKeys []string
+	}{
+		{
+			name: "L1 by hash",
+			sortKeys: []string{
+				"000001000000,0000000000000," + hashA,
+				"000001000000,0000000000000," + hashB,
+			},
+		},
+		{
+			name: "L1 before L2 from the same block",
+			sortKeys: []string{
+				"000001000000,0000000000000," + hashB,
+				"000001000000,1700000000000,00000000",
+			},
+		},
+		{
+			name: "L2 before L1 from the next block",
+			sortKeys: []string{
+				"000001000000,1700000000000,00000005",
+				"000001000001,0000000000000," + hashA,
+			},
+		},
+		{
+			name: "L2 by timestamp and counter",
+			sortKeys: []string{
+				"000001000000,1700000000000,00000001",
+				"000001000000,1700000000000,00000002",
+				"000001000000,1700000000001,00000000",
+			},
+		},
+		{
+			name: "L2 by timestamp and hash",
+			sortKeys: []string{
+				"000001000000,1700000000000," + hashA,
+				"000001000000,1700000000000," + hashB,
+				"000001000000,1700000000001," + hashA,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var prevMs, prevSeq uint64
+			for i, sortKey := range tt.sortKeys {
+				id, err := StreamId(sortKey)
+				require.Nil(t, err)
+
+				ms, seq := parseStreamId(t, id)
+				if i > 0 {
+					require.True(t, ms > prevMs || (ms == prevMs && seq > prevSeq), "%s isn't after the previous id", id)
+				}
+				prevMs, prevSeq = ms, seq
+			}
+		})
+	}
+}
+
+func TestStreamIdCollisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      string
+		isCollide bool
+	}{
+		{
+			name:      "L1 hashes differ in the first bits",
+			a:         "000001000000,0000000000000," + hashA,
+			b:         "000001000000,0000000000000," + hashB,
+			isCollide: false,
+		},
+		{
+			// Only 22 bits of the hash fit next to the timestamp, such messages can't be told apart by the id
+			name:      "L2 hashes differ after 22 bits",
+			a:         "000001000000,1700000000000,abcdef" + strings.Repeat("0", 58),
+			b:         "000001000000,1700000000000,abcdef" + strings.Repeat("f", 58),
+			isCollide: true,
+		},
+		{
+			name:      "L1 and L2 with the same last segment",
+			a:         "000001000000,0000000000000,00000001",
+			b:         "000001000000,1700000000000,00000001",
+			isCollide: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := StreamId(tt.a)
+			require.Nil(t, err)
+
+			b, err := StreamId(tt.b)
+			require.Nil(t, err)
+
+			require.Equal(t, tt.isCollide, a == b)
+		})
+	}
+}
+
+func TestStreamIdInvalid(t *testing.T) {
+	for _, sortKey := range []string{
+		"",
+		"000001000000,0000000000000",
+		"1000000,0000000000000,00000001",
+		"000001000000,1700000000000,123",
+		"000001000000,1700000000000,99999999",
+	} {
+		_, err := StreamId(sortKey)
+		require.NotNil(t, err, sortKey)
+	}
+}
+
+func TestNextStreamId(t *testing.T) {
+	tests := []struct {
+		id, next string
+	}{
+		{"2000001-0", "2000001-1"},
+		{"2000001-41", "2000001-42"},
+		{"2000001-18446744073709551615", "2000002-0"},
+	}
+
+	for _, tt := range tests {
+		next, err := NextStreamId(tt.id)
+		require.Nil(t, err)
+		require.Equal(t, tt.next, next)
+	}
+
+	_, err := NextStreamId("2000001")
+	require.NotNil(t, err)
+}
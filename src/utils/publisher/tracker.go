@@ -0,0 +1,44 @@
+package publisher
+
+// Messages whose delivery can be tracked
+type Trackable interface {
+	SortKeyGetter
+	GetInteractionSource() string
+}
+
+// Informed about messages a publisher takes from its input and about ones it's done with.
+// Messages are delivered when they reach the destination or are durably saved for a retry.
+// Failed messages were discarded or the publisher gave up on them, they aren't delivered.
+// Messages interrupted by stopping are neither, they get replayed after the restart.
+type DeliveryTracker interface {
+	OnDispatched(publisher, source, sortKey string)
+	OnDelivered(publisher, source, sortKey string)
+	OnFailed(publisher, source, sortKey string)
+}
+
+func trackDispatched(tracker DeliveryTracker, publisher string, message any) {
+	if tracker == nil {
+		return
+	}
+	if trackable, ok := message.(Trackable); ok && trackable.GetSortKey() != "" {
+		tracker.OnDispatched(publisher, trackable.GetInteractionSource(), trackable.GetSortKey())
+	}
+}
+
+func trackDelivered(tracker DeliveryTracker, publisher string, message any) {
+	if tracker == nil {
+		return
+	}
+	if trackable, ok := message.(Trackable); ok && trackable.GetSortKey() != "" {
+		tracker.OnDelivered(publisher, trackable.GetInteractionSource(), trackable.GetSortKey())
+	}
+}
+
+func trackFailed(tracker DeliveryTracker, publisher string, message any) {
+	if tracker == nil {
+		return
+	}
+	if trackable, ok := message.(Trackable); ok && trackable.GetSortKey() != "" {
+		tracker.OnFailed(publisher, trackable.GetInteractionSource(), trackable.GetSortKey())
+	}
+}
@@ -0,0 +1,498 @@
+package publisher
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-resty/resty/v2"
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm"
+)
+
+const (
+	// Unix timestamp of the request, part of the signed content
+	WebhookTimestampHeader = "X-Warp-Timestamp"
+
+	// HMAC-SHA256 of "<timestamp>.<body>", hex encoded and prefixed with "sha256="
+	WebhookSignatureHeader = "X-Warp-Signature"
+)
+
+// Endpoint responded with 4xx, sending the same batch again won't help
+var ErrWebhookRejected = errors.New("webhook rejected the batch")
+
+// Sends batches of messages to an HTTP endpoint, as a JSON array.
+// Batches that can't be delivered are saved in the database and redelivered periodically.
+// Until the retry queue is empty new batches are put at its end, so the order of messages is kept.
+// Batches rejected by the endpoint or failing too many times are moved to dead letters, so they don't block the queue.
+type WebhookPublisher[In encoding.BinaryMarshaler] struct {
+	*task.Task
+
+	webhookConfig config.Webhook
+	monitor       monitoring.Monitor
+	db            *gorm.DB
+	client        *resty.Client
+	input         chan In
+
+	// Messages published before the input, optional
+	replay chan In
+
+	// Informed about published messages, optional
+	tracker DeliveryTracker
+
+	// Receives batches that can't be delivered, optional
+	errorSink task.ErrorSink
+
+	// Monitor index
+	monitorIdx int
+
+	// Set when the endpoint keeps failing, batches go straight to the retry queue.
+	// Changed only with the mutex held
+	mtx       sync.Mutex
+	isFailing atomic.Bool
+}
+
+func NewWebhookPublisher[In encoding.BinaryMarshaler](config *config.Config, webhookConfig config.Webhook, name string) (self *WebhookPublisher[In]) {
+	self = new(WebhookPublisher[In])
+
+	self.webhookConfig = webhookConfig
+
+	self.client = resty.New().
+		SetTimeout(webhookConfig.Timeout)
+
+	self.Task = task.NewTask(config, name).
+		WithOnBeforeStart(self.init).
+		WithSubtaskFunc(self.run).
+		WithPeriodicSubtaskFunc(webhookConfig.RetryQueueInterval, self.redeliver)
+
+	return
+}
+
+func (self *WebhookPublisher[In]) WithInputChannel(v chan In) *WebhookPublisher[In] {
+	self.input = v
+	return self
+}
+
+func (self *WebhookPublisher[In]) WithReplayChannel(v chan In) *WebhookPublisher[In] {
+	self.replay = v
+	return self
+}
+
+func (self *WebhookPublisher[In]) WithDeliveryTracker(v DeliveryTracker) *WebhookPublisher[In] {
+	self.tracker = v
+	return self
+}
+
+func (self *WebhookPublisher[In]) WithMonitor(monitor monitoring.Monitor, idx int) *WebhookPublisher[In] {
+	self.monitor = monitor
+	self.monitorIdx = idx
+	return self
+}
+
+func (self *WebhookPublisher[In]) WithErrorSink(v task.ErrorSink) *WebhookPublisher[In] {
+	self.errorSink = v
+	return self
+}
+
+func (self *WebhookPublisher[In]) WithDB(db *gorm.DB) *WebhookPublisher[In] {
+	self.db = db
+	return self
+}
+
+// Batches left in the retry queue after a restart are delivered before any new ones
+func (self *WebhookPublisher[In]) init() (err error) {
+	var count int64
+	err = self.db.WithContext(self.Ctx).
+		Model(&model.WebhookRetry{}).
+		Where("publisher = ?", self.Name).
+		Count(&count).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to get the size of the retry queue")
+		return
+	}
+
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.RetryQueueSize.Store(count)
+	if count > 0 {
+		self.Log.WithField("size", count).Info("Found batches in the retry queue")
+		self.setFailing(true)
+	}
+
+	return
+}
+
+func (self *WebhookPublisher[In]) run() (err error) {
+	batch := make([]In, 0, self.webhookConfig.BatchSize)
+
+	// Replayed messages go before the live ones
+	input := self.input
+	if self.replay != nil {
+		input = self.replay
+	}
+
+	// Ensures messages don't wait for the batch to fill up for too long
+	timer := time.NewTimer(self.webhookConfig.BatchMaxInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case in, ok := <-input:
+			if !ok {
+				if self.replay != nil {
+					// Replay finished
+					self.replay = nil
+					input = self.input
+					continue
+				}
+
+				// Source is stopping, send what's left
+				return self.publish(batch)
+			}
+
+			trackDispatched(self.tracker, self.Name, in)
+
+			batch = append(batch, in)
+			if len(batch) < self.webhookConfig.BatchSize {
+				continue
+			}
+		case <-timer.C:
+		}
+
+		err = self.publish(batch)
+		if err != nil {
+			return
+		}
+
+		batch = make([]In, 0, self.webhookConfig.BatchSize)
+		timer.Reset(self.webhookConfig.BatchMaxInterval)
+	}
+}
+
+// Delivers the batch or puts it in the retry queue
+func (self *WebhookPublisher[In]) publish(batch []In) (err error) {
+	if len(batch) == 0 {
+		return
+	}
+
+	// Messages saved in the retry queue or dead letters count as delivered,
+	// ones interrupted by stopping get replayed after the restart
+	defer func() {
+		for _, in := range batch {
+			switch {
+			case err == nil:
+				trackDelivered(self.tracker, self.Name, in)
+			case !self.IsStopping.Load():
+				trackFailed(self.tracker, self.Name, in)
+			}
+		}
+	}()
+
+	messages := make([]json.RawMessage, 0, len(batch))
+	for _, in := range batch {
+		buf, err := in.MarshalBinary()
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to marshal message, skipping")
+			continue
+		}
+		messages = append(messages, buf)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(messages)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to marshal batch, skipping")
+		return nil
+	}
+
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	if self.isFailing.Load() {
+		return self.enqueue(payload)
+	}
+
+	err = task.NewRetry().
+		WithContext(self.Ctx).
+		WithMaxElapsedTime(self.webhookConfig.MaxElapsedTime).
+		WithMaxInterval(self.webhookConfig.MaxInterval).
+		WithOnError(func(err error, isDurationAcceptable bool) error {
+			if errors.Is(err, context.Canceled) && self.IsStopping.Load() {
+				// Stopping
+				return backoff.Permanent(err)
+			}
+
+			self.Log.WithError(err).Warn("Failed to deliver batch, retrying")
+			self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.Delivery.Inc()
+			return err
+		}).
+		Run(func() error {
+			return self.deliver(payload)
+		})
+	if errors.Is(err, ErrWebhookRejected) {
+		// Following batches may be fine
+		self.deadLetter(payload, err)
+		return nil
+	}
+	if err != nil {
+		self.Log.WithError(err).WithField("len", len(batch)).Error("Persistent error to deliver batch, moving it to the retry queue")
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.PersistentFailure.Inc()
+		self.setFailing(true)
+		return self.enqueue(payload)
+	}
+
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.BatchesDelivered.Inc()
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.MessagesDelivered.Add(uint64(len(batch)))
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.LastSuccessfulDeliveryTimestamp.Store(time.Now().Unix())
+
+	return
+}
+
+// Single delivery attempt
+func (self *WebhookPublisher[In]) deliver(payload []byte) (err error) {
+	return postWebhook(self.Ctx, self.client, self.webhookConfig, payload)
+}
+
+func postWebhook(ctx context.Context, client *resty.Client, webhookConfig config.Webhook, payload []byte) (err error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req := client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(WebhookTimestampHeader, timestamp).
+		SetBody(payload)
+
+	if webhookConfig.Secret != "" {
+		req.SetHeader(WebhookSignatureHeader, "sha256="+sign(webhookConfig.Secret, timestamp, payload))
+	}
+
+	resp, err := req.Post(webhookConfig.Url)
+	if err != nil {
+		return
+	}
+
+	if !resp.IsSuccess() {
+		err = fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+		if resp.StatusCode() >= 400 && resp.StatusCode() < 500 &&
+			resp.StatusCode() != http.StatusRequestTimeout &&
+			resp.StatusCode() != http.StatusTooManyRequests {
+			// Endpoint rejected the payload, retrying won't help
+			err = backoff.Permanent(fmt.Errorf("%w: %w", ErrWebhookRejected, err))
+		}
+	}
+
+	return
+}
+
+func sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sends a dead-lettered batch once, out of order with the rest
+func ReplayWebhookBatch(webhookConfig config.Webhook) func(ctx context.Context, db *gorm.DB, payload []byte) error {
+	client := resty.New().
+		SetTimeout(webhookConfig.Timeout)
+	return func(ctx context.Context, db *gorm.DB, payload []byte) error {
+		return postWebhook(ctx, client, webhookConfig, payload)
+	}
+}
+
+// Batch won't be delivered again automatically
+func (self *WebhookPublisher[In]) deadLetter(payload []byte, err error) {
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.BatchesDeadLettered.Inc()
+	if self.errorSink == nil {
+		self.Log.WithError(err).Error("Batch can't be delivered and dead letters are disabled, batch is lost")
+		return
+	}
+
+	self.Log.WithError(err).Error("Batch can't be delivered, moving it to dead letters")
+	self.errorSink.OnError(self.Name, json.RawMessage(payload), err)
+}
+
+// Saves the batch at the end of the retry queue
+func (self *WebhookPublisher[In]) enqueue(payload []byte) (err error) {
+	retry := &model.WebhookRetry{
+		Publisher: self.Name,
+		Payload:   pgtype.JSONB{Bytes: payload, Status: pgtype.Present},
+	}
+
+	if self.IsStopping.Load() {
+		// Task's context is already cancelled, last batches get one attempt
+		ctx, cancel := context.WithTimeout(context.Background(), self.webhookConfig.Timeout)
+		defer cancel()
+		err = self.db.WithContext(ctx).Create(retry).Error
+	} else {
+		err = task.NewRetry().
+			WithContext(self.Ctx).
+			WithMaxElapsedTime(0).
+			WithMaxInterval(self.webhookConfig.MaxInterval).
+			WithOnError(func(err error, isDurationAcceptable bool) error {
+				if errors.Is(err, context.Canceled) && self.IsStopping.Load() {
+					// Stopping
+					return backoff.Permanent(err)
+				}
+
+				self.Log.WithError(err).Warn("Failed to save batch in the retry queue, retrying")
+				self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+				return err
+			}).
+			Run(func() error {
+				return self.db.WithContext(self.Ctx).Create(retry).Error
+			})
+	}
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to save batch in the retry queue, batch is lost")
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+		return
+	}
+
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.BatchesQueued.Inc()
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.RetryQueueSize.Inc()
+
+	return
+}
+
+// Periodically delivers batches from the retry queue, oldest first.
+// Stops at the first failure, so the order is kept. Batches that won't be delivered are moved to dead letters.
+func (self *WebhookPublisher[In]) redeliver() (err error) {
+	if !self.isFailing.Load() {
+		return
+	}
+
+	for {
+		var retries []*model.WebhookRetry
+		err = self.db.WithContext(self.Ctx).
+			Where("publisher = ?", self.Name).
+			Order("id").
+			Limit(self.webhookConfig.RetryQueueBatchSize).
+			Find(&retries).
+			Error
+		if err != nil {
+			self.Log.WithError(err).Error("Failed to get batches from the retry queue")
+			self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+			return nil
+		}
+
+		for _, retry := range retries {
+			err = self.deliver(retry.Payload.Bytes)
+			if err != nil {
+				self.Log.WithError(err).
+					WithField("id", retry.Id).
+					WithField("attempts", retry.Attempts+1).
+					Warn("Failed to redeliver batch from the retry queue")
+				self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.Delivery.Inc()
+
+				if errors.Is(err, ErrWebhookRejected) ||
+					(self.webhookConfig.RetryQueueMaxAttempts > 0 && retry.Attempts+1 >= self.webhookConfig.RetryQueueMaxAttempts) {
+					err = self.removeDeadLetter(retry, err)
+					if err != nil {
+						return nil
+					}
+					continue
+				}
+
+				err = self.db.WithContext(self.Ctx).
+					Model(retry).
+					Updates(map[string]interface{}{
+						"attempts":   gorm.Expr("attempts + 1"),
+						"last_error": err.Error(),
+					}).
+					Error
+				if err != nil {
+					self.Log.WithError(err).Error("Failed to update batch in the retry queue")
+					self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+				}
+				return nil
+			}
+
+			// Batch may be delivered again if this fails
+			err = self.db.WithContext(self.Ctx).
+				Delete(retry).
+				Error
+			if err != nil {
+				self.Log.WithError(err).Error("Failed to remove batch from the retry queue")
+				self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+				return nil
+			}
+
+			self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.BatchesRedelivered.Inc()
+			self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.RetryQueueSize.Dec()
+			self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.LastSuccessfulDeliveryTimestamp.Store(time.Now().Unix())
+		}
+
+		if len(retries) < self.webhookConfig.RetryQueueBatchSize {
+			break
+		}
+	}
+
+	// No batch can be queued while the lock is held
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	var count int64
+	err = self.db.WithContext(self.Ctx).
+		Model(&model.WebhookRetry{}).
+		Where("publisher = ?", self.Name).
+		Count(&count).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to get the size of the retry queue")
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+		return nil
+	}
+
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.RetryQueueSize.Store(count)
+	if count == 0 {
+		self.Log.Info("Retry queue is empty, delivering new batches directly")
+		self.setFailing(false)
+	}
+
+	return nil
+}
+
+// Moves the batch from the retry queue to dead letters
+func (self *WebhookPublisher[In]) removeDeadLetter(retry *model.WebhookRetry, cause error) (err error) {
+	self.deadLetter(retry.Payload.Bytes, cause)
+
+	// Batch may be dead-lettered again if this fails
+	err = self.db.WithContext(self.Ctx).
+		Delete(retry).
+		Error
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to remove batch from the retry queue")
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].Errors.RetryQueue.Inc()
+		return
+	}
+	self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.RetryQueueSize.Dec()
+	return
+}
+
+func (self *WebhookPublisher[In]) setFailing(v bool) {
+	self.isFailing.Store(v)
+	if v {
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.IsFailing.Store(1)
+	} else {
+		self.monitor.GetReport().WebhookPublishers[self.monitorIdx].State.IsFailing.Store(0)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
@@ -40,7 +41,7 @@ func newBaseClient(config *config.Sequencer) (self *BaseClient) {
 			SetTimeout(self.config.RequestTimeout).
 			SetHeader("User-Agent", "warp.cc/sequencer").
 			SetRetryCount(0).
-			SetTransport(self.createTransport()).
+			SetTransport(tracing.NewTransport(self.createTransport())).
 			AddRetryCondition(self.onRetryCondition).
 			// OnBeforeRequest(self.onRateLimit).
 			// NOTE: Trace logs, used only when debugging. Needs to be before other OnAfterResponse callbacks
@@ -0,0 +1,61 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/warp-contracts/syncer/src/utils/arweave"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/model"
+	"github.com/warp-contracts/syncer/src/utils/monitoring"
+	monitor_supervisor "github.com/warp-contracts/syncer/src/utils/monitoring/supervisor"
+	"github.com/warp-contracts/syncer/src/utils/peer_monitor"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"gorm.io/gorm"
+)
+
+// Resources shared by modes running in one process, see the run command.
+// Nil Resources (or nil fields) mean the mode creates everything on its own, like when it runs as a separate process
+type Resources struct {
+	DB            *gorm.DB
+	ArweaveClient *arweave.Client
+	PeerMonitor   *peer_monitor.PeerMonitor
+
+	// Monitoring server serving monitors of all modes
+	Monitor *monitor_supervisor.Monitor
+	Server  *monitoring.Server
+}
+
+// Shared read-write connection or a new one
+func (self *Resources) GetDB(ctx context.Context, config *config.Config, applicationName string) (*gorm.DB, error) {
+	if self == nil || self.DB == nil {
+		return model.NewConnection(ctx, config, applicationName)
+	}
+	return self.DB, nil
+}
+
+// Shared Arweave client or a new one
+func (self *Resources) GetArweaveClient(ctx context.Context, config *config.Config) *arweave.Client {
+	if self == nil || self.ArweaveClient == nil {
+		return arweave.NewClient(ctx, config)
+	}
+	return self.ArweaveClient
+}
+
+// Shared peer monitor already updates peers of the shared Arweave client
+func (self *Resources) IsPeerMonitorShared() bool {
+	return self != nil && self.PeerMonitor != nil
+}
+
+// Returns the monitoring server task for the mode's monitor.
+// Nil if the monitor got added to the shared server, with metrics prefixed with the mode's name
+func (self *Resources) ServeMonitor(config *config.Config, name string, monitor monitoring.Monitor) *task.Task {
+	if self == nil || self.Server == nil {
+		return monitoring.NewServer(config).
+			WithMonitor(monitor).Task
+	}
+
+	self.Monitor.WithMonitor(name, monitor)
+	self.Server.WithNamedMonitor(name, monitor)
+	return nil
+}
@@ -0,0 +1,132 @@
+package streamer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgtype"
+	"gorm.io/gorm/schema"
+)
+
+type Operation string
+
+const (
+	OperationInsert Operation = "INSERT"
+	OperationUpdate Operation = "UPDATE"
+	OperationDelete Operation = "DELETE"
+)
+
+var (
+	ErrInvalidDestination = errors.New("destination needs to be a pointer to a struct")
+	naming                = schema.NamingStrategy{}
+	scannerType           = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType              = reflect.TypeOf(time.Time{})
+)
+
+// Sent transaction, confirmed when all its changes are acked
+type transaction struct {
+	lsn       pglogrepl.LSN
+	remaining int
+}
+
+// Single row change from the logical replication stream
+type Change struct {
+	// End of the transaction with this change. Pass the change to Replicator.Ack after it's handled
+	LSN pglogrepl.LSN
+
+	transaction *transaction
+
+	Table     string
+	Operation Operation
+
+	// Column values in the text format, nil is NULL.
+	// Updates miss unchanged TOASTed values and deletes only have the key columns,
+	// unless the table has REPLICA IDENTITY FULL (see Replication.FullReplicaIdentity)
+	Values map[string]*string
+}
+
+// Value of the column, false if it's NULL or missing
+func (self *Change) Get(column string) (string, bool) {
+	value, ok := self.Values[column]
+	if !ok || value == nil {
+		return "", false
+	}
+	return *value, true
+}
+
+// Copies values to the struct's fields, columns are named like in gorm.
+// Fields without a matching column are left untouched.
+func (self *Change) Scan(dest any) (err error) {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Pointer || val.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+	val = val.Elem()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		value, ok := self.Values[naming.ColumnName("", field.Name)]
+		if !ok {
+			continue
+		}
+
+		err = scanValue(val.Field(i), value)
+		if err != nil {
+			return fmt.Errorf("failed to scan column for field %s: %w", field.Name, err)
+		}
+	}
+	return
+}
+
+func scanValue(field reflect.Value, value *string) (err error) {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return
+	}
+
+	// Types from pgtype and database/sql parse the text format
+	if reflect.PointerTo(field.Type()).Implements(scannerType) {
+		return field.Addr().Interface().(sql.Scanner).Scan(*value)
+	}
+
+	if field.Type() == timeType {
+		var timestamp pgtype.Timestamptz
+		err = timestamp.DecodeText(nil, []byte(*value))
+		if err != nil {
+			return
+		}
+		field.Set(reflect.ValueOf(timestamp.Time))
+		return
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(*value)
+	case reflect.Bool:
+		field.SetBool(*value == "t" || *value == "true")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		v, err = strconv.ParseInt(*value, 10, field.Type().Bits())
+		field.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		v, err = strconv.ParseUint(*value, 10, field.Type().Bits())
+		field.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		v, err = strconv.ParseFloat(*value, field.Type().Bits())
+		field.SetFloat(v)
+	default:
+		// Nested structs, slices etc. aren't columns
+	}
+	return
+}
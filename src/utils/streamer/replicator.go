@@ -0,0 +1,462 @@
+package streamer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/build_info"
+	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/task"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// Postgres error codes
+const (
+	// Creating a slot that already exists
+	errCodeDuplicateObject = "42710"
+
+	// Slot is streamed by another connection, e.g. another replica
+	errCodeObjectInUse = "55006"
+)
+
+// Streams row changes through Postgres logical replication (pgoutput), in commit order.
+// Replication slot is persistent, so changes made while the replicator is down are streamed after it starts.
+// Changes are streamed again after a restart unless they were confirmed with Ack.
+// Transaction is confirmed when all its changes, and all earlier transactions, were acked.
+// Slot can be streamed by one connection at a time. Replicas with the same Replication.SlotId wait for each other.
+type Replicator struct {
+	*task.Task
+
+	slotName string
+
+	// Only changes to those tables are streamed, empty means all published tables
+	tables map[string]struct{}
+
+	connection *pgconn.PgConn
+
+	// Relations received in this connection, by id
+	relations map[uint32]*pglogrepl.RelationMessage
+
+	// Changes from the current transaction, sent upon commit
+	pending []*Change
+
+	// Position of the handled changes, confirmed to the server
+	mtx      sync.Mutex
+	ackedLSN pglogrepl.LSN
+
+	// Sent transactions that aren't confirmed yet, in commit order
+	inFlight []*transaction
+
+	Output chan *Change
+}
+
+func NewReplicator(config *config.Config, name string) (self *Replicator) {
+	self = new(Replicator)
+
+	self.slotName = config.Replication.SlotPrefix + name
+	if config.Replication.SlotId != "" {
+		self.slotName += "_" + config.Replication.SlotId
+	}
+	self.tables = make(map[string]struct{})
+	self.Output = make(chan *Change)
+
+	self.Task = task.NewTask(config, "replicator-"+name).
+		WithSubtaskFunc(self.run).
+		WithOnAfterStop(func() {
+			close(self.Output)
+		}).
+		WithOnAfterStop(self.disconnect).
+		WithOnAfterStop(self.dropSlot)
+
+	return
+}
+
+func (self *Replicator) WithTables(tables ...string) *Replicator {
+	for _, table := range tables {
+		self.tables[table] = struct{}{}
+	}
+	return self
+}
+
+func (self *Replicator) WithCapacity(size int) *Replicator {
+	self.Output = make(chan *Change, size)
+	return self
+}
+
+// Marks the change as handled, call it exactly once per change.
+// Change won't be streamed again after a restart once the rest of its transaction is handled as well
+func (self *Replicator) Ack(change *Change) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	if change.transaction == nil {
+		return
+	}
+	change.transaction.remaining--
+	self.confirmHandled()
+}
+
+// Moves the confirmed position past transactions that were handled completely, in commit order
+func (self *Replicator) confirmHandled() {
+	for len(self.inFlight) > 0 && self.inFlight[0].remaining <= 0 {
+		if self.inFlight[0].lsn > self.ackedLSN {
+			self.ackedLSN = self.inFlight[0].lsn
+		}
+		self.inFlight = self.inFlight[1:]
+	}
+}
+
+// Transactions without changes are confirmed as soon as the ones before them
+func (self *Replicator) addInFlight(lsn pglogrepl.LSN, numChanges int) (out *transaction) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	if numChanges == 0 && len(self.inFlight) > 0 && self.inFlight[len(self.inFlight)-1].remaining == 0 {
+		// Consecutive empty transactions waiting for an earlier one, only the last position matters
+		out = self.inFlight[len(self.inFlight)-1]
+		out.lsn = lsn
+		return
+	}
+
+	out = &transaction{lsn: lsn, remaining: numChanges}
+	self.inFlight = append(self.inFlight, out)
+	self.confirmHandled()
+	return
+}
+
+func (self *Replicator) getAckedLSN() pglogrepl.LSN {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	return self.ackedLSN
+}
+
+func (self *Replicator) run() (err error) {
+	for {
+		err = self.replicate()
+		if self.IsStopping.Load() {
+			return nil
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == errCodeObjectInUse {
+			self.Log.WithField("slot", self.slotName).Debug("Replication slot is used by another replica, waiting")
+		} else {
+			self.Log.WithError(err).Error("Replication failed, reconnecting")
+		}
+		self.disconnect()
+
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case <-time.After(self.Config.Replication.ReconnectInterval):
+		}
+	}
+}
+
+func (self *Replicator) connect(ctx context.Context) (err error) {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s application_name=%s/warp.cc/%s replication=database",
+		self.Config.Database.Host,
+		self.Config.Database.Port,
+		self.Config.Database.User,
+		self.Config.Database.Password,
+		self.Config.Database.Name,
+		self.Config.Database.SslMode,
+		self.Name,
+		build_info.Version)
+
+	config, err := pgconn.ParseConfig(dsn)
+	if err != nil {
+		return
+	}
+
+	tlsConfig, err := tlsConfig(self.Config, self.Log)
+	if err != nil {
+		return
+	}
+	if tlsConfig != nil {
+		config.TLSConfig = tlsConfig
+	}
+
+	self.connection, err = pgconn.ConnectConfig(ctx, config)
+	return
+}
+
+func (self *Replicator) disconnect() {
+	if self.connection == nil {
+		return
+	}
+
+	// Context is already cancelled when stopping
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := self.connection.Close(ctx)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to close connection")
+	}
+	self.connection = nil
+}
+
+// Removes the slot with all the changes it retains, if configured to
+func (self *Replicator) dropSlot() {
+	if !self.Config.Replication.DropSlotOnStop {
+		return
+	}
+
+	// Context is already cancelled when stopping
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := self.connect(ctx)
+	if err != nil {
+		self.Log.WithError(err).Error("Failed to connect to drop the replication slot")
+		return
+	}
+	defer self.disconnect()
+
+	err = pglogrepl.DropReplicationSlot(ctx, self.connection, self.slotName, pglogrepl.DropReplicationSlotOptions{Wait: true})
+	if err != nil {
+		self.Log.WithError(err).WithField("slot", self.slotName).Error("Failed to drop replication slot")
+		return
+	}
+	self.Log.WithField("slot", self.slotName).Info("Dropped replication slot")
+}
+
+// Creates the slot if needed and gets its confirmed position
+func (self *Replicator) setupSlot() (err error) {
+	_, err = pglogrepl.CreateReplicationSlot(self.Ctx, self.connection, self.slotName, "pgoutput",
+		pglogrepl.CreateReplicationSlotOptions{Mode: pglogrepl.LogicalReplication})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == errCodeDuplicateObject {
+		err = nil
+	} else if err == nil {
+		self.Log.WithField("slot", self.slotName).Info("Created replication slot")
+	}
+	if err != nil {
+		return
+	}
+
+	results, err := self.connection.Exec(self.Ctx,
+		fmt.Sprintf("SELECT confirmed_flush_lsn FROM pg_replication_slots WHERE slot_name = '%s'", self.slotName)).
+		ReadAll()
+	if err != nil {
+		return
+	}
+	if len(results) != 1 || len(results[0].Rows) != 1 || results[0].Rows[0][0] == nil {
+		return fmt.Errorf("replication slot %s not found", self.slotName)
+	}
+
+	lsn, err := pglogrepl.ParseLSN(string(results[0].Rows[0][0]))
+	if err != nil {
+		return
+	}
+
+	// Changes before this position won't be streamed anyway.
+	// Transactions in flight from the previous connection are streamed again, their acks don't count anymore
+	self.mtx.Lock()
+	if lsn > self.ackedLSN {
+		self.ackedLSN = lsn
+	}
+	self.inFlight = nil
+	self.mtx.Unlock()
+
+	return
+}
+
+// Old rows of the tables passed to WithTables are sent with updates and deletes
+func (self *Replicator) setupReplicaIdentity() (err error) {
+	for table := range self.tables {
+		_, err = self.connection.Exec(self.Ctx,
+			fmt.Sprintf("ALTER TABLE %s REPLICA IDENTITY FULL", pgx.Identifier{table}.Sanitize())).
+			ReadAll()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (self *Replicator) replicate() (err error) {
+	err = self.connect(self.Ctx)
+	if err != nil {
+		return
+	}
+
+	err = self.setupSlot()
+	if err != nil {
+		return
+	}
+
+	if self.Config.Replication.FullReplicaIdentity {
+		err = self.setupReplicaIdentity()
+		if err != nil {
+			return
+		}
+	}
+
+	// Streaming continues from the last confirmed position
+	err = pglogrepl.StartReplication(self.Ctx, self.connection, self.slotName, 0, pglogrepl.StartReplicationOptions{
+		PluginArgs: []string{
+			"proto_version '1'",
+			fmt.Sprintf("publication_names '%s'", self.Config.Replication.PublicationName),
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	self.Log.WithField("slot", self.slotName).WithField("lsn", self.getAckedLSN()).Info("Replication started")
+
+	self.relations = make(map[uint32]*pglogrepl.RelationMessage)
+	self.pending = nil
+
+	nextStatusUpdate := time.Now()
+	for {
+		if time.Now().After(nextStatusUpdate) {
+			err = pglogrepl.SendStandbyStatusUpdate(self.Ctx, self.connection, pglogrepl.StandbyStatusUpdate{
+				WALWritePosition: self.getAckedLSN(),
+			})
+			if err != nil {
+				return
+			}
+			nextStatusUpdate = time.Now().Add(self.Config.Replication.StatusInterval)
+		}
+
+		ctx, cancel := context.WithDeadline(self.Ctx, nextStatusUpdate)
+		var msg pgproto3.BackendMessage
+		msg, err = self.connection.ReceiveMessage(ctx)
+		cancel()
+		if err != nil {
+			if pgconn.Timeout(err) && !self.IsStopping.Load() {
+				continue
+			}
+			return
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.CopyData:
+			err = self.handleCopyData(msg.Data)
+			if err != nil {
+				return
+			}
+
+			// Server asked for the status
+			if self.isReplyRequested(msg.Data) {
+				nextStatusUpdate = time.Time{}
+			}
+		default:
+			self.Log.WithField("type", fmt.Sprintf("%T", msg)).Debug("Unexpected message")
+		}
+	}
+}
+
+func (self *Replicator) isReplyRequested(data []byte) bool {
+	if len(data) == 0 || data[0] != pglogrepl.PrimaryKeepaliveMessageByteID {
+		return false
+	}
+	keepalive, err := pglogrepl.ParsePrimaryKeepaliveMessage(data[1:])
+	return err == nil && keepalive.ReplyRequested
+}
+
+func (self *Replicator) handleCopyData(data []byte) (err error) {
+	if len(data) == 0 || data[0] != pglogrepl.XLogDataByteID {
+		return
+	}
+
+	xld, err := pglogrepl.ParseXLogData(data[1:])
+	if err != nil {
+		return
+	}
+
+	logicalMsg, err := pglogrepl.Parse(xld.WALData)
+	if err != nil {
+		return
+	}
+
+	switch logicalMsg := logicalMsg.(type) {
+	case *pglogrepl.RelationMessage:
+		self.relations[logicalMsg.RelationID] = logicalMsg
+	case *pglogrepl.BeginMessage:
+		self.pending = self.pending[:0]
+	case *pglogrepl.InsertMessage:
+		err = self.addChange(OperationInsert, logicalMsg.RelationID, logicalMsg.Tuple, nil)
+	case *pglogrepl.UpdateMessage:
+		err = self.addChange(OperationUpdate, logicalMsg.RelationID, logicalMsg.NewTuple, logicalMsg.OldTuple)
+	case *pglogrepl.DeleteMessage:
+		err = self.addChange(OperationDelete, logicalMsg.RelationID, logicalMsg.OldTuple, nil)
+	case *pglogrepl.CommitMessage:
+		err = self.commit(logicalMsg.TransactionEndLSN)
+	}
+
+	return
+}
+
+// Old tuple is only sent for tables with REPLICA IDENTITY FULL (or upon key change), it fills in unchanged TOASTed values
+func (self *Replicator) addChange(operation Operation, relationId uint32, tuple, oldTuple *pglogrepl.TupleData) (err error) {
+	relation, ok := self.relations[relationId]
+	if !ok {
+		return fmt.Errorf("unknown relation id: %d", relationId)
+	}
+
+	if len(self.tables) > 0 {
+		if _, ok := self.tables[relation.RelationName]; !ok {
+			return
+		}
+	}
+
+	change := &Change{
+		Table:     relation.RelationName,
+		Operation: operation,
+		Values:    make(map[string]*string, len(relation.Columns)),
+	}
+
+	if tuple != nil {
+		for i, column := range tuple.Columns {
+			if i >= len(relation.Columns) {
+				break
+			}
+			name := relation.Columns[i].Name
+			if column.DataType == pglogrepl.TupleDataTypeToast && oldTuple != nil && i < len(oldTuple.Columns) {
+				// Unchanged TOASTed value isn't sent in the new tuple
+				column = oldTuple.Columns[i]
+			}
+			switch column.DataType {
+			case pglogrepl.TupleDataTypeNull:
+				change.Values[name] = nil
+			case pglogrepl.TupleDataTypeText:
+				value := string(column.Data)
+				change.Values[name] = &value
+			default:
+				// Unchanged TOASTed value without the old tuple
+			}
+		}
+	}
+
+	self.pending = append(self.pending, change)
+	return
+}
+
+// Sends all changes from the transaction
+func (self *Replicator) commit(lsn pglogrepl.LSN) (err error) {
+	transaction := self.addInFlight(lsn, len(self.pending))
+	for _, change := range self.pending {
+		change.LSN = lsn
+		change.transaction = transaction
+		select {
+		case <-self.Ctx.Done():
+			return errors.New("replicator stopped")
+		case self.Output <- change:
+		}
+	}
+
+	self.pending = self.pending[:0]
+	return
+}
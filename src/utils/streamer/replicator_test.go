@@ -0,0 +1,51 @@
+package streamer
+
+import (
+	"testing"
+
+	"github.com/jackc/pglogrepl"
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+// Changes of a committed transaction, as commit sends them
+func sent(replicator *Replicator, lsn pglogrepl.LSN, numChanges int) (out []*Change) {
+	transaction := replicator.addInFlight(lsn, numChanges)
+	for i := 0; i < numChanges; i++ {
+		out = append(out, &Change{LSN: lsn, transaction: transaction})
+	}
+	return
+}
+
+func TestAckWholeTransaction(t *testing.T) {
+	replicator := NewReplicator(config.Default(), "test")
+
+	changes := sent(replicator, 10, 3)
+	replicator.Ack(changes[0])
+	replicator.Ack(changes[1])
+	require.Equal(t, pglogrepl.LSN(0), replicator.getAckedLSN())
+
+	replicator.Ack(changes[2])
+	require.Equal(t, pglogrepl.LSN(10), replicator.getAckedLSN())
+}
+
+func TestAckInCommitOrder(t *testing.T) {
+	replicator := NewReplicator(config.Default(), "test")
+
+	first := sent(replicator, 10, 1)
+	second := sent(replicator, 20, 1)
+
+	// Empty transactions wait for the earlier ones
+	sent(replicator, 30, 0)
+	sent(replicator, 40, 0)
+
+	replicator.Ack(second[0])
+	require.Equal(t, pglogrepl.LSN(0), replicator.getAckedLSN())
+
+	replicator.Ack(first[0])
+	require.Equal(t, pglogrepl.LSN(40), replicator.getAckedLSN())
+
+	// Nothing in flight
+	sent(replicator, 50, 0)
+	require.Equal(t, pglogrepl.LSN(50), replicator.getAckedLSN())
+}
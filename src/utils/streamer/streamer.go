@@ -14,6 +14,7 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/task"
 
 	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
 )
 
 // Streams data from postgres notification channel
@@ -128,24 +129,11 @@ func (self *Streamer) connect() (err error) {
 		return
 	}
 
-	if self.Config.Database.ClientCert != "" && self.Config.Database.ClientKey != "" && self.Config.Database.CaCert != "" {
-		cert, err := tls.X509KeyPair([]byte(self.Config.Database.ClientCert), []byte(self.Config.Database.ClientKey))
-		if err != nil {
-			self.Log.WithError(err).Error("Failed to load client cert")
-		}
-
-		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM([]byte(self.Config.Database.CaCert)) {
-			return errors.New("failed to append CA cert to pool")
-		}
-
-		config.TLSConfig = &tls.Config{
-			InsecureSkipVerify: true,
-			RootCAs:            caCertPool,
-			ClientCAs:          caCertPool,
-			Certificates:       []tls.Certificate{cert},
-		}
+	config.TLSConfig, err = tlsConfig(self.Config, self.Log)
+	if err != nil {
+		return
 	}
+
 	self.pool, err = pgx.NewConnPool(pgx.ConnPoolConfig{ConnConfig: config})
 	if err != nil {
 		return
@@ -159,6 +147,30 @@ func (self *Streamer) connect() (err error) {
 	return
 }
 
+// Client certificates from the database config, nil if not set
+func tlsConfig(config *config.Config, log *logrus.Entry) (*tls.Config, error) {
+	if config.Database.ClientCert == "" || config.Database.ClientKey == "" || config.Database.CaCert == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.X509KeyPair([]byte(config.Database.ClientCert), []byte(config.Database.ClientKey))
+	if err != nil {
+		log.WithError(err).Error("Failed to load client cert")
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM([]byte(config.Database.CaCert)) {
+		return nil, errors.New("failed to append CA cert to pool")
+	}
+
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		RootCAs:            caCertPool,
+		ClientCAs:          caCertPool,
+		Certificates:       []tls.Certificate{cert},
+	}, nil
+}
+
 func (self *Streamer) reconnect() {
 	var err error
 	for {
@@ -2,7 +2,9 @@ package task
 
 import (
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 )
@@ -15,13 +17,17 @@ type Duplicator[In any] struct {
 	input          chan In
 	output         []chan In
 	freeChannelIdx int
+
+	metrics *stageMetrics
 }
 
 func NewDuplicator[In any](config *config.Config, name string) (self *Duplicator[In]) {
 	self = new(Duplicator[In])
+	self.metrics = newStageMetrics(config, name)
 
 	self.Task = NewTask(config, name).
 		WithSubtaskFunc(self.run).
+		WithChannel("input", ProbeChannel(&self.input)).
 		WithOnBeforeStart(func() error {
 			if self.freeChannelIdx != len(self.output) {
 				return errors.New("Not all output channels are initialized")
@@ -46,6 +52,7 @@ func (self *Duplicator[In]) WithOutputChannels(numChannels, capacity int) *Dupli
 	self.output = make([]chan In, 0, numChannels)
 	for i := 0; i < numChannels; i++ {
 		self.output = append(self.output, make(chan In, capacity))
+		self.Task = self.Task.WithChannel(fmt.Sprintf("output-%d", i), ProbeChannel(&self.output[i]))
 	}
 	self.Task = self.Task.WithWorkerPool(numChannels, 1)
 	return self
@@ -62,6 +69,8 @@ func (self *Duplicator[In]) run() error {
 	for in := range self.input {
 		// self.Log.Debug("-> Duplicator send")
 		in := in
+		self.metrics.itemsIn.Inc()
+		start := time.Now()
 		wg.Add(len(self.output))
 		for channelIdx := range self.output {
 			channelIdx := channelIdx
@@ -69,6 +78,7 @@ func (self *Duplicator[In]) run() error {
 				select {
 				case <-self.Ctx.Done():
 				case self.output[channelIdx] <- in:
+					self.metrics.itemsOut.Inc()
 				}
 
 				wg.Done()
@@ -77,6 +87,7 @@ func (self *Duplicator[In]) run() error {
 
 		// Wait for all channels to receive data
 		wg.Wait()
+		self.metrics.observeBlocked(start)
 		// self.Log.Debug("<- Duplicator send")
 	}
 	return nil
@@ -0,0 +1,30 @@
+package task
+
+import (
+	"context"
+	"errors"
+)
+
+// Receives items that a pipeline stage failed to handle, so they don't disappear without a trace.
+// Called from the stage's goroutine, implementations shouldn't block for long.
+type ErrorSink interface {
+	// Stage is the name of the task, item is the input that failed processing or a single flushed item
+	OnError(stage string, item any, err error)
+}
+
+// Failures caused by stopping aren't routed. Stages stop in the middle of their work,
+// and the interrupted data is processed again after the restart.
+func (self *Task) isInterrupted(err error) bool {
+	return self.IsStopping.Load() || errors.Is(err, context.Canceled)
+}
+
+// Passes every item to the sink, does nothing if there's no sink
+func routeErrors[T any](sink ErrorSink, stage string, metrics *stageMetrics, items []T, err error) {
+	if sink == nil {
+		return
+	}
+	for _, item := range items {
+		sink.OnError(stage, item, err)
+	}
+	metrics.deadLetters.Add(float64(len(items)))
+}
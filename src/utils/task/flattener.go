@@ -19,6 +19,8 @@ func NewFlattener[In any](config *config.Config, name string) (self *Flattener[I
 
 	self.Task = NewTask(config, name).
 		WithSubtaskFunc(self.run).
+		WithChannel("input", ProbeChannel(&self.input)).
+		WithChannel("output", ProbeChannel(&self.Output)).
 		WithOnAfterStop(func() {
 			close(self.Output)
 		})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync/atomic"
 
 	"github.com/cenkalti/backoff"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -26,30 +27,37 @@ type Hole[In any] struct {
 	// Queue for the processed data
 	queue deque.Deque[In]
 
-	// Batch size that will trigger the onFlush function
-	batchSize int
+	// Batch size that will trigger the onFlush function, may change upon configuration reload
+	batchSize atomic.Int64
 
-	// Flush interval
-	flushInterval time.Duration
+	// Flush interval, may change upon configuration reload
+	flushInterval atomic.Int64
 
 	// Max time flush should be retried. 0 means no limit.
 	maxElapsedTime time.Duration
 
 	// Max times between flush retries
 	maxInterval time.Duration
+
+	// Optional, receives items that failed flushing
+	errorSink ErrorSink
+
+	metrics *stageMetrics
 }
 
 func NewHole[In any](config *config.Config, name string) (self *Hole[In]) {
 	self = new(Hole[In])
+	self.metrics = newStageMetrics(config, name)
 
 	self.Task = NewTask(config, name).
-		WithSubtaskFunc(self.run)
+		WithSubtaskFunc(self.run).
+		WithChannel("input", ProbeChannel(&self.input))
 
 	return
 }
 
 func (self *Hole[In]) WithBatchSize(batchSize int) *Hole[In] {
-	self.batchSize = batchSize
+	self.batchSize.Store(int64(batchSize))
 	exp := uint(math.Round(math.Logb(float64(batchSize)))) + 1
 	self.queue.SetMinCapacity(exp)
 	return self
@@ -61,17 +69,32 @@ func (self *Hole[In]) WithInputChannel(v chan In) *Hole[In] {
 }
 
 func (self *Hole[In]) WithOnFlush(interval time.Duration, f func([]In) error) *Hole[In] {
-	self.flushInterval = interval
+	self.flushInterval.Store(int64(interval))
 	self.onFlush = f
 	return self
 }
 
+// Takes effect upon the next flush
+func (self *Hole[In]) SetBatchSize(batchSize int) {
+	self.batchSize.Store(int64(batchSize))
+}
+
+// Takes effect upon the next flush
+func (self *Hole[In]) SetFlushInterval(interval time.Duration) {
+	self.flushInterval.Store(int64(interval))
+}
+
 func (self *Hole[In]) WithBackoff(maxElapsedTime, maxInterval time.Duration) *Hole[In] {
 	self.maxElapsedTime = maxElapsedTime
 	self.maxInterval = maxInterval
 	return self
 }
 
+func (self *Hole[In]) WithErrorSink(v ErrorSink) *Hole[In] {
+	self.errorSink = v
+	return self
+}
+
 func (self *Hole[In]) flush() (err error) {
 	size := self.queue.Len()
 	data := make([]In, 0, size)
@@ -79,6 +102,9 @@ func (self *Hole[In]) flush() (err error) {
 		data = append(data, self.queue.PopFront())
 	}
 
+	start := time.Now()
+	defer self.metrics.observeFlush(start, size)
+
 	err = NewRetry().
 		WithContext(self.Ctx).
 		WithMaxElapsedTime(self.maxElapsedTime).
@@ -90,6 +116,7 @@ func (self *Hole[In]) flush() (err error) {
 			}
 
 			self.Log.WithError(err).Error("Failed to flush data, retrying")
+			self.metrics.flushRetries.Inc()
 
 			return err
 		}).
@@ -98,6 +125,9 @@ func (self *Hole[In]) flush() (err error) {
 		})
 	if err != nil {
 		self.Log.WithError(err).Error("Failed to flush data, no more retries")
+		if !self.isInterrupted(err) {
+			routeErrors(self.errorSink, self.Name, self.metrics, data, err)
+		}
 		return
 	}
 
@@ -107,7 +137,7 @@ func (self *Hole[In]) flush() (err error) {
 // Receives data from the input channel and saves in the database
 func (self *Hole[In]) run() (err error) {
 	// Used to ensure data isn't stuck in Processor for too long
-	timer := time.NewTimer(self.flushInterval)
+	timer := time.NewTimer(time.Duration(self.flushInterval.Load()))
 
 	for {
 		select {
@@ -118,10 +148,11 @@ func (self *Hole[In]) run() (err error) {
 				err = self.flush()
 				return
 			}
+			self.metrics.itemsIn.Inc()
 
 			self.queue.PushBack(in)
 
-			if self.queue.Len() >= self.batchSize {
+			if int64(self.queue.Len()) >= self.batchSize.Load() {
 				err = self.flush()
 				if err != nil {
 					return err
@@ -134,7 +165,7 @@ func (self *Hole[In]) run() (err error) {
 			if err != nil {
 				return
 			}
-			timer = time.NewTimer(self.flushInterval)
+			timer = time.NewTimer(time.Duration(self.flushInterval.Load()))
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -15,6 +16,8 @@ type Joiner[In JoinController] struct {
 	Output chan In
 
 	mtx sync.RWMutex
+
+	numInputs int
 }
 
 type JoinController interface {
@@ -33,6 +36,7 @@ func NewJoiner[In JoinController](config *config.Config, name string) (self *Joi
 	self.Output = make(chan In)
 
 	self.Task = NewTask(config, name).
+		WithChannel("output", ProbeChannel(&self.Output)).
 		WithOnAfterStop(func() {
 			close(self.Output)
 		})
@@ -41,9 +45,12 @@ func NewJoiner[In JoinController](config *config.Config, name string) (self *Joi
 }
 
 func (self *Joiner[In]) WithInputChannel(input chan In) *Joiner[In] {
-	self.Task = self.Task.WithSubtaskFunc(func() error {
-		return self.handleOneInput(input)
-	})
+	self.numInputs++
+	self.Task = self.Task.
+		WithChannel(fmt.Sprintf("input-%d", self.numInputs-1), ProbeChannel(&input)).
+		WithSubtaskFunc(func() error {
+			return self.handleOneInput(input)
+		})
 	return self
 }
 
@@ -1,26 +1,37 @@
 package task
 
 import (
+	"time"
+
 	"github.com/warp-contracts/syncer/src/utils/config"
 )
 
 // Takes item from input channel, processes it and inserts it into the output channel
+// Process func sends to an internal channel that is forwarded to the output, so both sides are counted in metrics
 type Mapper[In any, Out any] struct {
 	*Task
 
 	process func(in In, out chan Out) (err error)
 
 	input  chan In
+	out    chan Out
 	Output chan Out
+
+	metrics *stageMetrics
 }
 
 func NewMapper[In any, Out any](config *config.Config, name string) (self *Mapper[In, Out]) {
 	self = new(Mapper[In, Out])
+	self.metrics = newStageMetrics(config, name)
 
+	self.out = make(chan Out)
 	self.Output = make(chan Out)
 
 	self.Task = NewTask(config, name).
 		WithSubtaskFunc(self.run).
+		WithSubtaskFunc(self.forward).
+		WithChannel("input", ProbeChannel(&self.input)).
+		WithChannel("output", ProbeChannel(&self.Output)).
 		WithOnAfterStop(func() {
 			close(self.Output)
 		})
@@ -46,8 +57,9 @@ func (self *Mapper[In, Out]) WithWorkerPool(maxWorkers, maxQueueSize int) *Mappe
 func (self *Mapper[In, Out]) run() error {
 	for in := range self.input {
 		in := in
+		self.metrics.itemsIn.Inc()
 		self.SubmitToWorker(func() {
-			err := self.process(in, self.Output)
+			err := self.process(in, self.out)
 			if err != nil {
 				self.Log.WithError(err).Error("Failed to process item, skipping")
 			}
@@ -55,3 +67,22 @@ func (self *Mapper[In, Out]) run() error {
 	}
 	return nil
 }
+
+// Passes processed items to the output. Process funcs give up sending when the context is cancelled, so does this.
+func (self *Mapper[In, Out]) forward() error {
+	for {
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case item := <-self.out:
+			start := time.Now()
+			select {
+			case <-self.Ctx.Done():
+				return nil
+			case self.Output <- item:
+			}
+			self.metrics.observeBlocked(start)
+			self.metrics.itemsOut.Inc()
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package task
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+// Metrics common for the pipeline primitives (Processor, Hole, SinkTask, Mapper, Duplicator), labeled with the mode and the task name.
+// Primitives report them by themselves, so every new pipeline is observable without changes to the mode's collector.
+type Metrics struct {
+	ItemsIn              *prometheus.CounterVec
+	ItemsOut             *prometheus.CounterVec
+	BatchSize            *prometheus.HistogramVec
+	FlushDuration        *prometheus.HistogramVec
+	FlushRetries         *prometheus.CounterVec
+	OutputBlockedSeconds *prometheus.CounterVec
+	DeadLetters          *prometheus.CounterVec
+}
+
+var metrics = &Metrics{
+	ItemsIn: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_items_in",
+		Help: "Number of items received from the input channel",
+	}, []string{"mode", "task"}),
+	ItemsOut: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_items_out",
+		Help: "Number of items sent to the output channels",
+	}, []string{"mode", "task"}),
+	BatchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "task_batch_size",
+		Help:    "Number of items in a flushed batch",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{"mode", "task"}),
+	FlushDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "task_flush_duration_seconds",
+		Help:    "Time of flushing a batch, including retries",
+		Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+	}, []string{"mode", "task"}),
+	FlushRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_flush_retries",
+		Help: "Number of failed flush attempts that were retried",
+	}, []string{"mode", "task"}),
+	OutputBlockedSeconds: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_output_blocked_seconds",
+		Help: "Time spent waiting for the output channel to accept data",
+	}, []string{"mode", "task"}),
+	DeadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "task_dead_letters",
+		Help: "Number of failed items passed to the error sink",
+	}, []string{"mode", "task"}),
+}
+
+// Collector for the primitives' metrics, register it once per process
+func GetPrometheusCollector() prometheus.Collector {
+	return metrics
+}
+
+func (self *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	self.ItemsIn.Describe(ch)
+	self.ItemsOut.Describe(ch)
+	self.BatchSize.Describe(ch)
+	self.FlushDuration.Describe(ch)
+	self.FlushRetries.Describe(ch)
+	self.OutputBlockedSeconds.Describe(ch)
+	self.DeadLetters.Describe(ch)
+}
+
+func (self *Metrics) Collect(ch chan<- prometheus.Metric) {
+	self.ItemsIn.Collect(ch)
+	self.ItemsOut.Collect(ch)
+	self.BatchSize.Collect(ch)
+	self.FlushDuration.Collect(ch)
+	self.FlushRetries.Collect(ch)
+	self.OutputBlockedSeconds.Collect(ch)
+	self.DeadLetters.Collect(ch)
+}
+
+// Metrics of a single primitive
+type stageMetrics struct {
+	itemsIn       prometheus.Counter
+	itemsOut      prometheus.Counter
+	batchSize     prometheus.Observer
+	flushDuration prometheus.Observer
+	flushRetries  prometheus.Counter
+	outputBlocked prometheus.Counter
+	deadLetters   prometheus.Counter
+}
+
+// Stages of different modes run by one process may share a name, the mode tells them apart
+func newStageMetrics(config *config.Config, name string) *stageMetrics {
+	mode := config.Mode()
+	return &stageMetrics{
+		itemsIn:       metrics.ItemsIn.WithLabelValues(mode, name),
+		itemsOut:      metrics.ItemsOut.WithLabelValues(mode, name),
+		batchSize:     metrics.BatchSize.WithLabelValues(mode, name),
+		flushDuration: metrics.FlushDuration.WithLabelValues(mode, name),
+		flushRetries:  metrics.FlushRetries.WithLabelValues(mode, name),
+		outputBlocked: metrics.OutputBlockedSeconds.WithLabelValues(mode, name),
+		deadLetters:   metrics.DeadLetters.WithLabelValues(mode, name),
+	}
+}
+
+// Empty flushes are triggered by timers, they would skew the histograms
+func (self *stageMetrics) observeFlush(start time.Time, size int) {
+	if size == 0 {
+		return
+	}
+	self.batchSize.Observe(float64(size))
+	self.flushDuration.Observe(time.Since(start).Seconds())
+}
+
+func (self *stageMetrics) observeBlocked(start time.Time) {
+	self.outputBlocked.Add(time.Since(start).Seconds())
+}
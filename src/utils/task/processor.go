@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync/atomic"
 
 	"github.com/cenkalti/backoff"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -31,11 +32,11 @@ type Processor[In any, Out any] struct {
 	// Queue for the processed data
 	queue deque.Deque[Out]
 
-	// Batch size that will trigger the onFlush function
-	batchSize int
+	// Batch size that will trigger the onFlush function, may change upon configuration reload
+	batchSize atomic.Int64
 
-	// Flush interval
-	flushInterval time.Duration
+	// Flush interval, may change upon configuration reload
+	flushInterval atomic.Int64
 
 	// Max time flush should be retried. 0 means no limit.
 	maxElapsedTime time.Duration
@@ -45,20 +46,28 @@ type Processor[In any, Out any] struct {
 
 	// Output channel that forwards successfuly processed data
 	Output chan []Out
+
+	// Optional, receives items that failed processing or flushing
+	errorSink ErrorSink
+
+	metrics *stageMetrics
 }
 
 func NewProcessor[In any, Out any](config *config.Config, name string) (self *Processor[In, Out]) {
 	self = new(Processor[In, Out])
+	self.metrics = newStageMetrics(config, name)
 
 	self.Task = NewTask(config, name).
-		WithSubtaskFunc(self.run)
+		WithSubtaskFunc(self.run).
+		WithChannel("input", ProbeChannel(&self.input)).
+		WithChannel("output", ProbeChannel(&self.Output))
 
 	return
 }
 
 func (self *Processor[In, Out]) WithBatchSize(batchSize int) *Processor[In, Out] {
 	self.Output = make(chan []Out)
-	self.batchSize = batchSize
+	self.batchSize.Store(int64(batchSize))
 	exp := uint(math.Round(math.Logb(float64(batchSize)))) + 1
 	self.queue.SetMinCapacity(exp)
 	return self
@@ -70,7 +79,7 @@ func (self *Processor[In, Out]) WithInputChannel(v chan In) *Processor[In, Out]
 }
 
 func (self *Processor[In, Out]) WithOnFlush(interval time.Duration, f func([]Out) ([]Out, error)) *Processor[In, Out] {
-	self.flushInterval = interval
+	self.flushInterval.Store(int64(interval))
 	self.onFlush = f
 	return self
 }
@@ -80,12 +89,27 @@ func (self *Processor[In, Out]) WithOnProcess(f func(In) ([]Out, error)) *Proces
 	return self
 }
 
+// Takes effect upon the next flush
+func (self *Processor[In, Out]) SetBatchSize(batchSize int) {
+	self.batchSize.Store(int64(batchSize))
+}
+
+// Takes effect upon the next flush
+func (self *Processor[In, Out]) SetFlushInterval(interval time.Duration) {
+	self.flushInterval.Store(int64(interval))
+}
+
 func (self *Processor[In, Out]) WithBackoff(maxElapsedTime, maxInterval time.Duration) *Processor[In, Out] {
 	self.maxElapsedTime = maxElapsedTime
 	self.maxInterval = maxInterval
 	return self
 }
 
+func (self *Processor[In, Out]) WithErrorSink(v ErrorSink) *Processor[In, Out] {
+	self.errorSink = v
+	return self
+}
+
 func (self *Processor[In, Out]) flush() (err error) {
 	size := self.queue.Len()
 	data := make([]Out, 0, size)
@@ -93,6 +117,9 @@ func (self *Processor[In, Out]) flush() (err error) {
 		data = append(data, self.queue.PopFront())
 	}
 
+	start := time.Now()
+	defer self.metrics.observeFlush(start, size)
+
 	var out []Out
 	err = NewRetry().
 		WithContext(self.Ctx).
@@ -103,6 +130,7 @@ func (self *Processor[In, Out]) flush() (err error) {
 				// Stopping
 				return backoff.Permanent(err)
 			}
+			self.metrics.flushRetries.Inc()
 			return err
 		}).
 		Run(func() error {
@@ -112,23 +140,67 @@ func (self *Processor[In, Out]) flush() (err error) {
 		})
 	if err != nil {
 		self.Log.WithError(err).Error("Failed to flush data")
-		return
+		if self.isInterrupted(err) || self.errorSink == nil {
+			return
+		}
+
+		// Batch may fail because of a single item, only the failing ones go to the sink
+		out, err = self.flushSplit(data)
+		if err != nil {
+			return
+		}
 	}
 
 	if len(out) > 0 {
+		blockedStart := time.Now()
 		select {
 		case <-self.Ctx.Done():
 		case self.Output <- out:
+			self.metrics.itemsOut.Add(float64(len(out)))
 		}
+		self.metrics.observeBlocked(blockedStart)
 	}
 
 	return
 }
 
+// Flushes halves of the batch separately, without retries, until single items are left.
+// Items that fail on their own are passed to the error sink. Returns an error only when stopping.
+func (self *Processor[In, Out]) flushSplit(data []Out) (out []Out, err error) {
+	half := len(data) / 2
+	for _, part := range [][]Out{data[:half], data[half:]} {
+		if len(part) == 0 {
+			continue
+		}
+
+		partOut, partErr := self.onFlush(part)
+		if partErr == nil {
+			out = append(out, partOut...)
+			continue
+		}
+
+		if self.isInterrupted(partErr) {
+			return nil, partErr
+		}
+
+		if len(part) == 1 {
+			routeErrors(self.errorSink, self.Name, self.metrics, part, partErr)
+			continue
+		}
+
+		partOut, err = self.flushSplit(part)
+		if err != nil {
+			return
+		}
+		out = append(out, partOut...)
+	}
+	return
+}
+
 // Receives data from the input channel and saves in the database
 func (self *Processor[In, Out]) run() (err error) {
 	// Used to ensure data isn't stuck in Processor for too long
-	timer := time.NewTimer(self.flushInterval)
+	timer := time.NewTimer(time.Duration(self.flushInterval.Load()))
 
 	for {
 		select {
@@ -139,9 +211,13 @@ func (self *Processor[In, Out]) run() (err error) {
 				err = self.flush()
 				return
 			}
+			self.metrics.itemsIn.Inc()
 
 			data, err := self.onProcess(in)
 			if err != nil {
+				if !self.isInterrupted(err) {
+					routeErrors(self.errorSink, self.Name, self.metrics, []In{in}, err)
+				}
 				continue
 			}
 
@@ -150,7 +226,7 @@ func (self *Processor[In, Out]) run() (err error) {
 				self.queue.PushBack(d)
 			}
 
-			if self.queue.Len() >= self.batchSize {
+			if int64(self.queue.Len()) >= self.batchSize.Load() {
 				err = self.flush()
 				if err != nil {
 					return err
@@ -163,7 +239,7 @@ func (self *Processor[In, Out]) run() (err error) {
 			if err != nil {
 				return err
 			}
-			timer = time.NewTimer(self.flushInterval)
+			timer = time.NewTimer(time.Duration(self.flushInterval.Load()))
 		}
 	}
 }
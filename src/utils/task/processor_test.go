@@ -0,0 +1,35 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+type testErrorSink struct {
+	items []any
+}
+
+func (self *testErrorSink) OnError(stage string, item any, err error) {
+	self.items = append(self.items, item)
+}
+
+func TestFlushSplitDeadLettersOnlyFailingItems(t *testing.T) {
+	sink := new(testErrorSink)
+	processor := NewProcessor[int, int](config.Default(), "test").
+		WithErrorSink(sink).
+		WithOnFlush(0, func(data []int) ([]int, error) {
+			if slices.Contains(data, 3) || slices.Contains(data, 6) {
+				return nil, errors.New("bad item")
+			}
+			return data, nil
+		})
+
+	out, err := processor.flushSplit([]int{1, 2, 3, 4, 5, 6, 7})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 4, 5, 7}, out)
+	require.Equal(t, []any{3, 6}, sink.items)
+}
@@ -0,0 +1,179 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type State string
+
+const (
+	StateStarting State = "starting"
+	StateRunning  State = "running"
+	StateStopping State = "stopping"
+	StateStopped  State = "stopped"
+)
+
+// All started tasks, used to inspect the task tree while it's running
+var registry = &Registry{
+	tasks: make(map[uint64]*Task),
+}
+
+var lastTaskId atomic.Uint64
+
+type Registry struct {
+	mtx   sync.RWMutex
+	tasks map[uint64]*Task
+}
+
+// Snapshot of a single task
+type TaskInfo struct {
+	Id         uint64        `json:"id"`
+	Name       string        `json:"name"`
+	ParentId   uint64        `json:"parent_id,omitempty"`
+	ParentName string        `json:"parent_name,omitempty"`
+	State      State         `json:"state"`
+	Restarts   int64         `json:"restarts"`
+	Workers    *WorkersInfo  `json:"workers,omitempty"`
+	Channels   []ChannelInfo `json:"channels,omitempty"`
+}
+
+type WorkersInfo struct {
+	MaxWorkers      int     `json:"max_workers"`
+	MaxQueueSize    int     `json:"max_queue_size"`
+	QueueSize       int     `json:"queue_size"`
+	QueueFillFactor float32 `json:"queue_fill_factor"`
+}
+
+type ChannelInfo struct {
+	Name     string `json:"name"`
+	Length   int    `json:"length"`
+	Capacity int    `json:"capacity"`
+}
+
+// Named channel of the task, checked upon taking the snapshot
+type channelProbe struct {
+	name  string
+	probe func() (length, capacity int)
+}
+
+// Reads the field upon every check, so channels replaced after the probe is added are handled
+func ProbeChannel[T any](channel *chan T) func() (int, int) {
+	return func() (int, int) {
+		c := *channel
+		return len(c), cap(c)
+	}
+}
+
+func GetRegistry() *Registry {
+	return registry
+}
+
+func (self *Registry) register(task *Task) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+	self.tasks[task.id] = task
+}
+
+// Removes the task with all its subtasks
+func (self *Registry) unregister(task *Task) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	var remove func(t *Task)
+	remove = func(t *Task) {
+		delete(self.tasks, t.id)
+		for _, subtask := range t.subtasks {
+			remove(subtask)
+		}
+	}
+	remove(task)
+}
+
+// Current state of all registered tasks, ordered by creation
+func (self *Registry) GetTasks() (out []*TaskInfo) {
+	self.mtx.RLock()
+	tasks := make([]*Task, 0, len(self.tasks))
+	for _, task := range self.tasks {
+		tasks = append(tasks, task)
+	}
+	self.mtx.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].id < tasks[j].id
+	})
+
+	out = make([]*TaskInfo, 0, len(tasks))
+	for _, task := range tasks {
+		out = append(out, task.getInfo())
+	}
+	return
+}
+
+// Writes the task tree in the Graphviz format.
+// Channels and worker queues are shown in the labels, tasks with full queues are highlighted.
+func (self *Registry) WriteDot(w io.Writer) (err error) {
+	tasks := self.GetTasks()
+
+	var b strings.Builder
+	b.WriteString("digraph tasks {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	ids := make(map[uint64]struct{}, len(tasks))
+	for _, task := range tasks {
+		ids[task.Id] = struct{}{}
+		label := []string{
+			task.Name,
+			fmt.Sprintf("state: %s", task.State),
+		}
+		if task.Restarts > 0 {
+			label = append(label, fmt.Sprintf("restarts: %d", task.Restarts))
+		}
+		isFull := false
+		if task.Workers != nil {
+			label = append(label, fmt.Sprintf("workers: %d, queue: %d/%d", task.Workers.MaxWorkers, task.Workers.QueueSize, task.Workers.MaxQueueSize))
+			isFull = isFull || (task.Workers.MaxQueueSize > 0 && task.Workers.QueueSize >= task.Workers.MaxQueueSize)
+		}
+		for _, channel := range task.Channels {
+			label = append(label, fmt.Sprintf("%s: %d/%d", channel.Name, channel.Length, channel.Capacity))
+			isFull = isFull || (channel.Capacity > 0 && channel.Length >= channel.Capacity)
+		}
+
+		fmt.Fprintf(&b, "\tt%d [label=\"%s\", fillcolor=\"%s\"];\n", task.Id, escapeDot(strings.Join(label, "\n")), dotColor(task.State, isFull))
+	}
+
+	for _, task := range tasks {
+		if _, ok := ids[task.ParentId]; ok {
+			fmt.Fprintf(&b, "\tt%d -> t%d;\n", task.ParentId, task.Id)
+		}
+	}
+
+	b.WriteString("}\n")
+
+	_, err = io.WriteString(w, b.String())
+	return
+}
+
+func dotColor(state State, isFull bool) string {
+	switch {
+	case isFull:
+		return "orange"
+	case state == StateRunning:
+		return "palegreen"
+	case state == StateStopped:
+		return "lightgrey"
+	default:
+		return "lightyellow"
+	}
+}
+
+func escapeDot(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\"", "\\\"")
+	return strings.ReplaceAll(s, "\n", "\\n")
+}
@@ -0,0 +1,370 @@
+package task
+
+import (
+	"sync"
+	"time"
+
+	"github.com/warp-contracts/syncer/src/utils/config"
+
+	"github.com/gammazero/deque"
+	"golang.org/x/time/rate"
+)
+
+// Reorders items between the producer and the consumer:
+// - items are divided into lanes, each lane gets a share of the output proportional to its weight,
+// - within a lane items are grouped by key (e.g. contract id) and keys are served round robin,
+// - each key may be rate limited, so that one key can't take the whole output.
+type Scheduler[In any] struct {
+	*Task
+
+	input  chan In
+	Output chan In
+
+	// Classification of items
+	getLane func(In) int
+	getKey  func(In) string
+
+	// Queued items, guarded by mtx
+	mtx      sync.Mutex
+	cond     *sync.Cond
+	lanes    []*schedulerLane[In]
+	size     int
+	capacity int
+	isClosed bool
+
+	// Per key rate limiting, 0 means no limit
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+type schedulerLane[In any] struct {
+	weight  int
+	current int
+
+	// Keys served round robin
+	keys   []string
+	next   int
+	queues map[string]*deque.Deque[In]
+	size   int
+}
+
+func NewScheduler[In any](config *config.Config, name string) (self *Scheduler[In]) {
+	self = new(Scheduler[In])
+
+	self.Output = make(chan In)
+	self.cond = sync.NewCond(&self.mtx)
+	self.limiters = make(map[string]*rate.Limiter)
+	self.capacity = 1
+
+	self.getLane = func(In) int { return 0 }
+	self.getKey = func(In) string { return "" }
+	self.WithLaneWeights(1)
+
+	self.Task = NewTask(config, name).
+		WithSubtaskFunc(self.receive).
+		WithSubtaskFunc(self.send).
+		WithChannel("input", ProbeChannel(&self.input)).
+		WithChannel("output", ProbeChannel(&self.Output)).
+		WithOnStop(func() {
+			// Wake up waiting goroutines. Locking ensures none of them misses the stopping flag.
+			self.mtx.Lock()
+			self.mtx.Unlock()
+			self.cond.Broadcast()
+		}).
+		WithOnAfterStop(func() {
+			close(self.Output)
+		})
+
+	return
+}
+
+func (self *Scheduler[In]) WithInputChannel(v chan In) *Scheduler[In] {
+	self.input = v
+	return self
+}
+
+// Max number of items waiting in the scheduler. Receiving is blocked when it's full.
+func (self *Scheduler[In]) WithCapacity(v int) *Scheduler[In] {
+	if v < 1 {
+		v = 1
+	}
+	self.capacity = v
+	return self
+}
+
+// Weights of lanes. Lane with index 0 is the first weight.
+func (self *Scheduler[In]) WithLaneWeights(weights ...int) *Scheduler[In] {
+	self.lanes = make([]*schedulerLane[In], len(weights))
+	for i, weight := range weights {
+		if weight < 1 {
+			weight = 1
+		}
+		self.lanes[i] = &schedulerLane[In]{
+			weight: weight,
+			queues: make(map[string]*deque.Deque[In]),
+		}
+	}
+	return self
+}
+
+// Function returning the lane index of the item. Out of range values go to the last lane.
+func (self *Scheduler[In]) WithGetLane(f func(In) int) *Scheduler[In] {
+	self.getLane = f
+	return self
+}
+
+// Function returning the key items are grouped by
+func (self *Scheduler[In]) WithGetKey(f func(In) string) *Scheduler[In] {
+	self.getKey = f
+	return self
+}
+
+// Limits the number of items per second emitted for one key
+func (self *Scheduler[In]) WithKeyRateLimit(perSecond float64, burst int) *Scheduler[In] {
+	if perSecond <= 0 {
+		self.limit = 0
+		return self
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	self.limit = rate.Limit(perSecond)
+	self.burst = burst
+	return self
+}
+
+// Number of items waiting in the lane
+func (self *Scheduler[In]) GetLaneSize(lane int) int {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	if lane < 0 || lane >= len(self.lanes) {
+		return 0
+	}
+	return self.lanes[lane].size
+}
+
+func (self *Scheduler[In]) push(in In) {
+	lane := self.lanes[len(self.lanes)-1]
+	idx := self.getLane(in)
+	if idx >= 0 && idx < len(self.lanes) {
+		lane = self.lanes[idx]
+	}
+
+	key := self.getKey(in)
+	queue, ok := lane.queues[key]
+	if !ok {
+		queue = new(deque.Deque[In])
+		lane.queues[key] = queue
+		lane.keys = append(lane.keys, key)
+	}
+	queue.PushBack(in)
+
+	lane.size++
+	self.size++
+}
+
+// Takes the next item from the lane, skipping keys that are rate limited
+func (self *Scheduler[In]) popFromLane(lane *schedulerLane[In], now time.Time) (out In, ok bool) {
+	for i := 0; i < len(lane.keys); i++ {
+		idx := (lane.next + i) % len(lane.keys)
+		key := lane.keys[idx]
+
+		if !self.allow(key, now) {
+			continue
+		}
+
+		queue := lane.queues[key]
+		out = queue.PopFront()
+		lane.size--
+		self.size--
+
+		if queue.Len() == 0 {
+			// Forget the key, it will be added again with the next item
+			delete(lane.queues, key)
+			lane.keys = append(lane.keys[:idx], lane.keys[idx+1:]...)
+			lane.next = idx
+		} else {
+			lane.next = idx + 1
+		}
+		if len(lane.keys) > 0 {
+			lane.next %= len(lane.keys)
+		} else {
+			lane.next = 0
+		}
+
+		return out, true
+	}
+	return
+}
+
+func (self *Scheduler[In]) allow(key string, now time.Time) bool {
+	if self.limit == 0 {
+		return true
+	}
+
+	limiter, ok := self.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(self.limit, self.burst)
+		self.limiters[key] = limiter
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// Smooth weighted round robin between non empty lanes.
+// Returns false if there's nothing that could be sent right now.
+func (self *Scheduler[In]) pop() (out In, ok bool) {
+	now := time.Now()
+
+	// Lanes that can't emit right now are excluded from this round
+	excluded := make([]bool, len(self.lanes))
+
+	for {
+		var (
+			best  *schedulerLane[In]
+			total int
+		)
+		for i, lane := range self.lanes {
+			if lane.size == 0 || excluded[i] {
+				continue
+			}
+			lane.current += lane.weight
+			total += lane.weight
+			if best == nil || lane.current > best.current {
+				best = lane
+			}
+		}
+
+		if best == nil {
+			return
+		}
+		best.current -= total
+
+		out, ok = self.popFromLane(best, now)
+		if ok {
+			return
+		}
+
+		for i, lane := range self.lanes {
+			if lane == best {
+				excluded[i] = true
+			}
+		}
+	}
+}
+
+// Removes limiters of keys that don't have queued items and are fully replenished
+func (self *Scheduler[In]) cleanupLimiters(now time.Time) {
+	if self.limit == 0 || len(self.limiters) < 1000 {
+		return
+	}
+
+	for key, limiter := range self.limiters {
+		if limiter.TokensAt(now) < float64(self.burst) {
+			continue
+		}
+		isQueued := false
+		for _, lane := range self.lanes {
+			if _, isQueued = lane.queues[key]; isQueued {
+				break
+			}
+		}
+		if !isQueued {
+			delete(self.limiters, key)
+		}
+	}
+}
+
+func (self *Scheduler[In]) receive() error {
+	for {
+		var (
+			in In
+			ok bool
+		)
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case in, ok = <-self.input:
+		}
+
+		if !ok {
+			// Input closed, sender will finish after emptying the queue
+			self.mtx.Lock()
+			self.isClosed = true
+			self.mtx.Unlock()
+			self.cond.Broadcast()
+
+			<-self.Ctx.Done()
+			return nil
+		}
+
+		self.mtx.Lock()
+		for self.size >= self.capacity && !self.IsStopping.Load() {
+			self.cond.Wait()
+		}
+		if self.IsStopping.Load() {
+			self.mtx.Unlock()
+			return nil
+		}
+		self.push(in)
+		self.mtx.Unlock()
+
+		// Wake up sender
+		self.cond.Broadcast()
+	}
+}
+
+func (self *Scheduler[In]) send() error {
+	// Used to recheck rate limited keys
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	go func() {
+		for {
+			select {
+			case <-self.Ctx.Done():
+				return
+			case <-ticker.C:
+				if self.limit != 0 {
+					self.cond.Broadcast()
+				}
+			}
+		}
+	}()
+
+	for {
+		self.mtx.Lock()
+		var (
+			out In
+			ok  bool
+		)
+		for {
+			if self.IsStopping.Load() {
+				self.mtx.Unlock()
+				return nil
+			}
+			out, ok = self.pop()
+			if ok {
+				break
+			}
+			if self.isClosed && self.size == 0 {
+				// Input closed and everything was sent
+				self.mtx.Unlock()
+				<-self.Ctx.Done()
+				return nil
+			}
+			self.cond.Wait()
+		}
+		self.cleanupLimiters(time.Now())
+		self.mtx.Unlock()
+
+		// Wake up receiver, there's space in the queue
+		self.cond.Broadcast()
+
+		select {
+		case <-self.Ctx.Done():
+			return nil
+		case self.Output <- out:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package task
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/warp-contracts/syncer/src/utils/config"
+)
+
+type testScheduledItem struct {
+	lane int
+	key  string
+	idx  int
+}
+
+func (self testScheduledItem) String() string {
+	return fmt.Sprintf("%s%d", self.key, self.idx)
+}
+
+func newTestScheduler(weights ...int) *Scheduler[testScheduledItem] {
+	return NewScheduler[testScheduledItem](config.Default(), "test").
+		WithLaneWeights(weights...).
+		WithGetLane(func(item testScheduledItem) int { return item.lane }).
+		WithGetKey(func(item testScheduledItem) string { return item.key })
+}
+
+// Pops everything that can be sent right now
+func popAll(scheduler *Scheduler[testScheduledItem]) (out []string) {
+	for {
+		item, ok := scheduler.pop()
+		if !ok {
+			return
+		}
+		out = append(out, item.String())
+	}
+}
+
+func TestSchedulerLaneOrdering(t *testing.T) {
+	scheduler := newTestScheduler(4, 1)
+	for i := 0; i < 10; i++ {
+		scheduler.push(testScheduledItem{lane: 0, key: "h", idx: i})
+	}
+	for i := 0; i < 4; i++ {
+		scheduler.push(testScheduledItem{lane: 1, key: "n", idx: i})
+	}
+
+	// Out of range lane goes to the last lane
+	scheduler.push(testScheduledItem{lane: 7, key: "n", idx: 4})
+	require.Equal(t, 10, scheduler.GetLaneSize(0))
+	require.Equal(t, 5, scheduler.GetLaneSize(1))
+
+	// 4 high priority items for each normal one, normal lane isn't starved.
+	// Normal lane takes the rest once the high priority lane is empty.
+	require.Equal(t, []string{
+		"h0", "h1", "n0", "h2", "h3",
+		"h4", "h5", "n1", "h6", "h7",
+		"h8", "h9", "n2", "n3", "n4",
+	}, popAll(scheduler))
+	require.Equal(t, 0, scheduler.size)
+}
+
+func TestSchedulerPerKeyFairness(t *testing.T) {
+	scheduler := newTestScheduler(1)
+	for i := 0; i < 5; i++ {
+		scheduler.push(testScheduledItem{key: "a", idx: i})
+	}
+	for i := 0; i < 2; i++ {
+		scheduler.push(testScheduledItem{key: "b", idx: i})
+	}
+	scheduler.push(testScheduledItem{key: "c", idx: 0})
+
+	// Backlog of one contract doesn't delay the others, order within a contract is kept
+	require.Equal(t, []string{"a0", "b0", "c0", "a1", "b1", "a2", "a3", "a4"}, popAll(scheduler))
+
+	// Emptied keys are added again with their next item
+	scheduler.push(testScheduledItem{key: "a", idx: 5})
+	scheduler.push(testScheduledItem{key: "b", idx: 2})
+	scheduler.push(testScheduledItem{key: "a", idx: 6})
+	require.Equal(t, []string{"a5", "b2", "a6"}, popAll(scheduler))
+}
+
+func TestSchedulerKeyRateLimit(t *testing.T) {
+	scheduler := newTestScheduler(4, 1).WithKeyRateLimit(1, 2)
+	for i := 0; i < 4; i++ {
+		scheduler.push(testScheduledItem{lane: 0, key: "a", idx: i})
+	}
+	scheduler.push(testScheduledItem{lane: 1, key: "b", idx: 0})
+
+	// Rate limited key in the high priority lane doesn't block the normal lane
+	require.Equal(t, []string{"a0", "a1", "b0"}, popAll(scheduler))
+	require.Equal(t, 2, scheduler.GetLaneSize(0))
+
+	// Key gets new tokens with time
+	_, ok := scheduler.popFromLane(scheduler.lanes[0], time.Now().Add(time.Second))
+	require.True(t, ok)
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"math"
+	"sync/atomic"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
 
@@ -26,21 +27,27 @@ type SinkTask[In any] struct {
 	// Queue for the processed data
 	queue deque.Deque[In]
 
-	// Batch size that will trigger the onFlush function
-	batchSize int
+	// Batch size that will trigger the onFlush function, may change upon configuration reload
+	batchSize atomic.Int64
 
-	// Flush interval
-	flushInterval time.Duration
+	// Flush interval, may change upon configuration reload
+	flushInterval atomic.Int64
 
 	// Max time flush should be retried. 0 means no limit.
 	maxElapsedTime time.Duration
 
 	// Max times between flush retries
 	maxInterval time.Duration
+
+	// Optional, receives items that failed flushing
+	errorSink ErrorSink
+
+	metrics *stageMetrics
 }
 
 func NewSinkTask[In any](config *config.Config, name string) (self *SinkTask[In]) {
 	self = new(SinkTask[In])
+	self.metrics = newStageMetrics(config, name)
 
 	// Defaults
 	self.maxElapsedTime = 0
@@ -48,13 +55,14 @@ func NewSinkTask[In any](config *config.Config, name string) (self *SinkTask[In]
 
 	self.Task = NewTask(config, name).
 		WithSubtaskFunc(self.run).
+		WithChannel("input", ProbeChannel(&self.input)).
 		WithWorkerPool(1, 1)
 
 	return
 }
 
 func (self *SinkTask[In]) WithBatchSize(batchSize int) *SinkTask[In] {
-	self.batchSize = batchSize
+	self.batchSize.Store(int64(batchSize))
 	exp := uint(math.Round(math.Logb(float64(batchSize)))) + 1
 	self.queue.SetMinCapacity(exp)
 	return self
@@ -66,17 +74,32 @@ func (self *SinkTask[In]) WithInputChannel(v chan In) *SinkTask[In] {
 }
 
 func (self *SinkTask[In]) WithOnFlush(interval time.Duration, f func([]In) error) *SinkTask[In] {
-	self.flushInterval = interval
+	self.flushInterval.Store(int64(interval))
 	self.onFlush = f
 	return self
 }
 
+// Takes effect upon the next flush
+func (self *SinkTask[In]) SetBatchSize(batchSize int) {
+	self.batchSize.Store(int64(batchSize))
+}
+
+// Takes effect upon the next flush
+func (self *SinkTask[In]) SetFlushInterval(interval time.Duration) {
+	self.flushInterval.Store(int64(interval))
+}
+
 func (self *SinkTask[In]) WithBackoff(maxElapsedTime, maxInterval time.Duration) *SinkTask[In] {
 	self.maxElapsedTime = maxElapsedTime
 	self.maxInterval = maxInterval
 	return self
 }
 
+func (self *SinkTask[In]) WithErrorSink(v ErrorSink) *SinkTask[In] {
+	self.errorSink = v
+	return self
+}
+
 func (self *SinkTask[In]) flush() {
 	size := self.queue.Len()
 	data := make([]In, 0, size)
@@ -92,12 +115,19 @@ func (self *SinkTask[In]) flush() {
 		b.MaxElapsedTime = self.maxElapsedTime
 		b.MaxInterval = self.maxInterval
 
-		err := backoff.Retry(func() error {
+		start := time.Now()
+		err := backoff.RetryNotify(func() error {
 			return self.onFlush(data)
-		}, b)
+		}, b, func(error, time.Duration) {
+			self.metrics.flushRetries.Inc()
+		})
+		self.metrics.observeFlush(start, size)
 
 		if err != nil {
 			self.Log.WithError(err).Error("Failed to flush data to sink")
+			if !self.isInterrupted(err) {
+				routeErrors(self.errorSink, self.Name, self.metrics, data, err)
+			}
 		}
 	})
 }
@@ -105,7 +135,7 @@ func (self *SinkTask[In]) flush() {
 // Receives data from the input channel and saves in the database
 func (self *SinkTask[In]) run() (err error) {
 	// Used to ensure data isn't stuck in Processor for too long
-	timer := time.NewTimer(self.flushInterval)
+	timer := time.NewTimer(time.Duration(self.flushInterval.Load()))
 
 	for {
 		select {
@@ -117,17 +147,18 @@ func (self *SinkTask[In]) run() (err error) {
 
 				return
 			}
+			self.metrics.itemsIn.Inc()
 
 			self.queue.PushBack(in)
 
-			if self.queue.Len() >= self.batchSize {
+			if int64(self.queue.Len()) >= self.batchSize.Load() {
 				self.flush()
 			}
 
 		case <-timer.C:
 			// Flush is called even if the queue is empty
 			self.flush()
-			timer = time.NewTimer(self.flushInterval)
+			timer = time.NewTimer(time.Duration(self.flushInterval.Load()))
 		}
 	}
 }
@@ -21,6 +21,13 @@ type Task struct {
 	Log    *logrus.Entry
 	Name   string
 
+	// Introspection
+	id       uint64
+	parent   *Task
+	state    atomic.Value
+	restarts atomic.Int64
+	channels []channelProbe
+
 	// Stopping
 	IsStopping    *atomic.Bool
 	StopChannel   chan bool
@@ -42,6 +49,7 @@ type Task struct {
 	workers            *workerpool.WorkerPool
 	workerQueueCond    *sync.Cond
 	workerMaxQueueSize int
+	workerMaxWorkers   int
 
 	// Callbacks
 	onBeforeStart []func() error
@@ -59,6 +67,8 @@ func NewTask(config *config.Config, name string) (self *Task) {
 	self.Log = logger.NewSublogger(name)
 	self.Name = name
 	self.Config = config
+	self.id = lastTaskId.Add(1)
+	self.state.Store(StateStarting)
 
 	// Context cancelled when Stop() is called
 	self.Ctx, self.cancel = context.WithCancel(context.Background())
@@ -91,6 +101,19 @@ func (self *Task) WithOnStop(f func()) *Task {
 	return self
 }
 
+// Calls f with the new configuration upon configuration file change, as long as the task is running
+func (self *Task) WithOnConfigChange(f func(*config.Config)) *Task {
+	var cancel func()
+	return self.WithOnBeforeStart(func() error {
+		cancel = config.OnChange(f)
+		return nil
+	}).WithOnAfterStop(func() {
+		if cancel != nil {
+			cancel()
+		}
+	})
+}
+
 func (self *Task) WithEnable(v bool) *Task {
 	self.isEnabled = v
 	return self
@@ -121,6 +144,7 @@ func (self *Task) WithSubtask(t *Task) *Task {
 	}).WithOnAfterStop(func() {
 		self.stopWaitGroup.Done()
 	})
+	t.parent = self
 	self.subtasks = append(self.subtasks, t)
 	return self
 }
@@ -134,6 +158,7 @@ func (self *Task) WithSubtaskSlice(tasks []*Task) *Task {
 		}).WithOnAfterStop(func() {
 			self.stopWaitGroup.Done()
 		})
+		t.parent = self
 		self.subtasks = append(self.subtasks, t)
 	}
 	return self
@@ -222,6 +247,7 @@ func (self *Task) WithWorkerPool(maxWorkers int, maxQueueSize int) *Task {
 	var m sync.Mutex
 	self.workerQueueCond = sync.NewCond(&m)
 	self.workerMaxQueueSize = maxQueueSize
+	self.workerMaxWorkers = maxWorkers
 
 	// The pool
 	self.workers = workerpool.New(maxWorkers)
@@ -350,12 +376,15 @@ func (self *Task) run(subtask func() error) {
 			}
 
 			self.Log.WithError(err).Error("Subtask func returned, but task wasn't stopped. Restarting...")
+			self.restarts.Add(1)
 		}
 	}()
 }
 
 func (self *Task) Start() (err error) {
 	self.Log.Info("Starting...")
+	registry.register(self)
+
 	// Run callbacks
 	for _, cb := range self.onBeforeStart {
 		err = cb()
@@ -388,9 +417,13 @@ func (self *Task) Start() (err error) {
 		}
 
 		// Inform that task doesn't run anymore
+		self.state.Store(StateStopped)
 		self.cancelRunning()
 	}()
 
+	// Task may already be stopping
+	self.state.CompareAndSwap(StateStarting, StateRunning)
+
 	self.Log.Info("Started!")
 	return nil
 }
@@ -400,6 +433,8 @@ func (self *Task) Stop() {
 	self.stopOnce.Do(func() {
 		// Mark that we're stopping
 		self.IsStopping.Store(true)
+		self.state.CompareAndSwap(StateStarting, StateStopping)
+		self.state.CompareAndSwap(StateRunning, StateStopping)
 
 		// Stop subtasks
 		for _, subtask := range self.subtasks {
@@ -447,3 +482,49 @@ func (self *Task) WithStopChannel(channel chan struct{}) *Task {
 	})
 	return self
 }
+
+// Adds a channel whose occupancy is shown in the task registry
+func (self *Task) WithChannel(name string, probe func() (length, capacity int)) *Task {
+	self.channels = append(self.channels, channelProbe{name: name, probe: probe})
+	return self
+}
+
+// Parent of tasks that aren't subtasks, e.g. task restarted by a watchdog
+func (self *Task) withParent(parent *Task) *Task {
+	self.parent = parent
+	return self
+}
+
+func (self *Task) getInfo() (out *TaskInfo) {
+	out = &TaskInfo{
+		Id:       self.id,
+		Name:     self.Name,
+		State:    self.state.Load().(State),
+		Restarts: self.restarts.Load(),
+	}
+
+	if self.parent != nil {
+		out.ParentId = self.parent.id
+		out.ParentName = self.parent.Name
+	}
+
+	if self.workers != nil {
+		out.Workers = &WorkersInfo{
+			MaxWorkers:      self.workerMaxWorkers,
+			MaxQueueSize:    self.workerMaxQueueSize,
+			QueueSize:       self.workers.WaitingQueueSize(),
+			QueueFillFactor: self.GetWorkerQueueFillFactor(),
+		}
+	}
+
+	for _, channel := range self.channels {
+		length, capacity := channel.probe()
+		out.Channels = append(out.Channels, ChannelInfo{
+			Name:     channel.name,
+			Length:   length,
+			Capacity: capacity,
+		})
+	}
+
+	return
+}
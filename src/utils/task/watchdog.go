@@ -1,44 +1,152 @@
 package task
 
 import (
+	"math"
+	"sync"
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/config"
+	"github.com/warp-contracts/syncer/src/utils/monitoring/report"
 )
 
+// Restarts watched tasks when their health check fails.
+// Consecutive restarts are delayed with an exponential backoff, too many restarts within a window end the process.
+// Watched subtrees are restarted independently from each other.
+// Inactive watchdog (e.g. on a standby replica) doesn't run the watched tasks at all, see SetActive.
 type Watchdog struct {
 	*Task
 
-	constructor func() *Task
+	// Watched by the health check passed to WithIsOK
+	main *watched
+
+	// Independently restarted parts, see WithSubtree
+	subtrees []*watched
+
+	// Restart policy
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+	stableInterval  time.Duration
+	maxRestarts     int
+	restartWindow   time.Duration
 
-	watchedTask *Task
+	// Times of recent restarts of all watched tasks, used to enforce maxRestarts
+	mtx          sync.Mutex
+	restartTimes []time.Time
+
+	// Optional, restart history is reported here
+	runReport *report.RunReport
+
+	// Guards starting and stopping of watched tasks
+	runMtx    sync.Mutex
+	isActive  bool
+	isStarted bool
+}
+
+// Task restarted by the watchdog, recreated with the constructor
+type watched struct {
+	name        string
+	constructor func() *Task
+	task        *Task
 	isOK        func() bool
+
+	// Task was started at least once, it needs to be constructed again before the next start
+	isUsed    bool
+	isRunning bool
+
+	// Restarts without a stable period in between
+	consecutive int
+	lastRestart time.Time
 }
 
 func NewWatchdog(config *config.Config) (self *Watchdog) {
 	self = new(Watchdog)
+	self.main = new(watched)
+	self.isActive = true
+
+	self.initialInterval = config.Watchdog.BackoffInitialInterval
+	self.maxInterval = config.Watchdog.BackoffMaxInterval
+	self.multiplier = config.Watchdog.BackoffMultiplier
+	self.stableInterval = config.Watchdog.StableInterval
+	self.maxRestarts = config.Watchdog.MaxRestarts
+	self.restartWindow = config.Watchdog.RestartWindow
 
 	self.Task = NewTask(config, "watchdog").
-		WithPeriodicSubtaskFunc(10*time.Second, self.runPeriodic).
 		WithOnBeforeStart(func() error {
-			return self.watchedTask.Start()
+			self.runMtx.Lock()
+			defer self.runMtx.Unlock()
+
+			self.isStarted = true
+			if !self.isActive {
+				return nil
+			}
+			for _, w := range self.all() {
+				err := self.start(w)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
 		}).
 		WithOnStop(func() {
-			self.watchedTask.StopWait()
+			self.runMtx.Lock()
+			defer self.runMtx.Unlock()
+
+			self.isStarted = false
+			for _, w := range self.all() {
+				self.stop(w)
+			}
 		})
 
 	return
 }
 
 func (self *Watchdog) WithTask(f func() *Task) *Watchdog {
-	self.constructor = f
-	self.watchedTask = f()
+	self.main.constructor = f
+	self.main.task = f().withParent(self.Task)
+	self.main.name = self.main.task.Name
 	return self
 }
 
 func (self *Watchdog) WithIsOK(interval time.Duration, isOK func() bool) *Watchdog {
-	self.isOK = isOK
-	self.Task.WithPeriodicSubtaskFunc(interval, self.runPeriodic)
+	self.main.isOK = isOK
+	self.Task.WithPeriodicSubtaskFunc(interval, func() error {
+		return self.check(self.main)
+	})
+	return self
+}
+
+// Part of the watched tree with its own health check, restarted without touching the rest
+func (self *Watchdog) WithSubtree(interval time.Duration, f func() *Task, isOK func() bool) *Watchdog {
+	w := &watched{
+		constructor: f,
+		task:        f().withParent(self.Task),
+		isOK:        isOK,
+	}
+	w.name = w.task.Name
+	self.subtrees = append(self.subtrees, w)
+
+	self.Task.WithPeriodicSubtaskFunc(interval, func() error {
+		return self.check(w)
+	})
+	return self
+}
+
+func (self *Watchdog) WithBackoff(initialInterval, maxInterval time.Duration) *Watchdog {
+	self.initialInterval = initialInterval
+	self.maxInterval = maxInterval
+	return self
+}
+
+// Process exits after more than maxRestarts within the window. 0 means no limit
+func (self *Watchdog) WithMaxRestarts(maxRestarts int, window time.Duration) *Watchdog {
+	self.maxRestarts = maxRestarts
+	self.restartWindow = window
+	return self
+}
+
+func (self *Watchdog) WithRunReport(v *report.RunReport) *Watchdog {
+	self.runReport = v
 	return self
 }
 
@@ -47,27 +155,188 @@ func (self *Watchdog) WithOnAfterStop(onAfterStop func()) *Watchdog {
 	return self
 }
 
-func (self *Watchdog) runPeriodic() error {
-	if self.isOK() {
+// Watched tasks don't run until SetActive(true), e.g. until this replica gets elected the leader
+func (self *Watchdog) WithStandby() *Watchdog {
+	self.isActive = false
+	return self
+}
+
+// Starts or stops all watched tasks, e.g. upon gaining or losing leadership.
+// Inactive watchdog doesn't restart anything
+func (self *Watchdog) SetActive(isActive bool) {
+	self.runMtx.Lock()
+	defer self.runMtx.Unlock()
+
+	if self.isActive == isActive {
+		return
+	}
+	self.isActive = isActive
+
+	if !self.isStarted {
+		// Watched tasks will start along with the watchdog
+		return
+	}
+
+	for _, w := range self.all() {
+		if !isActive {
+			self.Log.WithField("task", w.name).Info("Watchdog deactivated, stopping watched task")
+			self.stop(w)
+			continue
+		}
+
+		// Fresh start, previous restarts don't count
+		w.consecutive = 0
+		self.Log.WithField("task", w.name).Info("Watchdog activated, starting watched task")
+		err := self.start(w)
+		if err != nil {
+			self.Log.WithError(err).WithField("task", w.name).Error("Failed to start watched task")
+		}
+	}
+	self.setBackoffUntil(time.Time{})
+}
+
+// Constructs the task again if it already ran
+func (self *Watchdog) start(w *watched) error {
+	if w.isUsed {
+		registry.unregister(w.task)
+		w.task = w.constructor().withParent(self.Task)
+	}
+	w.isUsed = true
+	w.isRunning = true
+	return w.task.Start()
+}
+
+func (self *Watchdog) stop(w *watched) {
+	if !w.isRunning {
+		return
+	}
+	// Leader election relies on the task stopping within StopTimeout
+	w.task.WithStopTimeout(self.Config.StopTimeout).StopWait()
+	w.isRunning = false
+}
+
+func (self *Watchdog) all() []*watched {
+	if self.main.task == nil {
+		return self.subtrees
+	}
+	return append([]*watched{self.main}, self.subtrees...)
+}
+
+func (self *Watchdog) check(w *watched) error {
+	self.runMtx.Lock()
+	defer self.runMtx.Unlock()
+
+	if !self.isActive || !self.isStarted {
 		return nil
 	}
-	self.Log.Warn("Watched task is not running, restarting")
-	return self.Restart()
+
+	if w.isOK() {
+		if w.consecutive > 0 && time.Since(w.lastRestart) >= self.stableInterval {
+			// Task is stable again
+			w.consecutive = 0
+			self.setBackoffUntil(time.Time{})
+		}
+		return nil
+	}
+
+	if delay := self.getDelay(w); time.Since(w.lastRestart) < delay {
+		self.Log.WithField("task", w.name).
+			WithField("next_restart_in", time.Until(w.lastRestart.Add(delay)).Round(time.Second)).
+			Warn("Watched task is not running, waiting before restart")
+		return nil
+	}
+
+	self.Log.WithField("task", w.name).Warn("Watched task is not running, restarting")
+	return self.restart(w)
 }
 
+// Time that needs to pass since the last restart
+func (self *Watchdog) getDelay(w *watched) time.Duration {
+	if w.consecutive == 0 {
+		return 0
+	}
+	delay := float64(self.initialInterval) * math.Pow(self.multiplier, float64(w.consecutive-1))
+	if delay > float64(self.maxInterval) {
+		return self.maxInterval
+	}
+	return time.Duration(delay)
+}
+
+// Remembers the restart, exits the process if there were too many of them
+func (self *Watchdog) countRestart(now time.Time) {
+	self.mtx.Lock()
+	defer self.mtx.Unlock()
+
+	// Forget restarts outside of the window
+	i := 0
+	for i < len(self.restartTimes) && now.Sub(self.restartTimes[i]) > self.restartWindow {
+		i++
+	}
+	self.restartTimes = append(self.restartTimes[i:], now)
+
+	if self.maxRestarts > 0 && len(self.restartTimes) > self.maxRestarts {
+		self.Log.WithField("restarts", len(self.restartTimes)).
+			WithField("window", self.restartWindow).
+			Fatal("Too many restarts of watched tasks, exiting")
+	}
+}
+
+func (self *Watchdog) setBackoffUntil(t time.Time) {
+	if self.runReport == nil {
+		return
+	}
+	if t.IsZero() {
+		self.runReport.State.WatchdogBackoffUntil.Store(0)
+		return
+	}
+	self.runReport.State.WatchdogBackoffUntil.Store(t.Unix())
+}
+
+// Restarts the whole watched tree, regardless of the backoff. Does nothing if the watchdog is inactive
 func (self *Watchdog) Restart() (err error) {
-	self.Log.Warn("Restarting watched task, first stopping it")
-	self.watchedTask.StopWait()
+	self.runMtx.Lock()
+	defer self.runMtx.Unlock()
+
+	if !self.isActive || !self.isStarted {
+		return nil
+	}
+	return self.restart(self.main)
+}
+
+func (self *Watchdog) restart(w *watched) (err error) {
+	now := time.Now()
+	self.countRestart(now)
+
+	w.consecutive++
+	w.lastRestart = now
+	self.setBackoffUntil(now.Add(self.getDelay(w)))
+
+	defer func() {
+		if self.runReport == nil {
+			return
+		}
+		self.runReport.Errors.NumWatchdogRestarts.Inc()
+		restart := report.Restart{
+			Timestamp:   now.Unix(),
+			Task:        w.name,
+			Consecutive: w.consecutive,
+		}
+		if err != nil {
+			restart.Error = err.Error()
+		}
+		self.runReport.State.WatchdogRestarts.Add(restart)
+	}()
 
-	self.Log.Warn("Watched task stopped, constructing again")
-	self.watchedTask = self.constructor()
+	self.Log.WithField("task", w.name).Warn("Restarting watched task, first stopping it")
+	self.stop(w)
 
-	self.Log.Warn("Watched task recreated, starting")
-	err = self.watchedTask.Start()
+	self.Log.WithField("task", w.name).Warn("Watched task stopped, constructing again and starting")
+	self.restarts.Add(1)
+	err = self.start(w)
 	if err != nil {
-		self.Log.WithError(err).Error("Failed to restart watched task")
+		self.Log.WithError(err).WithField("task", w.name).Error("Failed to restart watched task")
 		return
 	}
-	self.Log.Warn("Watched task started")
+	self.Log.WithField("task", w.name).Warn("Watched task started")
 	return
 }
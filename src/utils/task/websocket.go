@@ -49,8 +49,11 @@ func NewWebsocket[T any](config *config.Config, name string) (self *Websocket[T]
 			WithOnStop(self.disconnect)
 	}
 
+	// Reconnecting is expected, it's done quickly and never ends the process
 	self.Watchdog = NewWatchdog(config).
 		WithTask(watched).
+		WithBackoff(time.Second, time.Minute).
+		WithMaxRestarts(0, 0).
 		WithOnAfterStop(func() {
 			close(self.Output)
 		})
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Embedded in payloads passed through channels between tasks.
+// Each stage starts its span as a child of the previous stage's span, so the whole path of a payload is a single trace.
+type Carrier struct {
+	spanContext trace.SpanContext
+}
+
+// Starts a span that's a child of the carried span, or a new trace if nothing is carried yet.
+// Carried span is replaced with the new one, so the next stage becomes its child.
+func (self *Carrier) StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if self.spanContext.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, self.spanContext)
+	}
+	ctx, span := Tracer().Start(ctx, name, opts...)
+	self.spanContext = span.SpanContext()
+	return ctx, span
+}
+
+// Continues the trace of another payload, used when a payload is converted into another one
+func (self *Carrier) InheritFrom(other *Carrier) {
+	self.spanContext = other.spanContext
+}
+
+// Continues the trace from the context's span
+func (self *Carrier) SetFromContext(ctx context.Context) {
+	self.spanContext = trace.SpanContextFromContext(ctx)
+}
+
+// Link to the carried span, used by spans that handle many payloads at once (e.g. batch flushes)
+func (self *Carrier) Link() trace.Link {
+	return trace.Link{SpanContext: self.spanContext}
+}
+
+// Links to the carried spans, skips payloads that weren't traced
+func Links(carriers ...*Carrier) (out []trace.Link) {
+	for _, carrier := range carriers {
+		if carrier == nil || !carrier.spanContext.IsValid() {
+			continue
+		}
+		out = append(out, carrier.Link())
+	}
+	return
+}
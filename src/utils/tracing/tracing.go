@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/warp-contracts/syncer/src/utils/build_info"
+	"github.com/warp-contracts/syncer/src/utils/config"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/warp-contracts/syncer"
+
+var provider *sdktrace.TracerProvider
+
+// Sets up exporting spans to the OTLP collector.
+// Without calling it (or when tracing is disabled) the global no-op provider is used and spans cost nothing.
+func Init(config *config.Config) (err error) {
+	if !config.Tracing.Enabled {
+		return
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(config.Tracing.Endpoint),
+		otlptracehttp.WithURLPath(config.Tracing.UrlPath),
+		otlptracehttp.WithTimeout(config.Tracing.ExportTimeout),
+	}
+	if config.Tracing.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", config.Tracing.ServiceName),
+		attribute.String("service.version", build_info.Version),
+	))
+	if err != nil {
+		return
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Tracing.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return
+}
+
+// Exports remaining spans
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Starts a span that's a child of the span in the context, if there's any
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// Marks the span as failed, does nothing if there's no error
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Ends the span, marks it as failed upon error
+func End(span trace.Span, err error) {
+	SetError(span, err)
+	span.End()
+}
+
+// Outgoing HTTP requests become child spans of the span in the request's context.
+// Base transport is used as is when tracing is disabled.
+func NewTransport(base *http.Transport) http.RoundTripper {
+	if provider == nil {
+		return base
+	}
+	return &transport{
+		RoundTripper: otelhttp.NewTransport(base),
+		base:         base,
+	}
+}
+
+// Keeps http.Client.CloseIdleConnections working with the wrapped transport
+type transport struct {
+	http.RoundTripper
+	base *http.Transport
+}
+
+func (self *transport) CloseIdleConnections() {
+	self.base.CloseIdleConnections()
+}
@@ -0,0 +1,6 @@
+package responses
+
+type Balance struct {
+	// Balance in winc
+	Winc string `json:"winc"`
+}
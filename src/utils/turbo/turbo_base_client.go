@@ -11,8 +11,10 @@ import (
 	"time"
 
 	"github.com/warp-contracts/syncer/src/utils/build_info"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/logger"
+	"github.com/warp-contracts/syncer/src/utils/tracing"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
@@ -79,9 +81,10 @@ func (self *BaseClient) Reset() {
 			SetTimeout(self.config.RequestTimeout).
 			SetHeader("User-Agent", "warp.cc/bundle/"+build_info.Version).
 			SetRetryCount(1).
-			SetTransport(self.createTransport()).
+			SetTransport(tracing.NewTransport(self.createTransport())).
 			AddRetryCondition(self.onRetryCondition).
 			OnBeforeRequest(self.onRateLimit).
+			SetPreRequestHook(bundlr.OnStreamBodyPreRequest).
 			// NOTE: Trace logs, used only when debugging. Needs to be before other OnAfterResponse callbacks
 			// EnableTrace().
 			// OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
@@ -2,8 +2,8 @@ package turbo
 
 import (
 	"context"
-	"io"
 	"net/http"
+	"strings"
 
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
@@ -23,21 +23,11 @@ func NewClient(ctx context.Context, config *config.Bundlr) (self *Client) {
 }
 
 func (self *Client) Upload(ctx context.Context, item *bundlr.BundleItem) (out *responses.Upload, resp *resty.Response, err error) {
-	reader, err := item.Reader()
-	if err != nil {
-		return
-	}
-
-	body, err := io.ReadAll(reader)
-	if err != nil {
-		return
-	}
-
 	req, cancel := self.Request(ctx)
 	defer cancel()
 
-	resp, err = req.
-		SetBody(body).
+	// Item is streamed, memory usage doesn't depend on its size
+	resp, err = bundlr.SetStreamBody(req, item).
 		SetResult(&responses.Upload{}).
 		ForceContentType("application/json").
 		SetHeader("Content-Type", "application/octet-stream").
@@ -93,3 +83,26 @@ func (self *Client) GetStatus(ctx context.Context, id string) (out *responses.St
 
 	return
 }
+
+// Balance (in winc) of the given Arweave address, checked in the payment service
+func (self *Client) GetBalance(ctx context.Context, address string) (out int64, err error) {
+	req, cancel := self.Request(ctx)
+	defer cancel()
+
+	resp, err := req.
+		SetResult(&responses.Balance{}).
+		ForceContentType("application/json").
+		SetQueryParam("address", address).
+		Get(strings.TrimSuffix(self.config.TurboPaymentUrl, "/") + "/v1/account/balance/arweave")
+	if err != nil {
+		return
+	}
+
+	balance, ok := resp.Result().(*responses.Balance)
+	if !ok {
+		err = bundlr.ErrFailedToParse
+		return
+	}
+
+	return bundlr.ParseWinc(balance.Winc)
+}
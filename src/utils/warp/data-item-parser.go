@@ -1,7 +1,6 @@
 package warp
 
 import (
-	"crypto/sha256"
 	"encoding/json"
 
 	"github.com/jackc/pgtype"
@@ -12,7 +11,6 @@ import (
 	"github.com/warp-contracts/syncer/src/utils/model"
 	"github.com/warp-contracts/syncer/src/utils/smartweave"
 
-	"github.com/dvsekhvalnov/jose2go/base64url"
 	"github.com/sirupsen/logrus"
 )
 
@@ -98,13 +96,10 @@ func (self *DataItemParser) Parse(tx *bundlr.BundleItem, blockHeight int64, bloc
 }
 
 func GetWalletAddressFromBundle(tx *bundlr.BundleItem) (owner string, err error) {
-	// The n value is the public modulus and is used as the transaction owner field,
-	// and the address of a wallet is a Base64URL encoded SHA-256 hash of the n value from the JWK.
+	// Address format depends on the signature type, e.g. for Arweave it's a Base64URL encoded SHA-256 hash of the n value from the JWK.
+	// Arweave and Ethereum items keep the SHA-256 based address they always had.
 	// https://docs.arweave.org/developers/server/http-api#addressing
-	h := sha256.New()
-	h.Write([]byte(tx.Owner))
-	owner = base64url.Encode(h.Sum(nil))
-	return
+	return bundlr.GetAddress(tx.SignatureType, tx.Owner)
 }
 
 func (self *DataItemParser) parseTags(tags []bundlr.Tag) (out []smartweave.Tag) {
@@ -78,13 +78,8 @@ func GetWalletToDiscordIdMap(httpClient *resty.Client, url string, addresses *[]
 	return
 }
 
-func WriteInteractionToWarpy(ctx context.Context, config config.WarpySyncer, input json.Marshaler, log *logrus.Entry, sequencerClient *sequencer.Client, apiKey string) (interactionId string, err error) {
-	signer, err := bundlr.NewArweaveSigner(config.SyncerSigner)
-	if err != nil {
-		log.WithError(err).Error("Could not create Arweave Signer")
-		return
-	}
-
+// Signer is created once by the caller, remote signers fetch their owner upon creation
+func WriteInteractionToWarpy(ctx context.Context, config config.WarpySyncer, input json.Marshaler, log *logrus.Entry, sequencerClient *sequencer.Client, signer bundlr.Signer, apiKey string) (interactionId string, err error) {
 	interactionId, err = sequencerClient.UploadInteraction(
 		ctx,
 		input,
@@ -91,7 +91,12 @@ func NewController(config *config.Config) (self *Controller, err error) {
 			WithInputChannel(blockDownloader.Output)
 
 		// Writes interaction to Warpy
-		writer := NewWriter(config).
+		var writer *Writer
+		writer, err = NewWriter(config)
+		if err != nil {
+			return
+		}
+		writer.
 			WithInputChannel(syncer.OutputInteractionPayload).
 			WithMonitor(monitor).
 			WithSequencerClient(sequencerClient)
@@ -102,6 +107,10 @@ func NewController(config *config.Config) (self *Controller, err error) {
 	case eth.Sommelier, eth.LayerBank, eth.Pendle, eth.Venus, eth.ListaDAO, eth.YeiFinance, eth.ZeroLend:
 		var contractAbi map[string]*abi.ABI
 		contractAbi, err = ContractAbiFromMap(config)
+		if err != nil {
+			self.Log.WithError(err).Error("Could not get contract Abi")
+			return
+		}
 
 		// to be removed in prod
 		// if config.WarpySyncer.SyncerProtocol == eth.ZeroLend {
@@ -132,7 +141,12 @@ func NewController(config *config.Config) (self *Controller, err error) {
 			WithAddressesToPoll(addressesJoined)
 
 		// Writes interaction to Warpy based on the records from the poller
-		writer := NewWriter(config).
+		var writer *Writer
+		writer, err = NewWriter(config)
+		if err != nil {
+			return
+		}
+		writer.
 			WithInputChannel(poller.Output).
 			WithMonitor(monitor).
 			WithSequencerClient(sequencerClient)
@@ -159,11 +173,6 @@ func NewController(config *config.Config) (self *Controller, err error) {
 		return
 	}
 
-	if err != nil {
-		self.Log.WithError(err).Error("Could not get contract Abi")
-		return
-	}
-
 	// Periodically stores last synced block height in the database
 	store := NewStore(config).
 		WithInputChannel(syncerOutput).
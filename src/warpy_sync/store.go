@@ -34,7 +34,7 @@ func NewStore(config *config.Config) (self *Store) {
 		WithBatchSize(config.WarpySyncer.StoreBatchSize).
 		WithOnFlush(config.WarpySyncer.StoreInterval, self.flush).
 		WithOnProcess(self.process).
-		WithBackoff(0, config.WarpySyncer.StoreMaxBackoffInterval)
+		WithBackoff(config.WarpySyncer.StoreMaxBackoffElapsedTime, config.WarpySyncer.StoreMaxBackoffInterval)
 
 	return
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cenkalti/backoff"
 	"github.com/go-resty/resty/v2"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
 	"github.com/warp-contracts/syncer/src/utils/config"
 	"github.com/warp-contracts/syncer/src/utils/monitoring"
 	"github.com/warp-contracts/syncer/src/utils/sequencer"
@@ -26,9 +27,10 @@ type Writer struct {
 	input           <-chan *[]InteractionPayload
 	sequencerClient *sequencer.Client
 	httpClient      *resty.Client
+	signer          bundlr.Signer
 }
 
-func NewWriter(config *config.Config) (self *Writer) {
+func NewWriter(config *config.Config) (self *Writer, err error) {
 	self = new(Writer)
 
 	self.httpClient = resty.New().
@@ -38,6 +40,13 @@ func NewWriter(config *config.Config) (self *Writer) {
 		WithSubtaskFunc(self.run).
 		WithWorkerPool(config.Evolver.DownloaderNumWorkers, config.Evolver.DownloaderWorkerQueueSize)
 
+	// Signs every interaction written to Warpy
+	self.signer, err = bundlr.NewSigner(config.WarpySyncer.SyncerSigner, bundlr.SignatureTypeArweave)
+	if err != nil {
+		self.Log.WithError(err).Error("Could not create Arweave Signer")
+		return
+	}
+
 	return
 }
 
@@ -163,7 +172,7 @@ func (self *Writer) sendInteractionChunk(interactions *[]InteractionPayload, cap
 
 	if !errorOccured {
 		interactionId, err := warpy.WriteInteractionToWarpy(
-			self.Ctx, self.Config.WarpySyncer, input, self.Log, self.sequencerClient, self.Config.WarpySyncer.WriterApiKey)
+			self.Ctx, self.Config.WarpySyncer, input, self.Log, self.sequencerClient, self.signer, self.Config.WarpySyncer.WriterApiKey)
 		if err != nil {
 			self.saveTxToFile(input, folderForTxs, true, txInternalId)
 			return err